@@ -5,6 +5,7 @@ import (
 	"io"
 	"runtime/debug"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -51,13 +52,27 @@ type BaseApp struct {
 	txDecoder   sdk.TxDecoder        // unmarshal []byte into sdk.Tx
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
+	storeLoader StoreLoader     // loads the latest version of the main state
+
+	// minimum gas prices, in each denom, of txs accepted in CheckTx
+	minGasPrices sdk.DecCoins
+
+	// state-sync snapshots, taken every snapshotInterval blocks if non zero,
+	// keeping the snapshotKeepRecent latest ones if non zero
+	snapshots          *store.SnapshotStore
+	snapshotInterval   int64
+	snapshotKeepRecent int
+	snapshotMtx        sync.Mutex     // serializes the snapshots and their pruning
+	snapshotWG         sync.WaitGroup // tracks the snapshots taken in the background
 
 	// may be nil
-	initChainer      sdk.InitChainer  // initialize state with validators and state blob
-	beginBlocker     sdk.BeginBlocker // logic to run before any txs
-	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
-	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
-	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	initChainer         sdk.InitChainer         // initialize state with validators and state blob
+	memStoreInitializer sdk.MemStoreInitializer // rebuild the memory stores when loading
+	upgrader            sdk.Upgrader            // perform the scheduled upgrades before the blocks
+	beginBlocker        sdk.BeginBlocker        // logic to run before any txs
+	endBlocker          sdk.EndBlocker          // logic to run after all txs, and to determine valset changes
+	addrPeerFilter      sdk.PeerFilter          // filter peers by address and port
+	pubkeyPeerFilter    sdk.PeerFilter          // filter peers by public key
 
 	//--------------------
 	// Volatile
@@ -93,6 +108,7 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		queryRouter: NewQueryRouter(),
 		codespacer:  sdk.NewCodespacer(),
 		txDecoder:   txDecoder,
+		storeLoader: DefaultStoreLoader,
 	}
 
 	// Register the undefined & root codespaces, which should not be used by
@@ -115,6 +131,17 @@ func (app *BaseApp) SetCommitMultiStoreTracer(w io.Writer) {
 	app.cms.WithTracer(w)
 }
 
+// AddStreamingListener adds a listener receiving the change set of the
+// deliver state of each committed block. It panics if the underlying
+// CommitMultiStore doesn't support streaming.
+func (app *BaseApp) AddStreamingListener(listener store.StreamingListener) {
+	cms, ok := app.cms.(store.Listenable)
+	if !ok {
+		panic("multistore doesn't support streaming")
+	}
+	cms.AddListener(listener)
+}
+
 // Register the next available codespace through the baseapp's codespacer, starting from a default
 func (app *BaseApp) RegisterCodespace(codespace sdk.CodespaceType) sdk.CodespaceType {
 	return app.codespacer.RegisterNext(codespace)
@@ -127,6 +154,19 @@ func (app *BaseApp) MountStoresIAVL(keys ...*sdk.KVStoreKey) {
 	}
 }
 
+// SetInterBlockCache keeps at most size values of each of the mounted IAVL
+// stores of the keys in a cache persisting across blocks. It must be called
+// before loading the stores.
+func (app *BaseApp) SetInterBlockCache(size int, keys ...*sdk.KVStoreKey) {
+	cms, ok := app.cms.(store.InterBlockCacher)
+	if !ok {
+		panic("multistore doesn't support inter-block caching")
+	}
+	for _, key := range keys {
+		cms.SetInterBlockCache(key, size)
+	}
+}
+
 // Mount a store to the provided key in the BaseApp multistore, using a specified DB
 func (app *BaseApp) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm.DB) {
 	app.cms.MountStoreWithDB(key, typ, db)
@@ -137,9 +177,28 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
-// load latest application version
+// StoreLoader loads the latest version of the multistore of an app, it is
+// replaced to apply store upgrades.
+type StoreLoader func(ms sdk.CommitMultiStore) error
+
+// DefaultStoreLoader loads the latest version of the multistore as is.
+func DefaultStoreLoader(ms sdk.CommitMultiStore) error {
+	return ms.LoadLatestVersion()
+}
+
+// StoreLoaderWithUpgrade returns a StoreLoader applying the store upgrades
+// when loading the latest version. It is set by the binary started at the
+// height of the upgrade mounting the new stores, so every node applies them
+// at the same height.
+func StoreLoaderWithUpgrade(upgrades *sdk.StoreUpgrades) StoreLoader {
+	return func(ms sdk.CommitMultiStore) error {
+		return ms.LoadLatestVersionAndUpgrade(upgrades)
+	}
+}
+
+// load latest application version with the store loader
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
-	err := app.cms.LoadLatestVersion()
+	err := app.storeLoader(app.cms)
 	if err != nil {
 		return err
 	}
@@ -173,6 +232,9 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+
+	app.initMemStores()
+
 	// Needed for `gaiad export`, which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
 
@@ -181,33 +243,60 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	return nil
 }
 
+// initMemStores has the modules rebuild the memory stores from the loaded
+// state, as they are empty whenever a version is loaded.
+func (app *BaseApp) initMemStores() {
+	if app.memStoreInitializer == nil {
+		return
+	}
+	msCache := app.cms.CacheMultiStore()
+	ctx := sdk.NewContext(msCache, abci.Header{Height: app.LastBlockHeight()}, false, app.Logger)
+	app.memStoreInitializer(ctx)
+	msCache.Write()
+}
+
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	if isCheckTx {
-		return sdk.NewContext(app.checkState.ms, header, true, app.Logger)
+		return sdk.NewContext(app.checkState.ms, header, true, app.Logger).
+			WithMinGasPrices(app.minGasPrices)
 	}
 	return sdk.NewContext(app.deliverState.ms, header, false, app.Logger)
 }
 
 type state struct {
-	ms  sdk.CacheMultiStore
-	ctx sdk.Context
+	ms      sdk.CacheMultiStore
+	ctx     sdk.Context
+	txCount int64
 }
 
 func (st *state) CacheMultiStore() sdk.CacheMultiStore {
 	return st.ms.CacheMultiStore()
 }
 
+// setTxIndex annotates the changes streamed from the state with the index of
+// the tx in the block, -1 outside of the txs.
+func (st *state) setTxIndex(txIndex int64) {
+	if ms, ok := st.ms.(store.ListeningCacheMultiStore); ok {
+		ms.SetTxIndex(txIndex)
+	}
+}
+
 func (app *BaseApp) setCheckState(header abci.Header) {
 	ms := app.cms.CacheMultiStore()
 	app.checkState = &state{
 		ms:  ms,
-		ctx: sdk.NewContext(ms, header, true, app.Logger),
+		ctx: sdk.NewContext(ms, header, true, app.Logger).WithMinGasPrices(app.minGasPrices),
 	}
 }
 
+// setDeliverState sets the deliver state, whose changes are streamed to the
+// listeners of the CommitMultiStore. The check state is never streamed.
 func (app *BaseApp) setDeliverState(header abci.Header) {
 	ms := app.cms.CacheMultiStore()
+	if cms, ok := app.cms.(store.Listenable); ok && cms.ListeningEnabled() {
+		ms = cms.ListeningCacheMultiStore()
+	}
 	app.deliverState = &state{
 		ms:  ms,
 		ctx: sdk.NewContext(ms, header, false, app.Logger),
@@ -414,6 +503,15 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// refuse to process the block if the upgrade scheduled at its height
+	// can't be performed, the node must be restarted with a binary which can
+	if app.upgrader != nil {
+		if err := app.upgrader(app.deliverState.ctx); err != nil {
+			app.Logger.Error("halting before block", "height", req.Header.Height, "err", err)
+			panic(err)
+		}
+	}
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
@@ -457,8 +555,11 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 	if err != nil {
 		result = err.Result()
 	} else {
+		app.deliverState.setTxIndex(app.deliverState.txCount)
 		result = app.runTx(runTxModeDeliver, txBytes, tx)
+		app.deliverState.setTxIndex(-1)
 	}
+	app.deliverState.txCount++
 
 	// Even though the Result.Code is not OK, there are still effects,
 	// namely fee deductions and sequence incrementing.
@@ -679,6 +780,13 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		"commit", commitID,
 	)
 
+	if app.snapshotInterval > 0 && commitID.Version%app.snapshotInterval == 0 {
+		// the snapshot reads the committed version, not the state of the
+		// following blocks, so it doesn't hold up the chain
+		app.snapshotWG.Add(1)
+		go app.snapshot(commitID.Version)
+	}
+
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 )
@@ -132,6 +134,114 @@ func TestLoadVersion(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(2), commitID2)
 }
 
+func TestMemStoreInitializer(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	capKey := sdk.NewKVStoreKey("main")
+	memKey := sdk.NewMemoryStoreKey("memory")
+	key := []byte("key")
+
+	// the memory store indexes the value of the main store
+	newApp := func() *BaseApp {
+		app := NewBaseApp(name, logger, db, nil)
+		app.MountStoresIAVL(capKey)
+		app.MountStore(memKey, sdk.StoreTypeMemory)
+		app.SetMemStoreInitializer(func(ctx sdk.Context) {
+			value := ctx.KVStore(capKey).Get(key)
+			if value != nil {
+				ctx.MemoryStore(memKey).Set(value, key)
+			}
+		})
+		err := app.LoadLatestVersion(capKey)
+		require.Nil(t, err)
+		return app
+	}
+
+	app := newApp()
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := app.deliverState.ctx
+	ctx.KVStore(capKey).Set(key, []byte("value"))
+	ctx.MemoryStore(memKey).Set([]byte("value"), key)
+	res := app.Commit()
+
+	// the memory store survives the blocks without changing the app hash
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.Equal(t, key, app.deliverState.ctx.MemoryStore(memKey).Get([]byte("value")))
+	app.Commit()
+
+	// and is rebuilt on restart
+	app = newApp()
+	require.Equal(t, key, app.cms.GetKVStore(memKey).Get([]byte("value")))
+
+	// the memory store isn't part of the state
+	app = NewBaseApp(name, logger, dbm.NewMemDB(), nil)
+	app.MountStoresIAVL(capKey)
+	require.Nil(t, app.LoadLatestVersion(capKey))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.deliverState.ctx.KVStore(capKey).Set(key, []byte("value"))
+	require.Equal(t, res.Data, app.Commit().Data)
+}
+
+func TestUpgrader(t *testing.T) {
+	haltHeight := int64(2)
+	beginBlocks := 0
+	app := setupBaseApp(t, func(app *BaseApp) {
+		app.SetUpgrader(func(ctx sdk.Context) error {
+			if ctx.BlockHeight() >= haltHeight {
+				return fmt.Errorf("upgrade needed")
+			}
+			return nil
+		})
+		app.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			beginBlocks++
+			return abci.ResponseBeginBlock{}
+		})
+	})
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.Commit()
+	require.Equal(t, 1, beginBlocks)
+
+	// the block at the upgrade height is refused before the begin blocker
+	require.Panics(t, func() {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: haltHeight}})
+	})
+	require.Equal(t, 1, beginBlocks)
+}
+
+func TestStoreLoaderWithUpgrade(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	capKey := sdk.NewKVStoreKey("main")
+	app := NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey)
+	require.Nil(t, app.LoadLatestVersion(capKey))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.Commit()
+
+	// a new store cannot be mounted without upgrading the stores
+	newKey := sdk.NewKVStoreKey("new")
+	app = NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey, newKey)
+	require.NotNil(t, app.LoadLatestVersion(capKey))
+
+	app = NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey, newKey)
+	app.SetStoreLoader(StoreLoaderWithUpgrade(&sdk.StoreUpgrades{Added: []string{"new"}}))
+	require.Nil(t, app.LoadLatestVersion(capKey))
+	require.Equal(t, int64(1), app.LastBlockHeight())
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	app.deliverState.ctx.KVStore(newKey).Set([]byte("key"), []byte("value"))
+	res := app.Commit()
+
+	// the next version has the new store
+	app = NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey, newKey)
+	require.Nil(t, app.LoadLatestVersion(capKey))
+	testLoadVersionHelper(t, app, int64(2), sdk.CommitID{2, res.Data})
+}
+
 func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, expectedID sdk.CommitID) {
 	lastHeight := app.LastBlockHeight()
 	lastID := app.LastCommitID()
@@ -139,6 +249,53 @@ func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, exp
 	require.Equal(t, expectedID, lastID)
 }
 
+func TestSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	logger := defaultLogger()
+	capKey := sdk.NewKVStoreKey("main")
+	app := NewBaseApp(t.Name(), logger, dbm.NewMemDB(), nil, SetSnapshots(dir, 2, 2))
+	app.MountStoresIAVL(capKey)
+	require.Nil(t, app.LoadLatestVersion(capKey))
+
+	// a snapshot is taken every 2 blocks, keeping the 2 latest ones
+	for height := int64(1); height <= 6; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey).Set([]byte("height"), []byte{byte(height)})
+		app.Commit()
+	}
+	app.snapshotWG.Wait()
+	snapshots, err := app.Snapshots()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, int64(4), snapshots[0].Height)
+	require.Equal(t, int64(6), snapshots[1].Height)
+
+	// an empty app restores the snapshot, rebuilding its memory stores
+	memKey := sdk.NewMemoryStoreKey("memory")
+	restored := NewBaseApp(t.Name(), logger, dbm.NewMemDB(), nil, SetSnapshots(dir, 2, 2))
+	restored.MountStoresIAVL(capKey)
+	restored.MountStore(memKey, sdk.StoreTypeMemory)
+	restored.SetMemStoreInitializer(func(ctx sdk.Context) {
+		if value := ctx.KVStore(capKey).Get([]byte("height")); value != nil {
+			ctx.MemoryStore(memKey).Set([]byte("height"), value)
+		}
+	})
+	require.Nil(t, restored.LoadLatestVersion(capKey))
+	require.NotNil(t, restored.RestoreSnapshot(6, []byte("untrusted")))
+	require.Nil(t, restored.RestoreSnapshot(6, app.LastCommitID().Hash))
+	testLoadVersionHelper(t, restored, int64(6), app.LastCommitID())
+	require.Equal(t, []byte{6}, restored.cms.GetKVStore(memKey).Get([]byte("height")))
+	require.NotNil(t, app.RestoreSnapshot(6, app.LastCommitID().Hash))
+
+	// snapshots must be enabled
+	app = setupBaseApp(t)
+	_, err = app.CreateSnapshot()
+	require.NotNil(t, err)
+}
+
 func TestOptionFunction(t *testing.T) {
 	logger := defaultLogger()
 	db := dbm.NewMemDB()
@@ -146,6 +303,17 @@ func TestOptionFunction(t *testing.T) {
 	require.Equal(t, bap.name, "new name", "BaseApp should have had name changed via option function")
 }
 
+func TestSetMinGasPrices(t *testing.T) {
+	minGasPrices := sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(25, 3))}
+	app := setupBaseApp(t, SetMinGasPrices("0.025steak"))
+
+	// the minimum gas prices only apply to CheckTx
+	require.Equal(t, minGasPrices, app.checkState.ctx.MinGasPrices())
+	require.Equal(t, minGasPrices, app.NewContext(true, abci.Header{}).MinGasPrices())
+
+	require.Panics(t, func() { SetMinGasPrices("0.025") })
+}
+
 func testChangeNameHelper(name string) func(*BaseApp) {
 	return func(bap *BaseApp) {
 		bap.name = name
@@ -509,6 +677,65 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+type changeSetRecorder struct {
+	changeSets []store.BlockChangeSet
+}
+
+func (r *changeSetRecorder) ListenCommit(changeSet store.BlockChangeSet) error {
+	r.changeSets = append(r.changeSets, changeSet)
+	return nil
+}
+
+// Test that the changes of the deliver state are streamed with the index of
+// their tx, unlike those of the check state.
+func TestStreaming(t *testing.T) {
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) { bapp.Router().AddRoute(typeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)) }
+	listener := &changeSetRecorder{}
+	streamingOpt := func(bapp *BaseApp) { bapp.AddStreamingListener(listener) }
+
+	app := setupBaseApp(t, anteOpt, routerOpt, streamingOpt)
+
+	codec := wire.NewCodec()
+	registerTestCodec(codec)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	txBytes, err := codec.MarshalBinary(newTxCounter(0, 0))
+	require.NoError(t, err)
+	require.True(t, app.CheckTx(txBytes).IsOK())
+	for i := int64(0); i < 2; i++ {
+		txBytes, err := codec.MarshalBinary(newTxCounter(i, i))
+		require.NoError(t, err)
+		res := app.DeliverTx(txBytes)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	}
+	app.deliverState.ctx.KVStore(capKey2).Set([]byte("end"), []byte("block"))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	require.Equal(t, 1, len(listener.changeSets))
+	changeSet := listener.changeSets[0]
+	require.Equal(t, int64(1), changeSet.Height)
+	type change struct {
+		storeKey string
+		txIndex  int64
+		key      string
+	}
+	var changes []change
+	for _, pair := range changeSet.Changes {
+		changes = append(changes, change{pair.StoreKey, pair.TxIndex, string(pair.Key)})
+	}
+	require.Equal(t, []change{
+		{"key1", 0, "ante-key"},
+		{"key1", 0, "deliver-key"},
+		{"key1", 1, "ante-key"},
+		{"key1", 1, "deliver-key"},
+		{"key2", -1, "end"},
+	}, changes)
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -3,26 +3,46 @@ package baseapp
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // File for storing in-package BaseApp optional functions,
 // for options that need access to non-exported fields of the BaseApp
 
-// SetPruning sets a pruning option on the multistore associated with the app
-func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("Invalid pruning strategy: %s", pruning))
+// SetPruning sets a pruning strategy on the multistore associated with the app
+func SetPruning(pruning sdk.PruningStrategy) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.cms.SetPruning(pruning)
+	}
+}
+
+// SetMinGasPrices sets the minimum gas prices, in each denom, of the txs
+// accepted in CheckTx. Txs are not checked against them in DeliverTx.
+func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
+	gasPrices, err := sdk.ParseDecCoins(gasPricesStr)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid minimum gas prices: %v", err))
+	}
+	return func(bap *BaseApp) {
+		bap.minGasPrices = gasPrices
+	}
+}
+
+// SetSnapshots sets the directory the state-sync snapshots of the app are kept
+// in. A snapshot is taken every interval blocks, keeping the keepRecent latest
+// ones, or all of them if keepRecent is 0. An interval of 0 disables the
+// periodic snapshots.
+func SetSnapshots(dir string, interval int64, keepRecent int) func(*BaseApp) {
+	if interval < 0 {
+		panic(fmt.Sprintf("Invalid snapshot interval: %d", interval))
+	}
+	if keepRecent < 0 {
+		panic(fmt.Sprintf("Invalid number of snapshots to keep: %d", keepRecent))
 	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.snapshots = store.NewSnapshotStore(dir)
+		bap.snapshotInterval = interval
+		bap.snapshotKeepRecent = keepRecent
 	}
 }
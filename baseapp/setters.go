@@ -26,12 +26,30 @@ func (app *BaseApp) SetCMS(cms store.CommitMultiStore) {
 	}
 	app.cms = cms
 }
+func (app *BaseApp) SetStoreLoader(loader StoreLoader) {
+	if app.sealed {
+		panic("SetStoreLoader() on sealed BaseApp")
+	}
+	app.storeLoader = loader
+}
 func (app *BaseApp) SetInitChainer(initChainer sdk.InitChainer) {
 	if app.sealed {
 		panic("SetInitChainer() on sealed BaseApp")
 	}
 	app.initChainer = initChainer
 }
+func (app *BaseApp) SetMemStoreInitializer(memStoreInitializer sdk.MemStoreInitializer) {
+	if app.sealed {
+		panic("SetMemStoreInitializer() on sealed BaseApp")
+	}
+	app.memStoreInitializer = memStoreInitializer
+}
+func (app *BaseApp) SetUpgrader(upgrader sdk.Upgrader) {
+	if app.sealed {
+		panic("SetUpgrader() on sealed BaseApp")
+	}
+	app.upgrader = upgrader
+}
 func (app *BaseApp) SetBeginBlocker(beginBlocker sdk.BeginBlocker) {
 	if app.sealed {
 		panic("SetBeginBlocker() on sealed BaseApp")
@@ -0,0 +1,84 @@
+package baseapp
+
+import (
+	"github.com/pkg/errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// Snapshots returns the state-sync snapshots of the app, from the oldest.
+func (app *BaseApp) Snapshots() ([]store.SnapshotManifest, error) {
+	if app.snapshots == nil {
+		return nil, errors.New("snapshots are not enabled")
+	}
+	return app.snapshots.List()
+}
+
+// CreateSnapshot takes a snapshot of the last committed state of the app.
+func (app *BaseApp) CreateSnapshot() (store.SnapshotManifest, error) {
+	return app.createSnapshot(app.LastBlockHeight())
+}
+
+// createSnapshot takes a snapshot of the committed state of height, one
+// snapshot at a time.
+func (app *BaseApp) createSnapshot(height int64) (store.SnapshotManifest, error) {
+	snapshotter, err := app.snapshotter()
+	if err != nil {
+		return store.SnapshotManifest{}, err
+	}
+	app.snapshotMtx.Lock()
+	defer app.snapshotMtx.Unlock()
+	return snapshotter.Snapshot(app.snapshots, height)
+}
+
+// RestoreSnapshot restores the state of an empty app from its snapshot at
+// height, which must match the trusted app hash of the block.
+func (app *BaseApp) RestoreSnapshot(height int64, appHash []byte) error {
+	snapshotter, err := app.snapshotter()
+	if err != nil {
+		return err
+	}
+	err = snapshotter.Restore(app.snapshots, height, appHash)
+	if err != nil {
+		return err
+	}
+	app.initMemStores()
+	app.setCheckState(abci.Header{})
+	return nil
+}
+
+func (app *BaseApp) snapshotter() (store.Snapshotter, error) {
+	if app.snapshots == nil {
+		return nil, errors.New("snapshots are not enabled")
+	}
+	snapshotter, ok := app.cms.(store.Snapshotter)
+	if !ok {
+		return nil, errors.New("multistore doesn't support snapshots")
+	}
+	return snapshotter, nil
+}
+
+// snapshot takes the periodic snapshot of height and prunes the old ones,
+// unless all are kept. It runs in the background of the following blocks,
+// so failures are logged, as they don't affect the state of the app.
+func (app *BaseApp) snapshot(height int64) {
+	defer app.snapshotWG.Done()
+	manifest, err := app.createSnapshot(height)
+	if err != nil {
+		app.Logger.Error("Failed to take snapshot", "err", err)
+		return
+	}
+	app.Logger.Info("Took snapshot", "height", manifest.Height, "chunks", len(manifest.Chunks))
+	if app.snapshotKeepRecent == 0 {
+		return
+	}
+
+	app.snapshotMtx.Lock()
+	err = app.snapshots.Prune(app.snapshotKeepRecent)
+	app.snapshotMtx.Unlock()
+	if err != nil {
+		app.Logger.Error("Failed to prune snapshots", "err", err)
+	}
+}
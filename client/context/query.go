@@ -1,6 +1,7 @@
 package context
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -309,12 +310,12 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 		return res, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
 	}
 
-	// Data from trusted node or subspace query doesn't need verification
+	// Data from trusted node doesn't need verification
 	if ctx.TrustNode || !isQueryStoreWithProof(path) {
 		return resp.Value, nil
 	}
 
-	err = ctx.verifyProof(path, resp)
+	err = ctx.verifyProof(path, key, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -322,8 +323,24 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 	return resp.Value, nil
 }
 
-// verifyProof perform response proof verification
-func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
+// verifyProof perform response proof verification of the query of key at
+// path. The proof must be about the key and store queried, as the untrusted
+// node could otherwise prove an entry of its choice.
+func (ctx CLIContext) verifyProof(path string, key cmn.HexBytes, resp abci.ResponseQuery) error {
+	if !bytes.Equal(resp.Key, key) {
+		return fmt.Errorf("response key %X doesn't match the queried key %X", resp.Key, key)
+	}
+
+	var multiStoreProof store.MultiStoreProof
+	cdc := wire.NewCodec()
+	err := cdc.UnmarshalBinary(resp.Proof, &multiStoreProof)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshalBinary rangeProof")
+	}
+	storeName := strings.SplitN(path[1:], "/", 3)[1]
+	if multiStoreProof.StoreName != storeName {
+		return fmt.Errorf("proof of store %s doesn't match the queried store %s", multiStoreProof.StoreName, storeName)
+	}
 
 	if ctx.Certifier == nil {
 		return fmt.Errorf("missing valid certifier to verify data from untrusted node")
@@ -340,19 +357,28 @@ func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
 		return err
 	}
 
-	var multiStoreProof store.MultiStoreProof
-	cdc := wire.NewCodec()
-	err = cdc.UnmarshalBinary(resp.Proof, &multiStoreProof)
-	if err != nil {
-		return errors.Wrap(err, "failed to unmarshalBinary rangeProof")
-	}
-
 	// Verify the substore commit hash against trusted appHash
 	substoreCommitHash, err := store.VerifyMultiStoreCommitInfo(multiStoreProof.StoreName,
 		multiStoreProof.StoreInfos, commit.Header.AppHash)
 	if err != nil {
 		return errors.Wrap(err, "failed in verifying the proof against appHash")
 	}
+
+	// A subspace query returns all the entries of the subspace, the proof
+	// must show that none is missing
+	if strings.HasSuffix(path, "/subspace") {
+		var kvs []store.KVPair
+		err = cdc.UnmarshalBinary(resp.Value, &kvs)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshalBinary subspace entries")
+		}
+		err = store.VerifySubspaceProof(resp.Key, kvs, substoreCommitHash, &multiStoreProof.RangeProof)
+		if err != nil {
+			return errors.Wrap(err, "failed in the subspace range proof verification")
+		}
+		return nil
+	}
+
 	err = store.VerifyRangeProof(resp.Key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
 	if err != nil {
 		return errors.Wrap(err, "failed in the range proof verification")
@@ -0,0 +1,33 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func TestVerifyProofMismatch(t *testing.T) {
+	proof, err := wire.NewCodec().MarshalBinary(store.MultiStoreProof{StoreName: "acc"})
+	require.Nil(t, err)
+	resp := abci.ResponseQuery{Key: []byte("key"), Proof: proof, Height: 1}
+	ctx := CLIContext{}
+
+	// the proof must be about the queried key
+	err = ctx.verifyProof("/store/acc/key", []byte("other"), resp)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "queried key")
+
+	// and store
+	err = ctx.verifyProof("/store/stake/key", []byte("key"), resp)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "queried store")
+
+	// a matching proof is then verified against the certified app hash
+	err = ctx.verifyProof("/store/acc/key", []byte("key"), resp)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "certifier")
+}
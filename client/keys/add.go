@@ -1,10 +1,12 @@
 package keys
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/gorilla/mux"
@@ -14,7 +16,9 @@ import (
 
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cli"
 )
 
@@ -25,6 +29,9 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+
+	flagMultisig          = "multisig"
+	flagMultisigThreshold = "multisig-threshold"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -33,7 +40,10 @@ func addKeyCommand() *cobra.Command {
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
 If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+phrase, otherwise, a new key will be generated.
+
+With --multisig, an offline multisig public key is stored instead, made of the
+given keys and requiring --multisig-threshold of them to sign.`,
 		RunE: runAddCmd,
 	}
 	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
@@ -43,6 +53,8 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().StringSlice(flagMultisig, nil, "Construct and store a multisig public key from the comma-separated names of existing keys")
+	cmd.Flags().Uint(flagMultisigThreshold, 1, "Number of keys of the multisig public key required to sign")
 	return cmd
 }
 
@@ -79,6 +91,13 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		// store a multisig public key made of existing keys, no password
+		// is needed as there is no private key
+		multisigKeys := viper.GetStringSlice(flagMultisig)
+		if len(multisigKeys) != 0 {
+			return createMultisigKey(kb, name, multisigKeys, viper.GetInt(flagMultisigThreshold))
+		}
+
 		// ask for a password when generating a local key
 		if !viper.GetBool(client.FlagUseLedger) {
 			pass, err = client.GetCheckPassword(
@@ -124,6 +143,38 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// createMultisigKey stores an offline multisig public key made of the keys of
+// the given names, sorted by address so that the resulting address does not
+// depend on the order in which they are given.
+func createMultisigKey(kb keys.Keybase, name string, keyNames []string, threshold int) error {
+	if threshold <= 0 || threshold > len(keyNames) {
+		return errors.Errorf("threshold must be between 1 and the number of keys (%d)", len(keyNames))
+	}
+
+	pks := make([]crypto.PubKey, len(keyNames))
+	for i, keyName := range keyNames {
+		info, err := kb.Get(keyName)
+		if err != nil {
+			return err
+		}
+		pks[i] = info.GetPubKey()
+	}
+	sort.Slice(pks, func(i, j int) bool {
+		return bytes.Compare(pks[i].Address(), pks[j].Address()) < 0
+	})
+
+	pk := multisig.NewPubKeyMultisigThreshold(threshold, pks)
+	info, err := kb.CreateOffline(name, pk)
+	if err != nil {
+		return err
+	}
+
+	// there is no seed phrase to print
+	viper.Set(flagNoBackup, true)
+	printCreate(info, "")
+	return nil
+}
+
 func printCreate(info keys.Info, seed string) {
 	output := viper.Get(cli.OutputFlag)
 	switch output {
@@ -822,6 +822,7 @@ func doIBCTransfer(t *testing.T, port, seed, name, password string, addr sdk.Acc
 		"account_number":"%d",
 		"sequence": "%d",
 		"src_chain_id": "%s",
+		"timeout_height": "1000",
 		"amount":[
 			{
 				"denom": "%s",
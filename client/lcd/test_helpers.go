@@ -158,7 +158,7 @@ func InitializeTestLCD(t *testing.T, nValidators int, initAddrs []sdk.AccAddress
 		pk := gdValidator.PubKey
 		validatorsPKs = append(validatorsPKs, pk)
 
-		appGenTx, _, _, err := gapp.GaiaAppGenTxNF(cdc, pk, sdk.AccAddress(pk.Address()), "test_val1")
+		appGenTx, _, _, err := gapp.GaiaAppGenTxNF(cdc, pk, sdk.AccAddress(pk.Address()), "test_val1", gapp.DefaultGenesisCommission())
 		require.NoError(t, err)
 
 		appGenTxs = append(appGenTxs, appGenTx)
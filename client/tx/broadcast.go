@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/spf13/cobra"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
 )
 
 // Tx Broadcast Body
@@ -35,3 +38,30 @@ func BroadcastTxRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		w.Write([]byte(string(res.Height)))
 	}
 }
+
+// BroadcastTxCmd returns the command to broadcast a signed transaction, e.g.
+// one produced by the sign or multisign commands.
+func BroadcastTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast [file]",
+		Short: "Broadcast transactions generated offline",
+		Long:  `Broadcast a signed transaction, read as JSON from the given file.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			txBytes, err := cdc.MarshalBinary(stdTx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			return cliCtx.EnsureBroadcastTx(txBytes)
+		},
+	}
+
+	return cmd
+}
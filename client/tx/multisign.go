@@ -0,0 +1,113 @@
+package tx
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+)
+
+// MultiSignTxCmd returns the command to merge the signatures of the members of
+// a multisig key into a single multisignature.
+func MultiSignTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisign [file] [name] [[signature]...]",
+		Short: "Generate multisig signatures for transactions generated offline",
+		Long: `Merge the signatures produced with 'sign --multisig' by the members of the
+multisig key of the given name, and print the transaction generated with
+--generate-only with the resulting multisignature appended.`,
+		Args: cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			keybase, err := keys.GetKeyBase()
+			if err != nil {
+				return err
+			}
+
+			info, err := keybase.Get(args[1])
+			if err != nil {
+				return err
+			}
+
+			multisigPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+			if !ok {
+				return errors.Errorf("%q must be of type offline multisig", args[1])
+			}
+
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			signMsg, err := buildStdSignMsg(txCtx, cliCtx, sdk.AccAddress(multisigPub.Address()), stdTx)
+			if err != nil {
+				return err
+			}
+
+			multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+			for _, filename := range args[2:] {
+				sig, err := readStdSignatureFromFile(cdc, filename)
+				if err != nil {
+					return err
+				}
+
+				if !sig.PubKey.VerifyBytes(signMsg.Bytes(), sig.Signature) {
+					return errors.Errorf("invalid signature in %s", filename)
+				}
+
+				err = multisigSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !multisigPub.VerifyBytes(signMsg.Bytes(), multisigSig.Marshal()) {
+				return errors.Errorf("need at least %d valid signatures, got %d",
+					multisigPub.K, len(multisigSig.Sigs))
+			}
+
+			newStdSig := auth.StdSignature{
+				PubKey:        multisigPub,
+				Signature:     multisigSig.Marshal(),
+				AccountNumber: signMsg.AccountNumber,
+				Sequence:      signMsg.Sequence,
+			}
+			signedTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, append(stdTx.GetSignatures(), newStdSig), stdTx.GetMemo())
+			signedTx.FeeGranter = stdTx.FeeGranter
+
+			output, err := wire.MarshalJSONIndent(cdc, signedTx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// readStdSignatureFromFile reads a signature generated with 'sign --multisig'.
+func readStdSignatureFromFile(cdc *wire.Codec, filename string) (sig auth.StdSignature, err error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalJSON(bz, &sig)
+	return
+}
@@ -2,12 +2,145 @@ package tx
 
 import (
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
 	keybase "github.com/cosmos/cosmos-sdk/client/keys"
 	keys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+)
+
+const (
+	flagMultisig      = "multisig"
+	flagSignatureOnly = "signature-only"
 )
 
+// SignTxCmd returns the command to sign a transaction generated with
+// --generate-only, with the key given by --from.
+func SignTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [file]",
+		Short: "Sign transactions generated offline",
+		Long: `Sign a transaction created with the --generate-only flag and print it with
+the new signature appended. With --signature-only, only the signature is
+printed. When signing on behalf of a multisig account given by --multisig,
+only the signature is printed, to be merged with the other signatures by the
+multisign command.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			// the account which signs the transaction, which is not the
+			// signing key when signing for a multisig account
+			var addr sdk.AccAddress
+			multisigAddr := viper.GetString(flagMultisig)
+			if multisigAddr != "" {
+				addr, err = sdk.AccAddressFromBech32(multisigAddr)
+			} else {
+				addr, err = cliCtx.GetFromAddress()
+			}
+			if err != nil {
+				return err
+			}
+
+			signMsg, err := buildStdSignMsg(txCtx, cliCtx, addr, stdTx)
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := keybase.GetPassphrase(cliCtx.FromAddressName)
+			if err != nil {
+				return err
+			}
+
+			sig, err := txCtx.MakeSignature(cliCtx.FromAddressName, passphrase, signMsg)
+			if err != nil {
+				return err
+			}
+
+			var output []byte
+			if multisigAddr != "" || viper.GetBool(flagSignatureOnly) {
+				output, err = wire.MarshalJSONIndent(cdc, sig)
+			} else {
+				signedTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, append(stdTx.GetSignatures(), sig), stdTx.GetMemo())
+				signedTx.FeeGranter = stdTx.FeeGranter
+				output, err = wire.MarshalJSONIndent(cdc, signedTx)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagMultisig, "", "Address of the multisig account on behalf of which the transaction is signed")
+	cmd.Flags().Bool(flagSignatureOnly, false, "Print only the generated signature")
+	return cmd
+}
+
+// readStdTxFromFile reads a transaction generated with --generate-only.
+func readStdTxFromFile(cdc *wire.Codec, filename string) (stdTx auth.StdTx, err error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalJSON(bz, &stdTx)
+	return
+}
+
+// buildStdSignMsg returns the message the signer of the given address signs
+// for stdTx. The account number and sequence are queried unless provided.
+func buildStdSignMsg(txCtx authctx.TxContext, cliCtx context.CLIContext, addr sdk.AccAddress, stdTx auth.StdTx) (auth.StdSignMsg, error) {
+	if txCtx.ChainID == "" {
+		return auth.StdSignMsg{}, fmt.Errorf("chain ID required but not specified")
+	}
+
+	if txCtx.AccountNumber == 0 {
+		accNum, err := cliCtx.GetAccountNumber(addr)
+		if err != nil {
+			return auth.StdSignMsg{}, err
+		}
+		txCtx = txCtx.WithAccountNumber(accNum)
+	}
+
+	if txCtx.Sequence == 0 {
+		accSeq, err := cliCtx.GetAccountSequence(addr)
+		if err != nil {
+			return auth.StdSignMsg{}, err
+		}
+		txCtx = txCtx.WithSequence(accSeq)
+	}
+
+	return auth.StdSignMsg{
+		ChainID:       txCtx.ChainID,
+		AccountNumber: txCtx.AccountNumber,
+		Sequence:      txCtx.Sequence,
+		Fee:           stdTx.Fee,
+		Msgs:          stdTx.GetMsgs(),
+		Memo:          stdTx.GetMemo(),
+		FeeGranter:    stdTx.FeeGranter,
+	}, nil
+}
+
 // REST request body for signed txs
 // TODO does this need to be exposed?
 type SignTxBody struct {
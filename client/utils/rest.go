@@ -58,7 +58,9 @@ func WriteGenerateStdTxResponse(w http.ResponseWriter, txCtx authctx.TxContext,
 		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	output, err := txCtx.Codec.MarshalJSON(auth.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo))
+	stdTx := auth.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo)
+	stdTx.FeeGranter = stdMsg.FeeGranter
+	output, err := txCtx.Codec.MarshalJSON(stdTx)
 	if err != nil {
 		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -161,5 +161,7 @@ func buildUnsignedStdTx(txCtx authctx.TxContext, cliCtx context.CLIContext, msgs
 	if err != nil {
 		return
 	}
-	return auth.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo), nil
+	stdTx = auth.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo)
+	stdTx.FeeGranter = stdSignMsg.FeeGranter
+	return stdTx, nil
 }
@@ -15,16 +15,23 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
 	appName = "GaiaApp"
+
+	// number of values of the account and stake stores kept across blocks
+	interBlockCacheSize = 10000
 )
 
 // default home directories for expected binaries
@@ -44,7 +51,11 @@ type GaiaApp struct {
 	keyIBC           *sdk.KVStoreKey
 	keyStake         *sdk.KVStoreKey
 	keySlashing      *sdk.KVStoreKey
+	keyDistr         *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keyFeeGrant      *sdk.KVStoreKey
+	keyAuthz         *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
 	keyParams        *sdk.KVStoreKey
 	tkeyParams       *sdk.TransientStoreKey
@@ -56,7 +67,11 @@ type GaiaApp struct {
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
+	distrKeeper         distr.Keeper
 	govKeeper           gov.Keeper
+	upgradeKeeper       upgrade.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	authzKeeper         authz.Keeper
 	paramsKeeper        params.Keeper
 }
 
@@ -75,7 +90,11 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		keyIBC:           sdk.NewKVStoreKey("ibc"),
 		keyStake:         sdk.NewKVStoreKey("stake"),
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
+		keyDistr:         sdk.NewKVStoreKey("distr"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
+		keyFeeGrant:      sdk.NewKVStoreKey("feegrant"),
+		keyAuthz:         sdk.NewKVStoreKey("authz"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
 		keyParams:        sdk.NewKVStoreKey("params"),
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
@@ -91,31 +110,58 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	// add handlers
 	app.coinKeeper = bank.NewKeeper(app.accountMapper)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	ibcTransferApp := ibc.NewTransferApp(app.ibcMapper, app.coinKeeper)
+	ibcRouter := ibc.NewRouter().
+		AddRoute(ibc.TransferPort, ibcTransferApp)
 	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
 	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
 	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
-	app.stakeKeeper = app.stakeKeeper.WithValidatorHooks(app.slashingKeeper.ValidatorHooks())
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
-	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
+	app.distrKeeper = distr.NewKeeper(app.cdc, app.keyDistr, app.paramsKeeper.Getter(), app.coinKeeper, app.stakeKeeper, app.feeCollectionKeeper, app.RegisterCodespace(distr.DefaultCodespace))
+	app.stakeKeeper = app.stakeKeeper.
+		WithValidatorHooks(stake.NewMultiValidatorHooks(app.slashingKeeper.ValidatorHooks(), app.distrKeeper.ValidatorHooks())).
+		WithFeeCollectionKeeper(app.feeCollectionKeeper)
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade, app.RegisterCodespace(upgrade.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace)).
+		WithUpgradeKeeper(app.upgradeKeeper).
+		WithCommunityPoolKeeper(app.distrKeeper)
+	app.feeGrantKeeper = feegrant.NewKeeper(app.cdc, app.keyFeeGrant, app.RegisterCodespace(feegrant.DefaultCodespace))
+	app.authzKeeper = authz.NewKeeper(app.cdc, app.keyAuthz, app.Router(), app.RegisterCodespace(authz.DefaultCodespace))
+
+	// register the params which can be changed by governance
+	slashing.RegisterParamTypes(app.paramsKeeper)
+	distr.RegisterParamTypes(app.paramsKeeper)
+	gov.RegisterParamTypes(app.paramsKeeper)
+	stake.RegisterParamTypes(app.paramsKeeper, app.stakeKeeper)
+	ibc.RegisterParamTypes(app.paramsKeeper, app.ibcMapper)
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, ibcRouter)).
+		AddRoute("ibctransfer", ibc.NewTransferHandler(ibcTransferApp)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("distr", distr.NewHandler(app.distrKeeper)).
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("feegrant", feegrant.NewHandler(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewHandler(app.authzKeeper))
 
 	app.QueryRouter().
-		AddRoute("gov", gov.NewQuerier(app.govKeeper))
+		AddRoute("ibc", ibc.NewQuerier(app.ibcMapper)).
+		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
+		AddRoute("feegrant", feegrant.NewQuerier(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewQuerier(app.authzKeeper))
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
+	app.SetUpgrader(upgrade.NewUpgrader(app.upgradeKeeper))
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams)
+	app.SetAnteHandler(auth.NewFeeGrantAnteHandler(app.accountMapper, app.feeCollectionKeeper, app.feeGrantKeeper))
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyDistr, app.keyGov, app.keyUpgrade, app.keyFeeGrant, app.keyAuthz, app.keyFeeCollection, app.keyParams)
 	app.MountStore(app.tkeyParams, sdk.StoreTypeTransient)
+	app.SetInterBlockCache(interBlockCacheSize, app.keyAccount, app.keyStake)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -131,15 +177,30 @@ func MakeCodec() *wire.Codec {
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
+	distr.RegisterWire(cdc)
 	gov.RegisterWire(cdc)
+	feegrant.RegisterWire(cdc)
+	authz.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
 	return cdc
 }
 
+// StoreDecoders returns the key prefix decoders of the modules, under the
+// names of the stores NewGaiaApp mounts them on, used by the debug commands.
+func StoreDecoders() sdk.StoreDecoders {
+	decoders := make(sdk.StoreDecoders)
+	decoders.Register("acc", auth.StoreDecoders()...)
+	decoders.Register("stake", stake.StoreDecoders()...)
+	return decoders
+}
+
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// distribute the rewards of the previous block before any slashing
+	distr.BeginBlocker(ctx, req, app.distrKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	return abci.ResponseBeginBlock{
@@ -171,11 +232,17 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
 		// return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
+	if err = GaiaValidateGenesisState(genesisState); err != nil {
+		panic(err)
+	}
 
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		err = acc.SetAccountNumber(app.accountMapper.GetNextAccountNumber(ctx))
+		if err != nil {
+			panic(err)
+		}
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
@@ -189,8 +256,16 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	// load the address to pubkey map
 	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.StakeData)
 
+	distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData, genesisState.StakeData)
+
 	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
 
+	feegrant.InitGenesis(ctx, app.feeGrantKeeper, genesisState.FeeGrantData)
+
+	authz.InitGenesis(ctx, app.authzKeeper, genesisState.AuthzData)
+
+	ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCData)
+
 	return abci.ResponseInitChain{
 		Validators: validators,
 	}
@@ -210,9 +285,13 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
-		GovData:   gov.WriteGenesis(ctx, app.govKeeper),
+		Accounts:     accounts,
+		StakeData:    stake.WriteGenesis(ctx, app.stakeKeeper),
+		DistrData:    distr.WriteGenesis(ctx, app.distrKeeper),
+		GovData:      gov.WriteGenesis(ctx, app.govKeeper),
+		FeeGrantData: feegrant.WriteGenesis(ctx, app.feeGrantKeeper),
+		AuthzData:    authz.WriteGenesis(ctx, app.authzKeeper),
+		IBCData:      ibc.WriteGenesis(ctx, app.ibcMapper),
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
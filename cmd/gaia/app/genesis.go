@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -11,10 +12,15 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/crypto"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -27,19 +33,54 @@ var (
 	// bonded tokens given to genesis validators/accounts
 	freeFermionVal  = int64(100)
 	freeFermionsAcc = sdk.NewInt(50)
+
+	// commission of the genesis validators whose gentx doesn't set one
+	defaultCommissionRate          = "0.1"
+	defaultCommissionMaxRate       = "0.2"
+	defaultCommissionMaxChangeRate = "0.01"
 )
 
+// DefaultGenesisCommission returns the commission of the genesis validators
+// whose gentx doesn't set one
+func DefaultGenesisCommission() stake.CommissionMsg {
+	commission, err := stake.BuildCommissionMsg(
+		defaultCommissionRate, defaultCommissionMaxRate, defaultCommissionMaxChangeRate)
+	if err != nil {
+		panic(err)
+	}
+	return commission
+}
+
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount   `json:"accounts"`
-	StakeData stake.GenesisState `json:"stake"`
-	GovData   gov.GenesisState   `json:"gov"`
+	Accounts     []GenesisAccount      `json:"accounts"`
+	StakeData    stake.GenesisState    `json:"stake"`
+	DistrData    distr.GenesisState    `json:"distr"`
+	GovData      gov.GenesisState      `json:"gov"`
+	FeeGrantData feegrant.GenesisState `json:"feegrant"`
+	AuthzData    authz.GenesisState    `json:"authz"`
+	IBCData      ibc.GenesisState      `json:"ibc"`
 }
 
-// GenesisAccount doesn't need pubkey or sequence
+// nolint
+const (
+	VestingTypeContinuous = "continuous"
+	VestingTypeDelayed    = "delayed"
+)
+
+// GenesisAccount doesn't need pubkey or sequence. Accounts with original
+// vesting coins are vesting accounts of the vesting type, vesting continuously
+// from their start time to their end time or all at once at their end time.
 type GenesisAccount struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+
+	VestingType      string    `json:"vesting_type,omitempty"`
+	OriginalVesting  sdk.Coins `json:"original_vesting,omitempty"`
+	DelegatedFree    sdk.Coins `json:"delegated_free,omitempty"`
+	DelegatedVesting sdk.Coins `json:"delegated_vesting,omitempty"`
+	StartTime        int64     `json:"start_time,omitempty"`
+	EndTime          int64     `json:"end_time,omitempty"`
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -50,18 +91,117 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+
+	var bva auth.BaseVestingAccount
+	switch acc := acc.(type) {
+	case *auth.ContinuousVestingAccount:
+		bva = acc.BaseVestingAccount
+		gacc.VestingType = VestingTypeContinuous
+		gacc.StartTime = acc.StartTime
+	case *auth.DelayedVestingAccount:
+		bva = acc.BaseVestingAccount
+		gacc.VestingType = VestingTypeDelayed
+	default:
+		return gacc
+	}
+	gacc.OriginalVesting = bva.OriginalVesting
+	gacc.DelegatedFree = bva.DelegatedFree
+	gacc.DelegatedVesting = bva.DelegatedVesting
+	gacc.EndTime = bva.EndTime
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to an auth.Account, either an auth.BaseAccount or a
+// vesting account. The account must be valid.
+func (ga *GenesisAccount) ToAccount() auth.Account {
+	bacc := auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+	if ga.OriginalVesting.IsZero() {
+		return &bacc
+	}
+
+	bva := auth.BaseVestingAccount{
+		BaseAccount:      bacc,
+		OriginalVesting:  ga.OriginalVesting.Sort(),
+		DelegatedFree:    ga.DelegatedFree.Sort(),
+		DelegatedVesting: ga.DelegatedVesting.Sort(),
+		EndTime:          ga.EndTime,
+	}
+	switch ga.VestingType {
+	case VestingTypeContinuous:
+		return &auth.ContinuousVestingAccount{BaseVestingAccount: bva, StartTime: ga.StartTime}
+	case VestingTypeDelayed:
+		return &auth.DelayedVestingAccount{BaseVestingAccount: bva}
+	default:
+		panic(fmt.Sprintf("invalid vesting type %q of genesis account %s", ga.VestingType, ga.Address))
+	}
+}
+
+// Validate checks the coins of the account and, for vesting accounts, their
+// vesting type and schedule.
+func (ga GenesisAccount) Validate() error {
+	for _, c := range []struct {
+		name  string
+		coins sdk.Coins
+	}{
+		{"coins", ga.Coins},
+		{"original vesting", ga.OriginalVesting},
+		{"delegated free", ga.DelegatedFree},
+		{"delegated vesting", ga.DelegatedVesting},
+	} {
+		if !c.coins.Sort().IsValid() || !c.coins.IsNotNegative() {
+			return fmt.Errorf("invalid %s %v", c.name, c.coins)
+		}
+	}
+
+	if ga.OriginalVesting.IsZero() {
+		if ga.VestingType != "" || !ga.DelegatedFree.IsZero() || !ga.DelegatedVesting.IsZero() || ga.StartTime != 0 || ga.EndTime != 0 {
+			return errors.New("vesting fields set without original vesting coins")
+		}
+		return nil
+	}
+
+	switch ga.VestingType {
+	case VestingTypeContinuous:
+		if ga.EndTime <= ga.StartTime {
+			return fmt.Errorf("vesting end time %d not after start time %d", ga.EndTime, ga.StartTime)
+		}
+	case VestingTypeDelayed:
+		if ga.StartTime != 0 {
+			return errors.New("start time set for a delayed vesting account")
+		}
+		if ga.EndTime <= 0 {
+			return fmt.Errorf("invalid vesting end time %d", ga.EndTime)
+		}
+	default:
+		return fmt.Errorf("invalid vesting type %q", ga.VestingType)
+	}
+
+	// the delegated coins are no longer held by the account
+	held := ga.Coins.Plus(ga.DelegatedFree).Plus(ga.DelegatedVesting)
+	if !held.IsGTE(ga.OriginalVesting) {
+		return fmt.Errorf("original vesting %v above the account coins %v", ga.OriginalVesting, held)
+	}
+	if !ga.OriginalVesting.IsGTE(ga.DelegatedVesting) {
+		return fmt.Errorf("delegated vesting %v above the original vesting %v", ga.DelegatedVesting, ga.OriginalVesting)
+	}
+	return nil
+}
+
+// GaiaValidateGenesisState checks the genesis state before it is loaded
+func GaiaValidateGenesisState(genesisState GenesisState) error {
+	for _, acc := range genesisState.Accounts {
+		if err := acc.Validate(); err != nil {
+			return fmt.Errorf("invalid genesis account %s: %v", acc.Address, err)
+		}
+	}
+	return nil
 }
 
 // get app init parameters for server init command
@@ -73,6 +213,9 @@ func GaiaAppInit() server.AppInit {
 	fsAppGenTx.String(server.FlagClientHome, DefaultCLIHome,
 		"home directory for the client, used for key generation")
 	fsAppGenTx.Bool(server.FlagOWK, false, "overwrite the accounts created")
+	fsAppGenTx.String(stake.FlagCommissionRate, defaultCommissionRate, "initial commission rate of the validator")
+	fsAppGenTx.String(stake.FlagCommissionMaxRate, defaultCommissionMaxRate, "maximum commission rate of the validator")
+	fsAppGenTx.String(stake.FlagCommissionMaxChangeRate, defaultCommissionMaxChangeRate, "maximum daily change of the commission rate of the validator")
 
 	return server.AppInit{
 		FlagsAppGenState: fsAppGenState,
@@ -87,6 +230,8 @@ type GaiaGenTx struct {
 	Name    string         `json:"name"`
 	Address sdk.AccAddress `json:"address"`
 	PubKey  string         `json:"pub_key"`
+	// nil in the gentxs created before the validator commissions
+	Commission *stake.CommissionMsg `json:"commission,omitempty"`
 }
 
 // GaiaAppGenTx generates a Gaia genesis transaction.
@@ -128,21 +273,34 @@ func GaiaAppGenTx(
 		return appGenTx, cliPrint, validator, err
 	}
 
+	commission, err := stake.BuildCommissionMsg(
+		viper.GetString(stake.FlagCommissionRate),
+		viper.GetString(stake.FlagCommissionMaxRate),
+		viper.GetString(stake.FlagCommissionMaxChangeRate),
+	)
+	if err != nil {
+		return appGenTx, cliPrint, validator, err
+	}
+	if err = commission.Validate(); err != nil {
+		return appGenTx, cliPrint, validator, err
+	}
+
 	cliPrint = json.RawMessage(bz)
-	appGenTx, _, validator, err = GaiaAppGenTxNF(cdc, pk, addr, genTxConfig.Name)
+	appGenTx, _, validator, err = GaiaAppGenTxNF(cdc, pk, addr, genTxConfig.Name, commission)
 
 	return appGenTx, cliPrint, validator, err
 }
 
 // Generate a gaia genesis transaction without flags
-func GaiaAppGenTxNF(cdc *wire.Codec, pk crypto.PubKey, addr sdk.AccAddress, name string) (
+func GaiaAppGenTxNF(cdc *wire.Codec, pk crypto.PubKey, addr sdk.AccAddress, name string, commission stake.CommissionMsg) (
 	appGenTx, cliPrint json.RawMessage, validator tmtypes.GenesisValidator, err error) {
 
 	var bz []byte
 	gaiaGenTx := GaiaGenTx{
-		Name:    name,
-		Address: addr,
-		PubKey:  sdk.MustBech32ifyConsPub(pk),
+		Name:       name,
+		Address:    addr,
+		PubKey:     sdk.MustBech32ifyConsPub(pk),
+		Commission: &commission,
 	}
 	bz, err = wire.MarshalJSONIndent(cdc, gaiaGenTx)
 	if err != nil {
@@ -196,6 +354,19 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 				sdk.ValAddress(genTx.Address), sdk.MustGetConsPubKeyBech32(genTx.PubKey), desc,
 			)
 
+			// the gentxs created before the validator commissions get the
+			// default commission
+			commission := DefaultGenesisCommission()
+			if genTx.Commission != nil {
+				commission = *genTx.Commission
+			}
+			var sdkErr sdk.Error
+			validator, sdkErr = validator.SetInitialCommission(commission, time.Unix(0, 0))
+			if sdkErr != nil {
+				err = fmt.Errorf("invalid commission of genesis validator %s: %v", genTx.Name, sdkErr)
+				return
+			}
+
 			stakeData.Pool.LooseTokens = stakeData.Pool.LooseTokens.Add(sdk.NewDec(freeFermionVal)) // increase the supply
 
 			// add some new shares to the validator
@@ -217,9 +388,13 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		StakeData: stakeData,
-		GovData:   gov.DefaultGenesisState(),
+		Accounts:     genaccs,
+		StakeData:    stakeData,
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		FeeGrantData: feegrant.DefaultGenesisState(),
+		AuthzData:    authz.DefaultGenesisState(),
+		IBCData:      ibc.DefaultGenesisState(),
 	}
 	return
 }
@@ -1,10 +1,12 @@
 package app
 
 import (
+	"encoding/json"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
@@ -14,7 +16,61 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.AccAddress(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	require.Equal(t, &authAcc, genAcc.ToAccount())
+}
+
+func TestToVestingAccount(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+
+	var continuousAcc auth.Account = auth.NewContinuousVestingAccount(addr, coins, 100, 200)
+	genAcc := NewGenesisAccountI(continuousAcc)
+	require.Equal(t, continuousAcc, genAcc.ToAccount())
+
+	var delayedAcc auth.Account = auth.NewDelayedVestingAccount(addr, coins, 200)
+	genAcc = NewGenesisAccountI(delayedAcc)
+	require.Equal(t, delayedAcc, genAcc.ToAccount())
+}
+
+func TestValidateGenesisAccount(t *testing.T) {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+
+	valid := GenesisAccount{
+		Address:         addr,
+		Coins:           coins,
+		VestingType:     VestingTypeContinuous,
+		OriginalVesting: coins,
+		StartTime:       100,
+		EndTime:         200,
+	}
+	require.NoError(t, valid.Validate())
+	require.NoError(t, GaiaValidateGenesisState(GenesisState{Accounts: []GenesisAccount{valid}}))
+
+	// a continuous vesting account with a zero start time stays continuous
+	fromGenesis := valid
+	fromGenesis.StartTime = 0
+	require.NoError(t, fromGenesis.Validate())
+	_, ok := fromGenesis.ToAccount().(*auth.ContinuousVestingAccount)
+	require.True(t, ok)
+
+	for name, modify := range map[string]func(ga *GenesisAccount){
+		"missing vesting type": func(ga *GenesisAccount) { ga.VestingType = "" },
+		"unknown vesting type": func(ga *GenesisAccount) { ga.VestingType = "linear" },
+		"end before start":     func(ga *GenesisAccount) { ga.EndTime = ga.StartTime },
+		"delayed with start":   func(ga *GenesisAccount) { ga.VestingType = VestingTypeDelayed },
+		"vesting above coins":  func(ga *GenesisAccount) { ga.OriginalVesting = sdk.Coins{sdk.NewInt64Coin("steak", 101)} },
+		"negative delegation":  func(ga *GenesisAccount) { ga.DelegatedFree = sdk.Coins{sdk.NewInt64Coin("steak", -1)} },
+		"vesting fields only": func(ga *GenesisAccount) {
+			ga.OriginalVesting = nil
+		},
+	} {
+		invalid := valid
+		modify(&invalid)
+		require.Error(t, invalid.Validate(), name)
+		require.Error(t, GaiaValidateGenesisState(GenesisState{Accounts: []GenesisAccount{invalid}}), name)
+	}
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
@@ -34,3 +90,41 @@ func TestGaiaAppGenState(t *testing.T) {
 	// TODO test with both one and two genesis transactions:
 	// TODO        correct: genesis account created, canididates created, pool token variance
 }
+
+func TestGaiaAppGenStateCommission(t *testing.T) {
+	cdc := MakeCodec()
+
+	pk1, pk2 := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+	commission := stake.NewCommissionMsg(sdk.NewDecWithPrec(5, 2), sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 2))
+	appGenTx1, _, _, err := GaiaAppGenTxNF(cdc, pk1, sdk.AccAddress(pk1.Address()), "val1", commission)
+	require.NoError(t, err)
+
+	// a gentx without commission gets the default one
+	appGenTx2, err := cdc.MarshalJSON(GaiaGenTx{
+		Name:    "val2",
+		Address: sdk.AccAddress(pk2.Address()),
+		PubKey:  sdk.MustBech32ifyConsPub(pk2),
+	})
+	require.NoError(t, err)
+
+	genesisState, err := GaiaAppGenState(cdc, []json.RawMessage{appGenTx1, appGenTx2})
+	require.NoError(t, err)
+	require.Len(t, genesisState.StakeData.Validators, 2)
+
+	val1 := genesisState.StakeData.Validators[0]
+	require.True(t, commission.Rate.Equal(val1.Commission))
+	require.True(t, commission.MaxRate.Equal(val1.CommissionMax))
+	require.True(t, commission.MaxChangeRate.Equal(val1.CommissionChangeRate))
+
+	defaults := DefaultGenesisCommission()
+	val2 := genesisState.StakeData.Validators[1]
+	require.True(t, defaults.Rate.Equal(val2.Commission))
+	require.True(t, defaults.MaxRate.Equal(val2.CommissionMax))
+
+	// an invalid commission is rejected
+	invalid := stake.NewCommissionMsg(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(1, 2))
+	appGenTx1, _, _, err = GaiaAppGenTxNF(cdc, pk1, sdk.AccAddress(pk1.Address()), "val1", invalid)
+	require.NoError(t, err)
+	_, err = GaiaAppGenState(cdc, []json.RawMessage{appGenTx1})
+	require.Error(t, err)
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authzcmd "github.com/cosmos/cosmos-sdk/x/authz/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
+	feegrantcmd "github.com/cosmos/cosmos-sdk/x/feegrant/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
@@ -56,15 +59,33 @@ func main() {
 		Use:   "ibc",
 		Short: "Inter-Blockchain Communication subcommands",
 	}
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			ibccmd.GetCmdQueryDenomTrace("ibc", cdc),
+		)...)
 	ibcCmd.AddCommand(
 		client.PostCommands(
 			ibccmd.IBCTransferCmd(cdc),
+			ibccmd.IBCCreateClientCmd(cdc),
 			ibccmd.IBCRelayCmd(cdc),
 		)...)
 
+	//Add offline signing commands
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Transactions subcommands",
+	}
+	txCmd.AddCommand(
+		client.PostCommands(
+			tx.SignTxCmd(cdc),
+			tx.MultiSignTxCmd(cdc),
+			tx.BroadcastTxCmd(cdc),
+		)...)
+
 	rootCmd.AddCommand(
 		tendermintCmd,
 		ibcCmd,
+		txCmd,
 		lcd.ServeCommand(cdc),
 		client.LineBreak,
 	)
@@ -101,6 +122,20 @@ func main() {
 		stakeCmd,
 	)
 
+	//Add distribution commands
+	distrCmd := &cobra.Command{
+		Use:   "distr",
+		Short: "Fee distribution subcommands",
+	}
+	distrCmd.AddCommand(
+		client.PostCommands(
+			distrcmd.GetCmdWithdrawRewards(cdc),
+			distrcmd.GetCmdWithdrawCommission(cdc),
+		)...)
+	rootCmd.AddCommand(
+		distrCmd,
+	)
+
 	//Add stake commands
 	govCmd := &cobra.Command{
 		Use:   "gov",
@@ -112,6 +147,7 @@ func main() {
 			govcmd.GetCmdQueryVote("gov", cdc),
 			govcmd.GetCmdQueryVotes("gov", cdc),
 			govcmd.GetCmdQueryProposals("gov", cdc),
+			govcmd.GetCmdQueryUpgradePlan("gov", cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
@@ -123,6 +159,45 @@ func main() {
 		govCmd,
 	)
 
+	//Add fee grant commands
+	feegrantCmd := &cobra.Command{
+		Use:   "feegrant",
+		Short: "Fee grant subcommands",
+	}
+	feegrantCmd.AddCommand(
+		client.GetCommands(
+			feegrantcmd.GetCmdQueryFeeAllowance("feegrant", cdc),
+			feegrantcmd.GetCmdQueryFeeAllowances("feegrant", cdc),
+		)...)
+	feegrantCmd.AddCommand(
+		client.PostCommands(
+			feegrantcmd.GetCmdGrantFeeAllowance(cdc),
+			feegrantcmd.GetCmdRevokeFeeAllowance(cdc),
+		)...)
+	rootCmd.AddCommand(
+		feegrantCmd,
+	)
+
+	//Add authz commands
+	authzCmd := &cobra.Command{
+		Use:   "authz",
+		Short: "Authorization subcommands",
+	}
+	authzCmd.AddCommand(
+		client.GetCommands(
+			authzcmd.GetCmdQueryAuthorization("authz", cdc),
+			authzcmd.GetCmdQueryGrants("authz", cdc),
+		)...)
+	authzCmd.AddCommand(
+		client.PostCommands(
+			authzcmd.GetCmdGrant(cdc),
+			authzcmd.GetCmdRevoke(cdc),
+			authzcmd.GetCmdExec(cdc),
+		)...)
+	rootCmd.AddCommand(
+		authzCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
@@ -32,6 +32,7 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, app.GaiaAppInit(),
 		server.ConstructAppCreator(newApp, "gaia"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "gaia"))
+	rootCmd.AddCommand(server.DebugCmd(cdc, "gaia", app.StoreDecoders()))
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -43,7 +44,19 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(logger, db, traceStore, baseapp.SetPruning(viper.GetString("pruning")))
+	pruning, err := server.GetPruningStrategy()
+	if err != nil {
+		panic(err)
+	}
+	return app.NewGaiaApp(logger, db, traceStore,
+		baseapp.SetPruning(pruning),
+		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
+		baseapp.SetSnapshots(
+			server.SnapshotsDir(viper.GetString(cli.HomeFlag)),
+			viper.GetInt64(server.FlagSnapshotInterval),
+			viper.GetInt(server.FlagSnapshotKeepRecent),
+		),
+	)
 }
 
 func exportAppStateAndTMValidators(
@@ -0,0 +1,97 @@
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// CompactBitArray is a space efficient bit array, storing 8 bits per byte.
+// It records which of the keys of a multisig public key have signed.
+type CompactBitArray struct {
+	ExtraBitsStored byte   `json:"extra_bits"` // number of bits used in the last byte, 0 if all are
+	Elems           []byte `json:"bits"`
+}
+
+// NewCompactBitArray returns a bit array of the given size with all bits
+// unset, or nil if the size is not positive.
+func NewCompactBitArray(bits int) *CompactBitArray {
+	if bits <= 0 {
+		return nil
+	}
+	return &CompactBitArray{
+		ExtraBitsStored: byte(bits % 8),
+		Elems:           make([]byte, (bits+7)/8),
+	}
+}
+
+// Size returns the number of bits in the bit array.
+func (bA *CompactBitArray) Size() int {
+	if bA == nil {
+		return 0
+	}
+	if bA.ExtraBitsStored == 0 {
+		return len(bA.Elems) * 8
+	}
+	return (len(bA.Elems)-1)*8 + int(bA.ExtraBitsStored)
+}
+
+// ValidateSize checks that the bit array is well formed and holds size bits.
+// The bit arrays decoded from untrusted data must be validated before use.
+func (bA *CompactBitArray) ValidateSize(size int) error {
+	switch {
+	case bA == nil:
+		return errors.New("nil bit array")
+	case bA.ExtraBitsStored >= 8:
+		return fmt.Errorf("invalid number of extra bits %d", bA.ExtraBitsStored)
+	case len(bA.Elems) != (size+7)/8 || int(bA.ExtraBitsStored) != size%8:
+		return fmt.Errorf("bit array of %d bytes and %d extra bits instead of %d bits",
+			len(bA.Elems), bA.ExtraBitsStored, size)
+	}
+	return nil
+}
+
+// GetIndex returns the bit at index i, false if i is out of range.
+func (bA *CompactBitArray) GetIndex(i int) bool {
+	if i < 0 || i >= bA.Size() || i>>3 >= len(bA.Elems) {
+		return false
+	}
+	return bA.Elems[i>>3]&(uint8(1)<<uint8(7-(i%8))) > 0
+}
+
+// SetIndex sets the bit at index i, returning false if i is out of range.
+func (bA *CompactBitArray) SetIndex(i int, v bool) bool {
+	if i < 0 || i >= bA.Size() || i>>3 >= len(bA.Elems) {
+		return false
+	}
+	if v {
+		bA.Elems[i>>3] |= uint8(1) << uint8(7-(i%8))
+	} else {
+		bA.Elems[i>>3] &= ^(uint8(1) << uint8(7-(i%8)))
+	}
+	return true
+}
+
+// NumTrueBitsBefore returns the number of bits set before index.
+func (bA *CompactBitArray) NumTrueBitsBefore(index int) int {
+	count := 0
+	for i := 0; i < index && i < bA.Size(); i++ {
+		if bA.GetIndex(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// String returns the bits as a string of 'x' (set) and '_' (unset).
+func (bA *CompactBitArray) String() string {
+	var buf bytes.Buffer
+	for i := 0; i < bA.Size(); i++ {
+		if bA.GetIndex(i) {
+			buf.WriteString("x")
+		} else {
+			buf.WriteString("_")
+		}
+	}
+	return fmt.Sprintf("BA{%d:%s}", bA.Size(), buf.String())
+}
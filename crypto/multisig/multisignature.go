@@ -0,0 +1,62 @@
+package multisig
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// Multisignature is used to represent the signature object used in the
+// multisigs. Sigs holds the signatures of the keys whose bit is set in the
+// bit array, in the same order as the keys.
+type Multisignature struct {
+	BitArray *CompactBitArray `json:"bit_array"`
+	Sigs     [][]byte         `json:"sigs"`
+}
+
+// NewMultisig returns an empty multisignature for a multisig public key of n
+// keys.
+func NewMultisig(n int) *Multisignature {
+	// Default the signature list to have a capacity of two, since we can
+	// expect that most multisigs will require multiple signers.
+	return &Multisignature{NewCompactBitArray(n), make([][]byte, 0, 2)}
+}
+
+// AddSignature adds the signature of the key at the given index, replacing
+// any signature previously added for it.
+func (mSig *Multisignature) AddSignature(sig []byte, index int) {
+	newSigIndex := mSig.BitArray.NumTrueBitsBefore(index)
+	// signature already exists, just replace the value there
+	if mSig.BitArray.GetIndex(index) {
+		mSig.Sigs[newSigIndex] = sig
+		return
+	}
+	mSig.BitArray.SetIndex(index, true)
+	// optimization if the index is the greatest index
+	if newSigIndex == len(mSig.Sigs) {
+		mSig.Sigs = append(mSig.Sigs, sig)
+		return
+	}
+	// expand slice by one with a dummy element, move all elements after i
+	// over by one, then place the new signature in that gap
+	mSig.Sigs = append(mSig.Sigs, make([]byte, 0))
+	copy(mSig.Sigs[newSigIndex+1:], mSig.Sigs[newSigIndex:])
+	mSig.Sigs[newSigIndex] = sig
+}
+
+// AddSignatureFromPubKey adds the signature of pubkey, given the member keys
+// of the multisig public key. It errors if pubkey is not a member key.
+func (mSig *Multisignature) AddSignatureFromPubKey(sig []byte, pubkey crypto.PubKey, keys []crypto.PubKey) error {
+	for i, key := range keys {
+		if key.Equals(pubkey) {
+			mSig.AddSignature(sig, i)
+			return nil
+		}
+	}
+	return errors.Errorf("provided key %X is not a member of the multisig key", pubkey.Address())
+}
+
+// Marshal the multisignature with amino.
+func (mSig *Multisignature) Marshal() []byte {
+	return cdc.MustMarshalBinaryBare(mSig)
+}
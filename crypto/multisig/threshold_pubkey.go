@@ -0,0 +1,88 @@
+package multisig
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// PubKeyMultisigThreshold implements a K of N threshold multisig public key.
+// Its address derives from the threshold and the member keys, in order.
+type PubKeyMultisigThreshold struct {
+	K       uint            `json:"threshold"`
+	PubKeys []crypto.PubKey `json:"pubkeys"`
+}
+
+var _ crypto.PubKey = PubKeyMultisigThreshold{}
+
+// NewPubKeyMultisigThreshold returns a public key which is only valid for
+// multisignatures of at least k of the given keys.
+// Panics if k is not positive or if there are fewer than k keys.
+func NewPubKeyMultisigThreshold(k int, pubkeys []crypto.PubKey) crypto.PubKey {
+	if k <= 0 {
+		panic("threshold k of n multisignature: k <= 0")
+	}
+	if len(pubkeys) < k {
+		panic("threshold k of n multisignature: len(pubkeys) < k")
+	}
+	return PubKeyMultisigThreshold{uint(k), pubkeys}
+}
+
+// VerifyBytes expects the signature to be an amino encoded Multisignature.
+// It verifies that at least K of the member keys signed msg, and that every
+// sub-signature is valid.
+func (pk PubKeyMultisigThreshold) VerifyBytes(msg []byte, marshalledSig []byte) bool {
+	var sig Multisignature
+	err := cdc.UnmarshalBinaryBare(marshalledSig, &sig)
+	if err != nil {
+		return false
+	}
+
+	size := len(pk.PubKeys)
+	// ensure the bit array is well formed and the correct size and that
+	// enough keys signed
+	if sig.BitArray.ValidateSize(size) != nil || len(sig.Sigs) < int(pk.K) || len(sig.Sigs) > size {
+		return false
+	}
+	if sig.BitArray.NumTrueBitsBefore(size) != len(sig.Sigs) {
+		return false
+	}
+
+	sigIndex := 0
+	for i := 0; i < size; i++ {
+		if sig.BitArray.GetIndex(i) {
+			if !pk.PubKeys[i].VerifyBytes(msg, sig.Sigs[sigIndex]) {
+				return false
+			}
+			sigIndex++
+		}
+	}
+	return true
+}
+
+// Bytes returns the amino encoding of the public key.
+func (pk PubKeyMultisigThreshold) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pk)
+}
+
+// Address returns the hash of the amino encoding of the public key.
+func (pk PubKeyMultisigThreshold) Address() crypto.Address {
+	return crypto.Address(tmhash.Sum(pk.Bytes()))
+}
+
+// Equals returns true if other is a multisig public key with the same
+// threshold and the same member keys, in the same order.
+func (pk PubKeyMultisigThreshold) Equals(other crypto.PubKey) bool {
+	otherKey, ok := other.(PubKeyMultisigThreshold)
+	if !ok {
+		return false
+	}
+	if pk.K != otherKey.K || len(pk.PubKeys) != len(otherKey.PubKeys) {
+		return false
+	}
+	for i := 0; i < len(pk.PubKeys); i++ {
+		if !pk.PubKeys[i].Equals(otherKey.PubKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func generatePubKeysAndSignatures(n int, msg []byte) (pubkeys []crypto.PubKey, signatures [][]byte) {
+	pubkeys = make([]crypto.PubKey, n)
+	signatures = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		var privkey crypto.PrivKey
+		if i%2 == 0 {
+			privkey = ed25519.GenPrivKey()
+		} else {
+			privkey = secp256k1.GenPrivKey()
+		}
+		pubkeys[i] = privkey.PubKey()
+		signatures[i], _ = privkey.Sign(msg)
+	}
+	return
+}
+
+// This tests multisig functionality, but it expects the first k signatures
+// to be valid, with the remaining signatures unsigned or invalid
+func TestThresholdMultisigValidCases(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(5, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := NewMultisig(len(pubkeys))
+
+	// a single signature is below the threshold
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[4], pubkeys[4], pubkeys))
+	require.False(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+
+	// signatures can be added in any order
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[1], pubkeys[1], pubkeys))
+	require.True(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[2], pubkeys[2], pubkeys))
+	require.True(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+	require.Equal(t, "BA{5:_xx_x}", multisignature.BitArray.String())
+
+	// adding a signature twice replaces it
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[2], pubkeys[2], pubkeys))
+	require.Equal(t, 3, len(multisignature.Sigs))
+	require.True(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+}
+
+func TestThresholdMultisigInvalidCases(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	// a signature of a key which is not a member cannot be added
+	otherKeys, otherSigs := generatePubKeysAndSignatures(1, msg)
+	multisignature := NewMultisig(len(pubkeys))
+	require.Error(t, multisignature.AddSignatureFromPubKey(otherSigs[0], otherKeys[0], pubkeys))
+
+	// an invalid sub-signature invalidates the multisignature
+	multisignature.AddSignature(sigs[0], 0)
+	multisignature.AddSignature(sigs[0], 1)
+	require.False(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+	multisignature.AddSignature(sigs[1], 1)
+	require.True(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+
+	// the bit array must match the number of keys
+	wrongSize := NewMultisig(len(pubkeys) + 1)
+	wrongSize.AddSignature(sigs[0], 0)
+	wrongSize.AddSignature(sigs[1], 1)
+	require.False(t, multisigKey.VerifyBytes(msg, wrongSize.Marshal()))
+
+	// garbage is not a multisignature
+	require.False(t, multisigKey.VerifyBytes(msg, []byte{1, 2, 3}))
+}
+
+func TestThresholdMultisigMalformedBitArray(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(5, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	for _, bitArray := range []*CompactBitArray{
+		nil,
+		// too many extra bits, reporting more bits than stored
+		{ExtraBitsStored: 13, Elems: []byte{0xff}},
+		{ExtraBitsStored: 255, Elems: []byte{0xff}},
+		// extra bits without any byte
+		{ExtraBitsStored: 5, Elems: nil},
+		// the right number of bits with too many bytes
+		{ExtraBitsStored: 5, Elems: []byte{0x00, 0xff, 0xff}},
+		{ExtraBitsStored: 0, Elems: []byte{0xff}},
+	} {
+		multisignature := &Multisignature{BitArray: bitArray, Sigs: sigs[:2]}
+		require.NotPanics(t, func() {
+			require.False(t, multisigKey.VerifyBytes(msg, multisignature.Marshal()))
+		})
+		require.Error(t, bitArray.ValidateSize(len(pubkeys)))
+	}
+
+	require.NoError(t, NewCompactBitArray(5).ValidateSize(5))
+	require.NoError(t, NewCompactBitArray(16).ValidateSize(16))
+	require.Error(t, NewCompactBitArray(5).ValidateSize(6))
+}
+
+func TestMultisigAddress(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, _ := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	// the address derives from the threshold and the member keys
+	require.Equal(t, multisigKey.Address(), NewPubKeyMultisigThreshold(2, pubkeys).Address())
+	require.NotEqual(t, multisigKey.Address(), NewPubKeyMultisigThreshold(1, pubkeys).Address())
+	require.NotEqual(t, multisigKey.Address(), NewPubKeyMultisigThreshold(2, pubkeys[:2]).Address())
+	require.True(t, multisigKey.Equals(NewPubKeyMultisigThreshold(2, pubkeys)))
+	require.False(t, multisigKey.Equals(pubkeys[0]))
+}
+
+func TestMultisigKeyAminoEncoding(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, _ := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	var decoded crypto.PubKey
+	err := cdc.UnmarshalBinaryBare(multisigKey.Bytes(), &decoded)
+	require.NoError(t, err)
+	require.True(t, multisigKey.Equals(decoded))
+}
@@ -0,0 +1,22 @@
+package multisig
+
+import (
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/encoding/amino"
+)
+
+// nolint
+const PubKeyMultisigThresholdAminoRoute = "cosmos-sdk/PubKeyMultisigThreshold"
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+	RegisterAmino(cdc)
+}
+
+// RegisterAmino registers the multisig public key in the given (amino) codec,
+// which must already know the member key types.
+func RegisterAmino(cdc *amino.Codec) {
+	cdc.RegisterConcrete(PubKeyMultisigThreshold{}, PubKeyMultisigThresholdAminoRoute, nil)
+}
@@ -69,11 +69,18 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 	)
 	app.coinKeeper = bank.NewKeeper(app.accountMapper)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	ibcTransferApp := ibc.NewTransferApp(app.ibcMapper, app.coinKeeper)
+	ibcRouter := ibc.NewRouter().
+		AddRoute(ibc.TransferPort, ibcTransferApp)
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper))
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, ibcRouter)).
+		AddRoute("ibctransfer", ibc.NewTransferHandler(ibcTransferApp))
+
+	app.QueryRouter().
+		AddRoute("ibc", ibc.NewQuerier(app.ibcMapper))
 
 	// perform initialization logic
 	app.SetInitChainer(app.initChainer)
@@ -63,6 +63,7 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			ibccmd.IBCTransferCmd(cdc),
+			ibccmd.IBCCreateClientCmd(cdc),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
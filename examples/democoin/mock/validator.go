@@ -58,6 +58,11 @@ func (v Validator) GetMoniker() string {
 	return ""
 }
 
+// Implements sdk.Validator
+func (v Validator) GetCommission() sdk.Dec {
+	return sdk.ZeroDec()
+}
+
 // Implements sdk.Validator
 type ValidatorSet struct {
 	Validators []Validator
@@ -92,6 +97,11 @@ func (vs *ValidatorSet) ValidatorByPubKey(ctx sdk.Context, pubkey crypto.PubKey)
 	panic("not implemented")
 }
 
+// ValidatorByConsAddr implements sdk.ValidatorSet
+func (vs *ValidatorSet) ValidatorByConsAddr(ctx sdk.Context, addr sdk.ConsAddress) sdk.Validator {
+	panic("not implemented")
+}
+
 // TotalPower implements sdk.ValidatorSet
 func (vs *ValidatorSet) TotalPower(ctx sdk.Context) sdk.Dec {
 	res := sdk.ZeroDec()
@@ -1,5 +1,50 @@
 package config
 
+const (
+	defaultMinGasPrices       = ""
+	defaultPruning            = "syncable"
+	defaultPruningInterval    = 1
+	defaultSnapshotKeepRecent = 2
+)
+
+// BaseConfig defines the server's basic configuration
+type BaseConfig struct {
+	// The minimum gas prices a validator is willing to accept for processing a
+	// transaction. A transaction's fees must meet the minimum of any denomination
+	// specified in this config (e.g. 0.01photino,0.0001stake).
+	MinGasPrices string `mapstructure:"minimum-gas-prices"`
+
+	// The pruning strategy of the IAVL stores: syncable, nothing, everything,
+	// or custom for the keep-recent, keep-every and interval settings.
+	Pruning           string `mapstructure:"pruning"`
+	PruningKeepRecent int64  `mapstructure:"pruning-keep-recent"`
+	PruningKeepEvery  int64  `mapstructure:"pruning-keep-every"`
+	PruningInterval   int64  `mapstructure:"pruning-interval"`
+
+	// The number of blocks between state-sync snapshots, 0 disabling them.
+	SnapshotInterval int64 `mapstructure:"snapshot-interval"`
+
+	// The number of recent snapshots to keep, 0 keeping all of them.
+	SnapshotKeepRecent int `mapstructure:"snapshot-keep-recent"`
+}
+
+// Config defines the server's top level configuration
+type Config struct {
+	BaseConfig `mapstructure:",squash"`
+}
+
+// DefaultConfig returns server's default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		BaseConfig{
+			MinGasPrices:       defaultMinGasPrices,
+			Pruning:            defaultPruning,
+			PruningInterval:    defaultPruningInterval,
+			SnapshotKeepRecent: defaultSnapshotKeepRecent,
+		},
+	}
+}
+
 //_____________________________________________________________________
 
 // Configuration structure for command functions that share configuration.
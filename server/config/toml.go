@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/spf13/viper"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const defaultConfigTemplate = `# This is a TOML config file.
+# For more information, see https://github.com/toml-lang/toml
+
+##### main base config options #####
+
+# The minimum gas prices a validator is willing to accept for processing a
+# transaction. A transaction's fees must meet the minimum of any denomination
+# specified in this config (e.g. 0.01photino,0.0001stake).
+minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
+
+##### pruning #####
+
+# The pruning strategy of the stores: syncable keeps the last 100 versions and
+# every 10000th, nothing keeps every version, everything keeps only the current
+# one, and custom uses the settings below.
+pruning = "{{ .BaseConfig.Pruning }}"
+
+# The number of recent versions to keep with the custom strategy.
+pruning-keep-recent = {{ .BaseConfig.PruningKeepRecent }}
+
+# Keep every n-th version with the custom strategy. 0 keeps none. Both
+# pruning-keep-recent and pruning-keep-every cannot be 0.
+pruning-keep-every = {{ .BaseConfig.PruningKeepEvery }}
+
+# The number of blocks between pruning runs with the custom strategy.
+pruning-interval = {{ .BaseConfig.PruningInterval }}
+
+##### state-sync snapshots #####
+
+# The number of blocks between state-sync snapshots, which are kept under
+# $HOME/snapshots. 0 disables them.
+snapshot-interval = {{ .BaseConfig.SnapshotInterval }}
+
+# The number of recent snapshots to keep. 0 keeps all of them.
+snapshot-keep-recent = {{ .BaseConfig.SnapshotKeepRecent }}
+`
+
+var configTemplate *template.Template
+
+func init() {
+	var err error
+	tmpl := template.New("appConfigFileTemplate")
+	if configTemplate, err = tmpl.Parse(defaultConfigTemplate); err != nil {
+		panic(err)
+	}
+}
+
+// ParseConfig retrieves the default environment configuration for the
+// application.
+func ParseConfig() (*Config, error) {
+	conf := DefaultConfig()
+	err := viper.Unmarshal(conf)
+	return conf, err
+}
+
+// WriteConfigFile renders config using the template and writes it to
+// configFilePath.
+func WriteConfigFile(configFilePath string, config *Config) {
+	var buffer bytes.Buffer
+
+	if err := configTemplate.Execute(&buffer, config); err != nil {
+		panic(err)
+	}
+
+	cmn.MustWriteFile(configFilePath, buffer.Bytes(), 0644)
+}
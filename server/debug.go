@@ -0,0 +1,181 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+const (
+	flagFrom  = "from"
+	flagTo    = "to"
+	flagStore = "store"
+	flagJSON  = "json"
+)
+
+// stateDiff is a key of a store changed between two heights. The values are
+// decoded with the key prefix decoders of the store, if any, and hex encoded
+// otherwise.
+type stateDiff struct {
+	Store  string          `json:"store"`
+	Key    cmn.HexBytes    `json:"key"`
+	Change string          `json:"change"`
+	Old    json.RawMessage `json:"old,omitempty"`
+	New    json.RawMessage `json:"new,omitempty"`
+}
+
+// DebugCmd groups the commands inspecting the state of a stopped node. The
+// app db is opened under the given name, the values of the stores being
+// decoded with the app codec and the store decoders of its modules.
+func DebugCmd(cdc *wire.Codec, appName string, decoders sdk.StoreDecoders) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect the state of the node",
+	}
+	cmd.AddCommand(diffStateCmd(cdc, appName, decoders))
+	return cmd
+}
+
+func diffStateCmd(cdc *wire.Codec, appName string, decoders sdk.StoreDecoders) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-state",
+		Short: "Print the keys added, removed and modified between two heights",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := viper.GetInt64(flagFrom), viper.GetInt64(flagTo)
+			if from <= 0 || to <= 0 {
+				return errors.New("--from and --to must be positive heights")
+			}
+			db, err := dbm.NewGoLevelDB(appName, filepath.Join(viper.GetString("home"), "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			diffs, err := diffState(cdc, decoders, db, from, to, viper.GetString(flagStore))
+			if err != nil {
+				return err
+			}
+
+			if viper.GetBool(flagJSON) {
+				bz, err := json.MarshalIndent(diffs, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(bz))
+				return nil
+			}
+			printStateDiffs(diffs)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFrom, 0, "Height to diff from")
+	cmd.Flags().Int64(flagTo, 0, "Height to diff to")
+	cmd.Flags().String(flagStore, "", "Only diff the store of the given name")
+	cmd.Flags().Bool(flagJSON, false, "Output the diff as JSON")
+	return cmd
+}
+
+// diffState returns the changes of the IAVL stores of the app db between
+// the from and to heights, by store name and key.
+func diffState(cdc *wire.Codec, decoders sdk.StoreDecoders, db dbm.DB, from, to int64, storeName string) ([]stateDiff, error) {
+	fromIDs, err := store.LoadStoreCommitIDs(db, from)
+	if err != nil {
+		return nil, err
+	}
+	toIDs, err := store.LoadStoreCommitIDs(db, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stores added or deleted in between are diffed against empty ones
+	var names []string
+	for name := range fromIDs {
+		names = append(names, name)
+	}
+	for name := range toIDs {
+		if _, ok := fromIDs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if storeName != "" {
+		_, inFrom := fromIDs[storeName]
+		_, inTo := toIDs[storeName]
+		if !inFrom && !inTo {
+			return nil, errors.Errorf("no store %s at heights %d and %d", storeName, from, to)
+		}
+		names = []string{storeName}
+	}
+
+	diffs := []stateDiff{}
+	for _, name := range names {
+		kvDiffs, err := store.DiffIAVLStore(db, name, fromIDs[name], toIDs[name])
+		if err != nil {
+			return nil, err
+		}
+		for _, kvDiff := range kvDiffs {
+			diff := stateDiff{Store: name, Key: kvDiff.Key}
+			switch {
+			case kvDiff.Old == nil:
+				diff.Change = "added"
+			case kvDiff.New == nil:
+				diff.Change = "removed"
+			default:
+				diff.Change = "modified"
+			}
+			if kvDiff.Old != nil {
+				diff.Old = decodeValue(cdc, decoders, name, kvDiff.Key, kvDiff.Old)
+			}
+			if kvDiff.New != nil {
+				diff.New = decodeValue(cdc, decoders, name, kvDiff.Key, kvDiff.New)
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// decodeValue returns the JSON of the value decoded by the decoders of the
+// store, or of its hex encoding if none matches its key or decoding fails.
+func decodeValue(cdc *wire.Codec, decoders sdk.StoreDecoders, storeName string, key, value []byte) json.RawMessage {
+	decoded, ok, err := decoders.Decode(cdc, storeName, key, value)
+	if ok && err == nil {
+		bz, err := cdc.MarshalJSON(decoded)
+		if err == nil {
+			return bz
+		}
+	}
+	bz, _ := json.Marshal(cmn.HexBytes(value))
+	return bz
+}
+
+func printStateDiffs(diffs []stateDiff) {
+	storeName := ""
+	for _, diff := range diffs {
+		if diff.Store != storeName {
+			storeName = diff.Store
+			fmt.Printf("store %s\n", storeName)
+		}
+		switch diff.Change {
+		case "added":
+			fmt.Printf("  + %X: %s\n", []byte(diff.Key), diff.New)
+		case "removed":
+			fmt.Printf("  - %X: %s\n", []byte(diff.Key), diff.Old)
+		default:
+			fmt.Printf("  ~ %X: %s -> %s\n", []byte(diff.Key), diff.Old, diff.New)
+		}
+	}
+}
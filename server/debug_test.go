@@ -0,0 +1,54 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func TestDiffState(t *testing.T) {
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	key1, key2 := sdk.NewKVStoreKey("store1"), sdk.NewKVStoreKey("store2")
+	cms.MountStoreWithDB(key1, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(key2, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, cms.LoadLatestVersion())
+
+	cms.GetKVStore(key1).Set([]byte{0x01, 'a'}, []byte("1"))
+	cms.GetKVStore(key2).Set([]byte("b"), []byte{0xAB})
+	cms.Commit()
+	cms.GetKVStore(key1).Set([]byte{0x01, 'a'}, []byte("2"))
+	cms.GetKVStore(key2).Delete([]byte("b"))
+	cms.Commit()
+
+	cdc := wire.NewCodec()
+	decoders := make(sdk.StoreDecoders)
+	decoders.Register("store1", sdk.KeyPrefixDecoder{
+		Prefix: []byte{0x01},
+		Decode: func(_ *wire.Codec, _, value []byte) (interface{}, error) {
+			return string(value), nil
+		},
+	})
+
+	diffs, err := diffState(cdc, decoders, db, 1, 2, "")
+	require.Nil(t, err)
+	require.Equal(t, []stateDiff{
+		{Store: "store1", Key: []byte{0x01, 'a'}, Change: "modified", Old: json.RawMessage(`"1"`), New: json.RawMessage(`"2"`)},
+		{Store: "store2", Key: []byte("b"), Change: "removed", Old: json.RawMessage(`"AB"`)},
+	}, diffs)
+
+	diffs, err = diffState(cdc, decoders, db, 1, 2, "store2")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(diffs))
+
+	_, err = diffState(cdc, decoders, db, 1, 2, "store3")
+	require.NotNil(t, err)
+	_, err = diffState(cdc, decoders, db, 1, 3, "")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// Snapshotter is implemented by the apps able to take and restore state-sync
+// snapshots, like those built on BaseApp.
+type Snapshotter interface {
+	Snapshots() ([]store.SnapshotManifest, error)
+	CreateSnapshot() (store.SnapshotManifest, error)
+	RestoreSnapshot(height int64, appHash []byte) error
+}
+
+// SnapshotsDir returns the directory the state-sync snapshots of the node are
+// kept in.
+func SnapshotsDir(home string) string {
+	return filepath.Join(home, "snapshots")
+}
+
+// SnapshotsCmd manages the state-sync snapshots of a stopped node.
+func SnapshotsCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage state-sync snapshots",
+	}
+	cmd.PersistentFlags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything, custom")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the snapshots",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				app, err := loadSnapshotter(ctx, appCreator)
+				if err != nil {
+					return err
+				}
+				manifests, err := app.Snapshots()
+				if err != nil {
+					return err
+				}
+				for _, manifest := range manifests {
+					fmt.Printf("height: %d hash: %X chunks: %d\n", manifest.Height, manifest.Hash, len(manifest.Chunks))
+				}
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "create",
+			Short: "Take a snapshot of the last committed state",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				app, err := loadSnapshotter(ctx, appCreator)
+				if err != nil {
+					return err
+				}
+				manifest, err := app.CreateSnapshot()
+				if err != nil {
+					return errors.Errorf("error taking snapshot: %v", err)
+				}
+				fmt.Printf("height: %d hash: %X chunks: %d\n", manifest.Height, manifest.Hash, len(manifest.Chunks))
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "restore [height] [app-hash]",
+			Short: "Restore the state of an empty node from a snapshot",
+			Long: `Restore the state of an empty node from its snapshot at the given height,
+verifying it against the given app hash of the block, which must come from a
+trusted source like a light client, as the snapshots are untrusted. Snapshots
+of other nodes can be restored by copying them to $HOME/snapshots. The
+Tendermint data of the node must be at the same height before it is started.`,
+			Args: cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				height, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+				appHash, err := hex.DecodeString(args[1])
+				if err != nil {
+					return errors.Errorf("invalid app hash: %v", err)
+				}
+				app, err := loadSnapshotter(ctx, appCreator)
+				if err != nil {
+					return err
+				}
+				err = app.RestoreSnapshot(height, appHash)
+				if err != nil {
+					return errors.Errorf("error restoring snapshot: %v", err)
+				}
+				fmt.Printf("Restored snapshot at height %d\n", height)
+				return nil
+			},
+		},
+	)
+	return cmd
+}
+
+func loadSnapshotter(ctx *Context, appCreator AppCreator) (Snapshotter, error) {
+	app, err := appCreator(viper.GetString("home"), ctx.Logger, "")
+	if err != nil {
+		return nil, err
+	}
+	snapshotter, ok := app.(Snapshotter)
+	if !ok {
+		return nil, errors.New("the app doesn't support snapshots")
+	}
+	return snapshotter, nil
+}
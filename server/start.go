@@ -12,13 +12,30 @@ import (
 	"github.com/tendermint/tendermint/node"
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
-	flagTraceStore     = "trace-store"
-	flagPruning        = "pruning"
+	flagWithTendermint    = "with-tendermint"
+	flagAddress           = "address"
+	flagTraceStore        = "trace-store"
+	flagPruning           = "pruning"
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+	flagPruningInterval   = "pruning-interval"
+
+	// FlagMinGasPrices is the minimum gas prices, in each denom, of the txs
+	// accepted by the node in CheckTx
+	FlagMinGasPrices = "minimum-gas-prices"
+
+	// FlagSnapshotInterval is the number of blocks between state-sync
+	// snapshots, 0 disabling them
+	FlagSnapshotInterval = "snapshot-interval"
+
+	// FlagSnapshotKeepRecent is the number of recent snapshots to keep, 0
+	// keeping all of them
+	FlagSnapshotKeepRecent = "snapshot-keep-recent"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -28,6 +45,11 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 		Use:   "start",
 		Short: "Run the full node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := GetPruningStrategy()
+			if err != nil {
+				return err
+			}
+
 			if !viper.GetBool(flagWithTendermint) {
 				ctx.Logger.Info("Starting ABCI without Tendermint")
 				return startStandAlone(ctx, appCreator)
@@ -35,7 +57,7 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 
 			ctx.Logger.Info("Starting ABCI with Tendermint")
 
-			_, err := startInProcess(ctx, appCreator)
+			_, err = startInProcess(ctx, appCreator)
 			return err
 		},
 	}
@@ -44,13 +66,31 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent versions to keep with the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Keep every n-th version with the custom pruning strategy; 0 keeps none")
+	cmd.Flags().Int64(flagPruningInterval, 1, "Number of blocks between pruning runs with the custom pruning strategy")
+	cmd.Flags().String(FlagMinGasPrices, "",
+		"Minimum gas prices to accept for transactions; any fee in a tx must meet this minimum (e.g. 0.01photino,0.0001stake)")
+	cmd.Flags().Int64(FlagSnapshotInterval, 0, "Number of blocks between state-sync snapshots; 0 disables them")
+	cmd.Flags().Int(FlagSnapshotKeepRecent, 2, "Number of recent state-sync snapshots to keep; 0 keeps all of them")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
 	return cmd
 }
 
+// GetPruningStrategy returns the pruning strategy set by the pruning flags or
+// the app config.
+func GetPruningStrategy() (sdk.PruningStrategy, error) {
+	return sdk.ParsePruningStrategy(
+		viper.GetString(flagPruning),
+		viper.GetInt64(flagPruningKeepRecent),
+		viper.GetInt64(flagPruningKeepEvery),
+		viper.GetInt64(flagPruningInterval),
+	)
+}
+
 func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	addr := viper.GetString(flagAddress)
 	home := viper.GetString("home")
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/wire"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
@@ -92,7 +93,21 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 
 	if conf == nil {
 		conf, err = tcmd.ParseConfig()
+		if err != nil {
+			return
+		}
 	}
+
+	// create a default app config file if it doesn't already exist, and merge
+	// it into the loaded configuration
+	appConfigFilePath := filepath.Join(rootDir, "config/app.toml")
+	if _, err := os.Stat(appConfigFilePath); os.IsNotExist(err) {
+		appConf, _ := config.ParseConfig()
+		config.WriteConfigFile(appConfigFilePath, appConf)
+	}
+
+	viper.SetConfigName("app")
+	err = viper.MergeInConfig()
 	return
 }
 
@@ -123,6 +138,7 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotsCmd(ctx, appCreator),
 		client.LineBreak,
 		version.VersionCmd,
 	)
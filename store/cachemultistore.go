@@ -18,9 +18,13 @@ type cacheMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	// records the changes of the stores if listening
+	changeSet *changeSetBuffer
 }
 
 var _ CacheMultiStore = cacheMultiStore{}
+var _ ListeningCacheMultiStore = cacheMultiStore{}
 
 func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
 	cms := cacheMultiStore{
@@ -42,6 +46,24 @@ func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
 	return cms
 }
 
+// newListeningCacheMultiStoreFromRMS returns a cacheMultiStore recording the
+// changes of the stores of the state, i.e. neither transient nor memory
+// stores, to changeSet.
+func newListeningCacheMultiStoreFromRMS(rms *rootMultiStore, changeSet *changeSetBuffer) cacheMultiStore {
+	cms := newCacheMultiStoreFromRMS(rms)
+	cms.changeSet = changeSet
+
+	for key, store := range cms.stores {
+		typ := rms.storesParams[key].typ
+		if typ == sdk.StoreTypeTransient || typ == sdk.StoreTypeMemory {
+			continue
+		}
+		cms.stores[key] = newListenKVStore(store.(CacheKVStore), key.Name(), changeSet)
+	}
+
+	return cms
+}
+
 func newCacheMultiStoreFromCMS(cms cacheMultiStore) cacheMultiStore {
 	cms2 := cacheMultiStore{
 		db:           NewCacheKVStore(cms.db),
@@ -95,6 +117,14 @@ func (cms cacheMultiStore) ResetTraceContext() MultiStore {
 	return cms
 }
 
+// SetTxIndex implements the ListeningCacheMultiStore interface. It is a no-op
+// if the cacheMultiStore isn't listening.
+func (cms cacheMultiStore) SetTxIndex(txIndex int64) {
+	if cms.changeSet != nil {
+		cms.changeSet.txIndex = txIndex
+	}
+}
+
 // Implements Store.
 func (cms cacheMultiStore) GetStoreType() StoreType {
 	return sdk.StoreTypeMulti
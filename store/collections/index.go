@@ -0,0 +1,75 @@
+package collections
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Index is a secondary index of the entries of a Map, passed to NewMap which
+// keeps it in sync with them. It maps the index key computed from each entry
+// to the keys of the entries, so that the entries sharing an index key are
+// found without iterating over the whole map.
+type Index struct {
+	prefix   []byte
+	kc       KeyCodec
+	indexKey func(key, value interface{}) interface{}
+
+	// set by the map of the index
+	storeKey sdk.StoreKey
+	mapKC    KeyCodec
+}
+
+// NewIndex returns an index stored under the prefix, whose index keys are
+// encoded by kc. The index key of an entry of the map is returned by
+// indexKey, the entries for which it returns nil aren't indexed.
+func NewIndex(prefix []byte, kc KeyCodec, indexKey func(key, value interface{}) interface{}) *Index {
+	return &Index{
+		prefix:   prefix,
+		kc:       kc,
+		indexKey: indexKey,
+	}
+}
+
+func (idx *Index) attach(storeKey sdk.StoreKey, mapKC KeyCodec) {
+	if idx.storeKey != nil {
+		panic("collections: index already used by a map")
+	}
+	idx.storeKey = storeKey
+	idx.mapKC = mapKC
+}
+
+func (idx *Index) indexPrefix(indexKey interface{}) []byte {
+	return append(append([]byte{}, idx.prefix...), idx.kc.EncodeNonTerminal(indexKey)...)
+}
+
+func (idx *Index) entryKey(indexKey, key interface{}) []byte {
+	return append(idx.indexPrefix(indexKey), idx.mapKC.Encode(key)...)
+}
+
+func (idx *Index) add(ctx sdk.Context, key, value interface{}) {
+	indexKey := idx.indexKey(key, value)
+	if indexKey == nil {
+		return
+	}
+	ctx.KVStore(idx.storeKey).Set(idx.entryKey(indexKey, key), presenceValue)
+}
+
+func (idx *Index) remove(ctx sdk.Context, key, value interface{}) {
+	indexKey := idx.indexKey(key, value)
+	if indexKey == nil {
+		return
+	}
+	ctx.KVStore(idx.storeKey).Delete(idx.entryKey(indexKey, key))
+}
+
+// Has returns whether the entry of the key of the map has the index key.
+func (idx *Index) Has(ctx sdk.Context, indexKey, key interface{}) bool {
+	return ctx.KVStore(idx.storeKey).Has(idx.entryKey(indexKey, key))
+}
+
+// Iterate returns an iterator over the keys of the entries of the map with
+// the index key, in key order. The values of the iterator are nil.
+func (idx *Index) Iterate(ctx sdk.Context, indexKey interface{}) Iterator {
+	prefix := idx.indexPrefix(indexKey)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(idx.storeKey), prefix)
+	return Iterator{iter, len(prefix), idx.mapKC, noValue{}}
+}
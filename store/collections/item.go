@@ -0,0 +1,118 @@
+package collections
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Item is a single typed value stored at a key of a KVStore.
+type Item struct {
+	storeKey sdk.StoreKey
+	key      []byte
+	vc       ValueCodec
+}
+
+// NewItem returns the item stored at the key of the store of the store key.
+func NewItem(storeKey sdk.StoreKey, key []byte, vc ValueCodec) Item {
+	return Item{
+		storeKey: storeKey,
+		key:      key,
+		vc:       vc,
+	}
+}
+
+// Get returns the value of the item, if set.
+func (it Item) Get(ctx sdk.Context) (value interface{}, found bool) {
+	bz := ctx.KVStore(it.storeKey).Get(it.key)
+	if bz == nil {
+		return nil, false
+	}
+	value, err := it.vc.Decode(bz)
+	if err != nil {
+		panic(err)
+	}
+	return value, true
+}
+
+// Set sets the value of the item.
+func (it Item) Set(ctx sdk.Context, value interface{}) {
+	bz, err := it.vc.Encode(value)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(it.storeKey).Set(it.key, bz)
+}
+
+// Remove unsets the item.
+func (it Item) Remove(ctx sdk.Context) {
+	ctx.KVStore(it.storeKey).Delete(it.key)
+}
+
+// Sequence is a counter stored at a key of a KVStore, starting at 0.
+type Sequence struct {
+	item Item
+}
+
+// NewSequence returns the sequence stored at the key of the store of the
+// store key.
+func NewSequence(storeKey sdk.StoreKey, key []byte) Sequence {
+	return Sequence{NewItem(storeKey, key, Uint64Value)}
+}
+
+// Peek returns the current value of the sequence.
+func (s Sequence) Peek(ctx sdk.Context) uint64 {
+	value, found := s.item.Get(ctx)
+	if !found {
+		return 0
+	}
+	return value.(uint64)
+}
+
+// Next returns the current value of the sequence and increments it.
+func (s Sequence) Next(ctx sdk.Context) uint64 {
+	value := s.Peek(ctx)
+	s.item.Set(ctx, value+1)
+	return value
+}
+
+// Set sets the current value of the sequence.
+func (s Sequence) Set(ctx sdk.Context, value uint64) {
+	s.item.Set(ctx, value)
+}
+
+// KeySet is a typed set of keys stored under a prefix of a KVStore, iterated
+// in key order.
+type KeySet struct {
+	m Map
+}
+
+// NewKeySet returns the set stored under the prefix of the store of the
+// store key, whose keys are encoded by kc.
+func NewKeySet(storeKey sdk.StoreKey, prefix []byte, kc KeyCodec) KeySet {
+	return KeySet{NewMap(storeKey, prefix, kc, noValue{})}
+}
+
+// Has returns whether the set holds the key.
+func (s KeySet) Has(ctx sdk.Context, key interface{}) bool {
+	return s.m.Has(ctx, key)
+}
+
+// Set adds the key to the set.
+func (s KeySet) Set(ctx sdk.Context, key interface{}) {
+	s.m.Set(ctx, key, nil)
+}
+
+// Remove removes the key from the set.
+func (s KeySet) Remove(ctx sdk.Context, key interface{}) {
+	s.m.Remove(ctx, key)
+}
+
+// Iterate returns an iterator over the keys of the range. The values of the
+// iterator are nil.
+func (s KeySet) Iterate(ctx sdk.Context, rng Range) Iterator {
+	return s.m.Iterate(ctx, rng)
+}
+
+// Clear removes the keys of the range.
+func (s KeySet) Clear(ctx sdk.Context, rng Range) {
+	s.m.Clear(ctx, rng)
+}
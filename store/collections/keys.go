@@ -0,0 +1,265 @@
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyCodec encodes the keys of a collection to bytes sorting like the keys,
+// so that the collection is iterated in key order.
+//
+// A key is encoded as the last part of a store key by Encode and as an inner
+// part, followed by other parts, by EncodeNonTerminal, whose encoding must
+// tell where it ends.
+type KeyCodec interface {
+	// Encode returns the bytes of the key. It panics if the key isn't of the
+	// type of the codec.
+	Encode(key interface{}) []byte
+	// Decode returns the key encoded by all of bz.
+	Decode(bz []byte) (interface{}, error)
+	// EncodeNonTerminal returns the bytes of the key followed by other parts.
+	EncodeNonTerminal(key interface{}) []byte
+	// DecodeNonTerminal returns the key encoded at the start of bz and the
+	// number of bytes read.
+	DecodeNonTerminal(bz []byte) (interface{}, int, error)
+}
+
+// nolint
+var (
+	Int64Key   KeyCodec = int64Key{}
+	Uint64Key  KeyCodec = uint64Key{}
+	StringKey  KeyCodec = stringKey{}
+	BytesKey   KeyCodec = bytesKey{}
+	AddressKey KeyCodec = addressKey{}
+)
+
+func keyTypeError(expected string, key interface{}) string {
+	return fmt.Sprintf("collections: expected key of type %s, got %T", expected, key)
+}
+
+//----------------------------------------
+// Integers, in big endian. The sign bit of the int64 keys is flipped so that
+// the negative keys sort first.
+
+type uint64Key struct{}
+
+func (uint64Key) Encode(key interface{}) []byte {
+	i, ok := key.(uint64)
+	if !ok {
+		panic(keyTypeError("uint64", key))
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, i)
+	return bz
+}
+
+func (c uint64Key) Decode(bz []byte) (interface{}, error) {
+	if len(bz) != 8 {
+		return nil, fmt.Errorf("collections: uint64 key of %d bytes", len(bz))
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+func (c uint64Key) EncodeNonTerminal(key interface{}) []byte {
+	return c.Encode(key)
+}
+
+func (c uint64Key) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	if len(bz) < 8 {
+		return nil, 0, fmt.Errorf("collections: uint64 key of %d bytes", len(bz))
+	}
+	key, err := c.Decode(bz[:8])
+	return key, 8, err
+}
+
+type int64Key struct{}
+
+func (int64Key) Encode(key interface{}) []byte {
+	i, ok := key.(int64)
+	if !ok {
+		panic(keyTypeError("int64", key))
+	}
+	return Uint64Key.Encode(uint64(i) ^ (1 << 63))
+}
+
+func (int64Key) Decode(bz []byte) (interface{}, error) {
+	u, err := Uint64Key.Decode(bz)
+	if err != nil {
+		return nil, err
+	}
+	return int64(u.(uint64) ^ (1 << 63)), nil
+}
+
+func (c int64Key) EncodeNonTerminal(key interface{}) []byte {
+	return c.Encode(key)
+}
+
+func (c int64Key) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	if len(bz) < 8 {
+		return nil, 0, fmt.Errorf("collections: int64 key of %d bytes", len(bz))
+	}
+	key, err := c.Decode(bz[:8])
+	return key, 8, err
+}
+
+//----------------------------------------
+// Byte strings, as is when terminal and prefixed with their length otherwise.
+// The inner parts thus sort by length first, which makes no difference for
+// keys of a fixed length like addresses.
+
+// maxNonTerminalLength is the maximum length of a non terminal byte string
+// key, whose length prefix is a single byte.
+const maxNonTerminalLength = math.MaxUint8
+
+func encodeLengthPrefixed(bz []byte) []byte {
+	if len(bz) > maxNonTerminalLength {
+		panic(fmt.Sprintf("collections: non terminal key of %d bytes, the maximum is %d", len(bz), maxNonTerminalLength))
+	}
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+func decodeLengthPrefixed(bz []byte) ([]byte, int, error) {
+	if len(bz) == 0 || len(bz) < 1+int(bz[0]) {
+		return nil, 0, fmt.Errorf("collections: truncated length prefixed key")
+	}
+	n := 1 + int(bz[0])
+	return append([]byte{}, bz[1:n]...), n, nil
+}
+
+type bytesKey struct{}
+
+func (bytesKey) Encode(key interface{}) []byte {
+	bz, ok := key.([]byte)
+	if !ok {
+		panic(keyTypeError("[]byte", key))
+	}
+	return bz
+}
+
+func (bytesKey) Decode(bz []byte) (interface{}, error) {
+	return append([]byte{}, bz...), nil
+}
+
+func (c bytesKey) EncodeNonTerminal(key interface{}) []byte {
+	return encodeLengthPrefixed(c.Encode(key))
+}
+
+func (bytesKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	return decodeLengthPrefixed(bz)
+}
+
+type stringKey struct{}
+
+func (stringKey) Encode(key interface{}) []byte {
+	s, ok := key.(string)
+	if !ok {
+		panic(keyTypeError("string", key))
+	}
+	return []byte(s)
+}
+
+func (stringKey) Decode(bz []byte) (interface{}, error) {
+	return string(bz), nil
+}
+
+func (c stringKey) EncodeNonTerminal(key interface{}) []byte {
+	return encodeLengthPrefixed(c.Encode(key))
+}
+
+func (stringKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	s, n, err := decodeLengthPrefixed(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	return string(s), n, nil
+}
+
+type addressKey struct{}
+
+func (addressKey) Encode(key interface{}) []byte {
+	addr, ok := key.(sdk.AccAddress)
+	if !ok {
+		panic(keyTypeError("sdk.AccAddress", key))
+	}
+	return addr
+}
+
+func (addressKey) Decode(bz []byte) (interface{}, error) {
+	return sdk.AccAddress(append([]byte{}, bz...)), nil
+}
+
+func (c addressKey) EncodeNonTerminal(key interface{}) []byte {
+	return encodeLengthPrefixed(c.Encode(key))
+}
+
+func (addressKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	addr, n, err := decodeLengthPrefixed(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sdk.AccAddress(addr), n, nil
+}
+
+//----------------------------------------
+// Pairs
+
+// Pair is a key made of two parts, encoded by a PairKeys codec. The entries
+// of a collection with pair keys are sorted by K1 then K2, so that the
+// entries sharing a K1 can be iterated with a Range prefix.
+type Pair struct {
+	K1 interface{}
+	K2 interface{}
+}
+
+// PairKeys returns the codec of the Pair keys whose parts are encoded by k1
+// and k2.
+func PairKeys(k1, k2 KeyCodec) KeyCodec {
+	return pairKey{k1, k2}
+}
+
+type pairKey struct {
+	k1, k2 KeyCodec
+}
+
+func (c pairKey) Encode(key interface{}) []byte {
+	pair, ok := key.(Pair)
+	if !ok {
+		panic(keyTypeError("collections.Pair", key))
+	}
+	return append(c.k1.EncodeNonTerminal(pair.K1), c.k2.Encode(pair.K2)...)
+}
+
+func (c pairKey) Decode(bz []byte) (interface{}, error) {
+	k1, n, err := c.k1.DecodeNonTerminal(bz)
+	if err != nil {
+		return nil, err
+	}
+	k2, err := c.k2.Decode(bz[n:])
+	if err != nil {
+		return nil, err
+	}
+	return Pair{k1, k2}, nil
+}
+
+func (c pairKey) EncodeNonTerminal(key interface{}) []byte {
+	pair, ok := key.(Pair)
+	if !ok {
+		panic(keyTypeError("collections.Pair", key))
+	}
+	return append(c.k1.EncodeNonTerminal(pair.K1), c.k2.EncodeNonTerminal(pair.K2)...)
+}
+
+func (c pairKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	k1, n1, err := c.k1.DecodeNonTerminal(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	k2, n2, err := c.k2.DecodeNonTerminal(bz[n1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return Pair{k1, k2}, n1 + n2, nil
+}
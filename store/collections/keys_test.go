@@ -0,0 +1,49 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestKeyCodecs(t *testing.T) {
+	cases := []struct {
+		kc   KeyCodec
+		keys []interface{} // in ascending order
+	}{
+		{Int64Key, []interface{}{int64(-1 << 63), int64(-2), int64(-1), int64(0), int64(1), int64(1<<63 - 1)}},
+		{Uint64Key, []interface{}{uint64(0), uint64(1), uint64(256), uint64(1<<64 - 1)}},
+		{StringKey, []interface{}{"", "a", "ab", "b"}},
+		{BytesKey, []interface{}{[]byte{}, []byte{0x00}, []byte{0x00, 0xff}, []byte{0x01}}},
+		{AddressKey, []interface{}{sdk.AccAddress{0x01, 0x02}, sdk.AccAddress{0x02, 0x01}}},
+		{PairKeys(Int64Key, StringKey), []interface{}{Pair{int64(1), "b"}, Pair{int64(2), "a"}, Pair{int64(2), "b"}}},
+	}
+	for _, tc := range cases {
+		for i, key := range tc.keys {
+			// the keys round trip
+			bz := tc.kc.Encode(key)
+			decoded, err := tc.kc.Decode(bz)
+			require.Nil(t, err)
+			require.Equal(t, key, decoded)
+
+			nonTerminal := append(tc.kc.EncodeNonTerminal(key), 0x42)
+			decoded, n, err := tc.kc.DecodeNonTerminal(nonTerminal)
+			require.Nil(t, err)
+			require.Equal(t, key, decoded)
+			require.Equal(t, len(nonTerminal)-1, n)
+
+			// and sort like the keys
+			if i > 0 {
+				require.True(t, bytes.Compare(tc.kc.Encode(tc.keys[i-1]), bz) < 0, "%v < %v", tc.keys[i-1], key)
+			}
+		}
+	}
+
+	require.Panics(t, func() { Int64Key.Encode("1") })
+	require.Panics(t, func() { BytesKey.EncodeNonTerminal(make([]byte, 256)) })
+	_, _, err := BytesKey.DecodeNonTerminal([]byte{0x02, 0x00})
+	require.NotNil(t, err)
+}
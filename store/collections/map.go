@@ -0,0 +1,218 @@
+// Package collections provides typed collections of values stored in a
+// KVStore, so that the keepers don't encode their keys and values by hand.
+package collections
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Map is a typed mapping of keys to values stored under a prefix of a
+// KVStore, the keys and values being encoded by its codecs. The entries are
+// iterated in key order.
+//
+// Go having no generics, the keys and values are passed as interface{} and
+// must be of the types of the codecs; the codecs panic otherwise. The values
+// returned are of these types, to be asserted by the caller. The store being
+// trusted, an entry failing to decode panics too.
+type Map struct {
+	storeKey sdk.StoreKey
+	prefix   []byte
+	kc       KeyCodec
+	vc       ValueCodec
+	indexes  []*Index
+}
+
+// NewMap returns the map stored under the prefix of the store of the key.
+// The indexes are kept in sync with its entries, their prefixes must not
+// overlap with the prefix of the map or of other collections of the store.
+func NewMap(storeKey sdk.StoreKey, prefix []byte, kc KeyCodec, vc ValueCodec, indexes ...*Index) Map {
+	for _, idx := range indexes {
+		idx.attach(storeKey, kc)
+	}
+	return Map{
+		storeKey: storeKey,
+		prefix:   prefix,
+		kc:       kc,
+		vc:       vc,
+		indexes:  indexes,
+	}
+}
+
+func (m Map) storeKeyOf(key interface{}) []byte {
+	return append(append([]byte{}, m.prefix...), m.kc.Encode(key)...)
+}
+
+func (m Map) decodeValue(bz []byte) interface{} {
+	value, err := m.vc.Decode(bz)
+	if err != nil {
+		panic(fmt.Sprintf("collections: failed to decode value: %v", err))
+	}
+	return value
+}
+
+// Get returns the value of the key, if any.
+func (m Map) Get(ctx sdk.Context, key interface{}) (value interface{}, found bool) {
+	bz := ctx.KVStore(m.storeKey).Get(m.storeKeyOf(key))
+	if bz == nil {
+		return nil, false
+	}
+	return m.decodeValue(bz), true
+}
+
+// Has returns whether the key has a value.
+func (m Map) Has(ctx sdk.Context, key interface{}) bool {
+	return ctx.KVStore(m.storeKey).Has(m.storeKeyOf(key))
+}
+
+// Set sets the value of the key, updating the indexes.
+func (m Map) Set(ctx sdk.Context, key, value interface{}) {
+	bz, err := m.vc.Encode(value)
+	if err != nil {
+		panic(err)
+	}
+	if len(m.indexes) > 0 {
+		old, found := m.Get(ctx, key)
+		for _, idx := range m.indexes {
+			if found {
+				idx.remove(ctx, key, old)
+			}
+			idx.add(ctx, key, value)
+		}
+	}
+	ctx.KVStore(m.storeKey).Set(m.storeKeyOf(key), bz)
+}
+
+// Remove removes the value of the key, if any, updating the indexes.
+func (m Map) Remove(ctx sdk.Context, key interface{}) {
+	if len(m.indexes) > 0 {
+		old, found := m.Get(ctx, key)
+		if !found {
+			return
+		}
+		for _, idx := range m.indexes {
+			idx.remove(ctx, key, old)
+		}
+	}
+	ctx.KVStore(m.storeKey).Delete(m.storeKeyOf(key))
+}
+
+// Iterate returns an iterator over the entries of the range, in key order or
+// in reverse.
+func (m Map) Iterate(ctx sdk.Context, rng Range) Iterator {
+	start, end := m.bounds(rng)
+	store := ctx.KVStore(m.storeKey)
+	var iter sdk.Iterator
+	if rng.Reverse {
+		iter = store.ReverseIterator(start, end)
+	} else {
+		iter = store.Iterator(start, end)
+	}
+	return Iterator{iter, len(m.prefix), m.kc, m.vc}
+}
+
+// Walk calls fn on the entries of the range in order, until it returns true.
+func (m Map) Walk(ctx sdk.Context, rng Range, fn func(key, value interface{}) (stop bool)) {
+	iter := m.Iterate(ctx, rng)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if fn(iter.Key(), iter.Value()) {
+			return
+		}
+	}
+}
+
+// Clear removes the entries of the range, updating the indexes.
+func (m Map) Clear(ctx sdk.Context, rng Range) {
+	var keys []interface{}
+	iter := m.Iterate(ctx, rng)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		m.Remove(ctx, key)
+	}
+}
+
+// Range bounds the iteration of a collection. Its zero value ranges over all
+// the entries in key order.
+type Range struct {
+	// Prefix, if not nil, restricts the range to the Pair keys whose K1 is
+	// Prefix, Start and End then being bounds of their K2.
+	Prefix interface{}
+	// Start is the inclusive lower bound of the range, if not nil.
+	Start interface{}
+	// End is the exclusive upper bound of the range, if not nil.
+	End interface{}
+	// Reverse iterates the range in descending key order.
+	Reverse bool
+}
+
+// bounds returns the store keys bounding the range of a collection stored
+// under the prefix.
+func (m Map) bounds(rng Range) (start, end []byte) {
+	prefix := append([]byte{}, m.prefix...)
+	kc := m.kc
+	if rng.Prefix != nil {
+		pc, ok := m.kc.(pairKey)
+		if !ok {
+			panic("collections: range prefix of a collection without pair keys")
+		}
+		prefix = append(prefix, pc.k1.EncodeNonTerminal(rng.Prefix)...)
+		kc = pc.k2
+	}
+
+	start = prefix
+	if rng.Start != nil {
+		start = append(append([]byte{}, prefix...), kc.Encode(rng.Start)...)
+	}
+	end = sdk.PrefixEndBytes(prefix)
+	if rng.End != nil {
+		end = append(append([]byte{}, prefix...), kc.Encode(rng.End)...)
+	}
+	return start, end
+}
+
+// Iterator iterates over the entries of a collection. It must be closed.
+type Iterator struct {
+	iter      sdk.Iterator
+	prefixLen int
+	kc        KeyCodec
+	vc        ValueCodec
+}
+
+// Valid returns whether the iterator is at an entry.
+func (it Iterator) Valid() bool {
+	return it.iter.Valid()
+}
+
+// Next moves the iterator to the next entry.
+func (it Iterator) Next() {
+	it.iter.Next()
+}
+
+// Close releases the iterator.
+func (it Iterator) Close() {
+	it.iter.Close()
+}
+
+// Key returns the key of the current entry.
+func (it Iterator) Key() interface{} {
+	key, err := it.kc.Decode(it.iter.Key()[it.prefixLen:])
+	if err != nil {
+		panic(fmt.Sprintf("collections: failed to decode key: %v", err))
+	}
+	return key
+}
+
+// Value returns the value of the current entry, nil for the key sets and
+// indexes.
+func (it Iterator) Value() interface{} {
+	value, err := it.vc.Decode(it.iter.Value())
+	if err != nil {
+		panic(fmt.Sprintf("collections: failed to decode value: %v", err))
+	}
+	return value
+}
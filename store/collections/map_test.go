@@ -0,0 +1,135 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+type testValue struct {
+	Name  string
+	Owner sdk.AccAddress
+}
+
+func defaultContext(key sdk.StoreKey) sdk.Context {
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+	return ctx
+}
+
+func collectKeys(iter Iterator) (keys []interface{}) {
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	return keys
+}
+
+func TestMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	cdc := wire.NewCodec()
+	owner1, owner2 := sdk.AccAddress{0x01}, sdk.AccAddress{0x02}
+
+	byOwner := NewIndex([]byte{0x02}, AddressKey, func(key, value interface{}) interface{} {
+		return value.(testValue).Owner
+	})
+	m := NewMap(key, []byte{0x01}, PairKeys(Int64Key, StringKey), AminoValue(cdc, testValue{}), byOwner)
+
+	m.Set(ctx, Pair{int64(1), "a"}, testValue{"1a", owner1})
+	m.Set(ctx, Pair{int64(1), "b"}, testValue{"1b", owner2})
+	m.Set(ctx, Pair{int64(1), "c"}, testValue{"1c", owner1})
+	m.Set(ctx, Pair{int64(2), "a"}, testValue{"2a", owner1})
+	require.Panics(t, func() { m.Set(ctx, int64(3), testValue{}) })
+	require.Panics(t, func() { m.Set(ctx, Pair{int64(3), "a"}, &testValue{}) })
+
+	value, found := m.Get(ctx, Pair{int64(1), "b"})
+	require.True(t, found)
+	require.Equal(t, testValue{"1b", owner2}, value)
+	_, found = m.Get(ctx, Pair{int64(2), "b"})
+	require.False(t, found)
+	require.True(t, m.Has(ctx, Pair{int64(2), "a"}))
+
+	// ranges
+	require.Equal(t, []interface{}{
+		Pair{int64(1), "a"}, Pair{int64(1), "b"}, Pair{int64(1), "c"}, Pair{int64(2), "a"},
+	}, collectKeys(m.Iterate(ctx, Range{})))
+	require.Equal(t, []interface{}{
+		Pair{int64(1), "c"}, Pair{int64(1), "b"}, Pair{int64(1), "a"},
+	}, collectKeys(m.Iterate(ctx, Range{Prefix: int64(1), Reverse: true})))
+	require.Equal(t, []interface{}{
+		Pair{int64(1), "b"},
+	}, collectKeys(m.Iterate(ctx, Range{Prefix: int64(1), Start: "b", End: "c"})))
+	require.Equal(t, []interface{}{
+		Pair{int64(1), "c"}, Pair{int64(2), "a"},
+	}, collectKeys(m.Iterate(ctx, Range{Start: Pair{int64(1), "c"}})))
+
+	var names []string
+	m.Walk(ctx, Range{}, func(key, value interface{}) bool {
+		names = append(names, value.(testValue).Name)
+		return len(names) == 2
+	})
+	require.Equal(t, []string{"1a", "1b"}, names)
+
+	// the index follows the writes
+	require.Equal(t, []interface{}{
+		Pair{int64(1), "a"}, Pair{int64(1), "c"}, Pair{int64(2), "a"},
+	}, collectKeys(byOwner.Iterate(ctx, owner1)))
+	m.Set(ctx, Pair{int64(1), "a"}, testValue{"1a", owner2})
+	m.Remove(ctx, Pair{int64(2), "a"})
+	require.False(t, byOwner.Has(ctx, owner1, Pair{int64(1), "a"}))
+	require.True(t, byOwner.Has(ctx, owner2, Pair{int64(1), "a"}))
+	require.Equal(t, []interface{}{Pair{int64(1), "c"}}, collectKeys(byOwner.Iterate(ctx, owner1)))
+
+	m.Clear(ctx, Range{Prefix: int64(1)})
+	require.Empty(t, collectKeys(m.Iterate(ctx, Range{})))
+	require.Empty(t, collectKeys(byOwner.Iterate(ctx, owner1)))
+	require.Empty(t, collectKeys(byOwner.Iterate(ctx, owner2)))
+
+	require.Panics(t, func() { NewMap(key, []byte{0x03}, Int64Key, Uint64Value, byOwner) })
+}
+
+func TestItemSequenceKeySet(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	cdc := wire.NewCodec()
+
+	item := NewItem(key, []byte{0x01}, AminoValue(cdc, &testValue{}))
+	_, found := item.Get(ctx)
+	require.False(t, found)
+	item.Set(ctx, &testValue{Name: "item"})
+	value, found := item.Get(ctx)
+	require.True(t, found)
+	require.Equal(t, &testValue{Name: "item"}, value)
+	item.Remove(ctx)
+	_, found = item.Get(ctx)
+	require.False(t, found)
+
+	seq := NewSequence(key, []byte{0x02})
+	require.Equal(t, uint64(0), seq.Next(ctx))
+	require.Equal(t, uint64(1), seq.Next(ctx))
+	require.Equal(t, uint64(2), seq.Peek(ctx))
+	seq.Set(ctx, 10)
+	require.Equal(t, uint64(10), seq.Next(ctx))
+
+	set := NewKeySet(key, []byte{0x03}, Uint64Key)
+	set.Set(ctx, uint64(5))
+	set.Set(ctx, uint64(3))
+	set.Set(ctx, uint64(9))
+	require.True(t, set.Has(ctx, uint64(3)))
+	require.False(t, set.Has(ctx, uint64(4)))
+	require.Equal(t, []interface{}{uint64(9), uint64(5)}, collectKeys(set.Iterate(ctx, Range{Start: uint64(4), Reverse: true})))
+	set.Remove(ctx, uint64(5))
+	set.Clear(ctx, Range{End: uint64(4)})
+	require.Equal(t, []interface{}{uint64(9)}, collectKeys(set.Iterate(ctx, Range{})))
+}
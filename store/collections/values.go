@@ -0,0 +1,84 @@
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// ValueCodec encodes the values of a collection to bytes.
+type ValueCodec interface {
+	// Encode returns the bytes of the value.
+	Encode(value interface{}) ([]byte, error)
+	// Decode returns the value encoded by bz.
+	Decode(bz []byte) (interface{}, error)
+}
+
+// AminoValue returns the codec of the values of the type of the prototype,
+// binary encoded by cdc. The prototype must be of a concrete type; the
+// decoded values are of its type, pointer or not.
+func AminoValue(cdc *wire.Codec, prototype interface{}) ValueCodec {
+	return aminoValue{cdc, reflect.TypeOf(prototype)}
+}
+
+type aminoValue struct {
+	cdc *wire.Codec
+	typ reflect.Type
+}
+
+func (c aminoValue) Encode(value interface{}) ([]byte, error) {
+	if reflect.TypeOf(value) != c.typ {
+		return nil, fmt.Errorf("collections: expected value of type %v, got %T", c.typ, value)
+	}
+	return c.cdc.MarshalBinary(value)
+}
+
+func (c aminoValue) Decode(bz []byte) (interface{}, error) {
+	if c.typ.Kind() == reflect.Ptr {
+		ptr := reflect.New(c.typ.Elem())
+		err := c.cdc.UnmarshalBinary(bz, ptr.Interface())
+		return ptr.Interface(), err
+	}
+	ptr := reflect.New(c.typ)
+	err := c.cdc.UnmarshalBinary(bz, ptr.Interface())
+	return ptr.Elem().Interface(), err
+}
+
+// Uint64Value encodes uint64 values in big endian.
+var Uint64Value ValueCodec = uint64Value{}
+
+type uint64Value struct{}
+
+func (uint64Value) Encode(value interface{}) ([]byte, error) {
+	i, ok := value.(uint64)
+	if !ok {
+		return nil, fmt.Errorf("collections: expected value of type uint64, got %T", value)
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, i)
+	return bz, nil
+}
+
+func (uint64Value) Decode(bz []byte) (interface{}, error) {
+	if len(bz) != 8 {
+		return nil, fmt.Errorf("collections: uint64 value of %d bytes", len(bz))
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// presenceValue is the value of the entries of the key sets and indexes, the
+// presence of their key being the information. It isn't empty as the stores
+// can't tell an empty value from a missing one.
+var presenceValue = []byte{0x01}
+
+type noValue struct{}
+
+func (noValue) Encode(value interface{}) ([]byte, error) {
+	return presenceValue, nil
+}
+
+func (noValue) Decode(bz []byte) (interface{}, error) {
+	return nil, nil
+}
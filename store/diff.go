@@ -0,0 +1,94 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KVDiff is a key whose value differs between two versions of a store. Old
+// is nil if the key was added and New is nil if it was removed.
+type KVDiff struct {
+	Key []byte
+	Old []byte
+	New []byte
+}
+
+// LoadStoreCommitIDs returns the commit IDs of the stores of the
+// rootMultiStore persisted to db at version, by store name.
+func LoadStoreCommitIDs(db dbm.DB, version int64) (map[string]CommitID, error) {
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return nil, fmt.Errorf("version %d: %v", version, err)
+	}
+	commitIDs := make(map[string]CommitID, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		commitIDs[storeInfo.Name] = storeInfo.Core.CommitID
+	}
+	return commitIDs, nil
+}
+
+// DiffIAVLStore returns the keys whose value differs between the from and to
+// versions of the IAVL store of the given name persisted to the db of a
+// rootMultiStore, in ascending order. A zero CommitID stands for an empty
+// store, i.e. a store added or deleted between the versions.
+func DiffIAVLStore(db dbm.DB, name string, from, to CommitID) ([]KVDiff, error) {
+	fromIter, err := iavlStoreIterator(db, name, from)
+	if err != nil {
+		return nil, err
+	}
+	defer fromIter.Close()
+	toIter, err := iavlStoreIterator(db, name, to)
+	if err != nil {
+		return nil, err
+	}
+	defer toIter.Close()
+
+	var diffs []KVDiff
+	for fromIter.Valid() || toIter.Valid() {
+		cmp := 0
+		switch {
+		case !fromIter.Valid():
+			cmp = 1
+		case !toIter.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(fromIter.Key(), toIter.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			diffs = append(diffs, KVDiff{Key: fromIter.Key(), Old: fromIter.Value()})
+			fromIter.Next()
+		case cmp > 0:
+			diffs = append(diffs, KVDiff{Key: toIter.Key(), New: toIter.Value()})
+			toIter.Next()
+		default:
+			if !bytes.Equal(fromIter.Value(), toIter.Value()) {
+				diffs = append(diffs, KVDiff{Key: fromIter.Key(), Old: fromIter.Value(), New: toIter.Value()})
+			}
+			fromIter.Next()
+			toIter.Next()
+		}
+	}
+	return diffs, nil
+}
+
+// iavlStoreIterator iterates over the version of the IAVL store of the given
+// name, loaded without pruning.
+func iavlStoreIterator(db dbm.DB, name string, id CommitID) (Iterator, error) {
+	if id.IsZero() {
+		return newMemIterator(nil, nil, nil), nil
+	}
+	store, err := LoadIAVLStore(dbm.NewPrefixDB(db, storePrefix(name)), id, sdk.PruneNothing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load store %s at version %d: %v", name, id.Version, err)
+	}
+	if !bytes.Equal(store.LastCommitID().Hash, id.Hash) {
+		return nil, fmt.Errorf("store %s at version %d doesn't match hash %X", name, id.Version, id.Hash)
+	}
+	return store.(KVStore).Iterator(nil, nil), nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestDiffIAVLStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	store1 := store.getStoreByName("store1").(KVStore)
+
+	store1.Set([]byte("a"), []byte("1"))
+	store1.Set([]byte("b"), []byte("2"))
+	store1.Set([]byte("c"), []byte("3"))
+	store.Commit()
+	store1.Set([]byte("b"), []byte("4"))
+	store1.Delete([]byte("c"))
+	store1.Set([]byte("d"), []byte("5"))
+	store.Commit()
+	store1.Set([]byte("b"), []byte("2"))
+	store.Commit()
+
+	from, err := LoadStoreCommitIDs(db, 1)
+	require.Nil(t, err)
+	to, err := LoadStoreCommitIDs(db, 2)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(to))
+
+	diffs, err := DiffIAVLStore(db, "store1", from["store1"], to["store1"])
+	require.Nil(t, err)
+	require.Equal(t, []KVDiff{
+		{Key: []byte("b"), Old: []byte("2"), New: []byte("4")},
+		{Key: []byte("c"), Old: []byte("3")},
+		{Key: []byte("d"), New: []byte("5")},
+	}, diffs)
+
+	// a key set back to its value isn't modified
+	to, err = LoadStoreCommitIDs(db, 3)
+	require.Nil(t, err)
+	diffs, err = DiffIAVLStore(db, "store1", from["store1"], to["store1"])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(diffs))
+
+	// all the keys of a missing store are added or removed
+	diffs, err = DiffIAVLStore(db, "store1", CommitID{}, from["store1"])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(diffs))
+	diffs, err = DiffIAVLStore(db, "store2", from["store2"], to["store2"])
+	require.Nil(t, err)
+	require.Empty(t, diffs)
+
+	_, err = LoadStoreCommitIDs(db, 4)
+	require.NotNil(t, err)
+}
@@ -87,7 +87,7 @@ func testGasKVStoreWrap(t *testing.T, store KVStore) {
 func TestGasKVStoreWrap(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavl := newIAVLStore(tree, numRecent, storeEvery)
+	iavl := newIAVLStore(tree, pruning)
 	testGasKVStoreWrap(t, iavl)
 
 	st := NewCacheKVStore(iavl)
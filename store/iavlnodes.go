@@ -0,0 +1,149 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// The snapshots and the store upgrades read and write the trees of the IAVL
+// stores from their dbs directly, in the node db format of tendermint/iavl
+// v0.9: the nodes are saved under their hash and the roots under their
+// version.
+const (
+	iavlNodeKeyFmt = "n/%X"    // n/<hash>
+	iavlRootKeyFmt = "r/%010d" // r/<version>
+)
+
+func iavlNodeKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf(iavlNodeKeyFmt, hash))
+}
+
+func iavlRootKey(version int64) []byte {
+	return []byte(fmt.Sprintf(iavlRootKeyFmt, version))
+}
+
+// iavlNode is a decoded IAVL node. Leaves have a value, inner nodes the
+// hashes of their children.
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+// decodeIAVLNode decodes a node as persisted by the node db.
+func decodeIAVLNode(bz []byte) (node iavlNode, err error) {
+	var n int
+	if node.height, n, err = amino.DecodeInt8(bz); err != nil {
+		return node, fmt.Errorf("invalid node height: %v", err)
+	}
+	bz = bz[n:]
+	if node.size, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("invalid node size: %v", err)
+	}
+	bz = bz[n:]
+	if node.version, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("invalid node version: %v", err)
+	}
+	bz = bz[n:]
+	if node.key, n, err = amino.DecodeByteSlice(bz); err != nil {
+		return node, fmt.Errorf("invalid node key: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.isLeaf() {
+		if node.value, n, err = amino.DecodeByteSlice(bz); err != nil {
+			return node, fmt.Errorf("invalid node value: %v", err)
+		}
+		bz = bz[n:]
+	} else {
+		if node.leftHash, n, err = amino.DecodeByteSlice(bz); err != nil {
+			return node, fmt.Errorf("invalid left child hash: %v", err)
+		}
+		bz = bz[n:]
+		if node.rightHash, n, err = amino.DecodeByteSlice(bz); err != nil {
+			return node, fmt.Errorf("invalid right child hash: %v", err)
+		}
+		bz = bz[n:]
+		if len(node.leftHash) == 0 || len(node.rightHash) == 0 {
+			return node, fmt.Errorf("inner node without children")
+		}
+	}
+	if len(bz) != 0 {
+		return node, fmt.Errorf("node has %d trailing bytes", len(bz))
+	}
+	return node, nil
+}
+
+func (node iavlNode) isLeaf() bool {
+	return node.height == 0
+}
+
+// hash computes the hash of the node from its content, the inner nodes
+// committing to their children through their hashes.
+func (node iavlNode) hash() []byte {
+	var buf bytes.Buffer
+	// writing to a buffer doesn't fail
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	if node.isLeaf() {
+		_ = amino.EncodeByteSlice(&buf, node.key)
+		_ = amino.EncodeByteSlice(&buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
+
+// walkIAVLTree calls fn on the encoded nodes of the tree of root, parents
+// before their children, checking that each node matches the hash it is
+// referenced by. The tree is thereby verified from its leaves up to root.
+func walkIAVLTree(db dbm.DB, root []byte, fn func(bz []byte) error) error {
+	if len(root) == 0 {
+		// empty tree
+		return nil
+	}
+	hashes := [][]byte{root}
+	for len(hashes) > 0 {
+		hash := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+
+		bz := db.Get(iavlNodeKey(hash))
+		if bz == nil {
+			return fmt.Errorf("missing node %X", hash)
+		}
+		node, err := decodeIAVLNode(bz)
+		if err != nil {
+			return fmt.Errorf("node %X: %v", hash, err)
+		}
+		if !bytes.Equal(node.hash(), hash) {
+			return fmt.Errorf("node %X doesn't match its hash", hash)
+		}
+		if err = fn(bz); err != nil {
+			return err
+		}
+		if !node.isLeaf() {
+			hashes = append(hashes, node.rightHash, node.leftHash)
+		}
+	}
+	return nil
+}
+
+// setIAVLRoot sets the root of the tree of version, loaded as the latest
+// version of the tree provided that none follows it.
+func setIAVLRoot(db dbm.DB, version int64, root []byte) {
+	if root == nil {
+		// empty tree
+		root = []byte{}
+	}
+	db.Set(iavlRootKey(version), root)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sync"
@@ -16,6 +17,11 @@ import (
 
 const (
 	defaultIAVLCacheSize = 10000
+
+	// maximum number of versions deleted by a pruning run on top of the
+	// versions released since the previous run, so a large backlog, e.g.
+	// after switching to a stricter strategy, doesn't stall Commit
+	pruneBatchSize = 100
 )
 
 // load the iavl store
@@ -25,8 +31,7 @@ func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy) (CommitS
 	if err != nil {
 		return nil, err
 	}
-	iavl := newIAVLStore(tree, int64(0), int64(0))
-	iavl.SetPruning(pruning)
+	iavl := newIAVLStore(tree, pruning)
 	return iavl, nil
 }
 
@@ -42,26 +47,23 @@ type iavlStore struct {
 	// The underlying tree.
 	tree *iavl.VersionedTree
 
-	// How many old versions we hold onto.
-	// A value of 0 means keep no recent states.
-	numRecent int64
-
-	// This is the distance between state-sync waypoint states to be stored.
+	// The versions to keep: the KeepRecent latest ones, and every KeepEvery-th
+	// one as state-sync waypoints.
 	// See https://github.com/tendermint/tendermint/issues/828
-	// A value of 1 means store every state.
-	// A value of 0 means store no waypoints. (node cannot assist in state-sync)
-	// By default this value should be set the same across all nodes,
+	// By default KeepEvery should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
-	storeEvery int64
+	pruning sdk.PruningStrategy
+
+	// The released versions waiting for the next pruning run, from the oldest.
+	pruneVersions []int64
 }
 
 // CONTRACT: tree should be fully loaded.
-func newIAVLStore(tree *iavl.VersionedTree, numRecent int64, storeEvery int64) *iavlStore {
+func newIAVLStore(tree *iavl.VersionedTree, pruning sdk.PruningStrategy) *iavlStore {
 	st := &iavlStore{
-		tree:       tree,
-		numRecent:  numRecent,
-		storeEvery: storeEvery,
+		tree: tree,
 	}
+	st.SetPruning(pruning)
 	return st
 }
 
@@ -76,22 +78,44 @@ func (st *iavlStore) Commit() CommitID {
 
 	// Release an old version of history, if not a sync waypoint.
 	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
+	if st.pruning.KeepRecent < previous {
+		toRelease := previous - st.pruning.KeepRecent
+		if !st.pruning.KeepVersion(toRelease) {
+			st.pruneVersions = append(st.pruneVersions, toRelease)
 		}
 	}
 
+	if st.pruning.Interval <= 1 || version%st.pruning.Interval == 0 {
+		st.prune()
+	}
+
 	return CommitID{
 		Version: version,
 		Hash:    hash,
 	}
 }
 
+// prune deletes the versions released since the previous run, and at most
+// pruneBatchSize older ones.
+func (st *iavlStore) prune() {
+	interval := st.pruning.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	n := int64(len(st.pruneVersions))
+	if n > interval+pruneBatchSize {
+		n = interval + pruneBatchSize
+	}
+
+	for _, version := range st.pruneVersions[:n] {
+		err := st.tree.DeleteVersion(version)
+		if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+			panic(err)
+		}
+	}
+	st.pruneVersions = st.pruneVersions[n:]
+}
+
 // Implements Committer.
 func (st *iavlStore) LastCommitID() CommitID {
 	return CommitID{
@@ -100,17 +124,17 @@ func (st *iavlStore) LastCommitID() CommitID {
 	}
 }
 
-// Implements Committer.
+// Implements Committer. The stored versions the strategy doesn't keep are
+// queued for deletion by the next pruning runs.
 func (st *iavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100
-		st.storeEvery = 10000
+	st.pruning = pruning
+	st.pruneVersions = nil
+
+	latest := st.tree.Version64()
+	for version := int64(1); version < latest-pruning.KeepRecent; version++ {
+		if !pruning.KeepVersion(version) && st.tree.VersionExists(version) {
+			st.pruneVersions = append(st.pruneVersions, version)
+		}
 	}
 }
 
@@ -189,6 +213,52 @@ func getHeight(tree *iavl.VersionedTree, req abci.RequestQuery) int64 {
 	return height
 }
 
+// getVersionedSubspace returns the entries of the subspace at version and
+// their range proof. The proof extends to the first leaf following the
+// subspace, if any, so that it also proves that no entry was left out at
+// either edge. The proof of an empty tree has no leaves.
+func getVersionedSubspace(tree *iavl.VersionedTree, subspace []byte, version int64) (kvs []KVPair, proof *iavl.RangeProof, err error) {
+	end := sdk.PrefixEndBytes(subspace)
+	if end != nil {
+		next, _, _, err := tree.GetVersionedRangeWithProof(end, nil, 1, version)
+		if isNilRoot(err) {
+			return nil, &iavl.RangeProof{}, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		// the range end is exclusive
+		end = nil
+		if len(next) > 0 {
+			end = append(append([]byte{}, next[0]...), 0x00)
+		}
+	}
+
+	keys, values, proof, err := tree.GetVersionedRangeWithProof(subspace, end, 0, version)
+	if isNilRoot(err) {
+		return nil, &iavl.RangeProof{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, key := range keys {
+		// skip the leaf following the subspace
+		if !bytes.HasPrefix(key, subspace) {
+			continue
+		}
+		kvs = append(kvs, KVPair{Key: key, Value: values[i]})
+	}
+	return kvs, proof, nil
+}
+
+// isNilRoot returns whether err is returned for a range of an empty tree.
+func isNilRoot(err error) bool {
+	if cmnErr, ok := err.(cmn.Error); ok {
+		return cmnErr.Data() == iavl.ErrNilRoot
+	}
+	return err == iavl.ErrNilRoot
+}
+
 // Query implements ABCI interface, allows queries
 //
 // by default we will return from (latest height -1),
@@ -236,12 +306,18 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	case "/subspace":
 		subspace := req.Data
 		res.Key = subspace
-		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
+		if !st.VersionExists(res.Height) {
+			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
+			break
+		}
+		KVs, proof, err := getVersionedSubspace(tree, subspace, res.Height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
+		if req.Prove {
+			res.Proof = cdc.MustMarshalBinary(proof)
 		}
-		iterator.Close()
 		res.Value = cdc.MustMarshalBinary(KVs)
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
@@ -309,7 +385,7 @@ func (iter *iavlIterator) iterateRoutine() {
 			select {
 			case <-iter.quitCh:
 				return true // done with iteration.
-			case iter.iterCh <- cmn.KVPair{key, value}:
+			case iter.iterCh <- cmn.KVPair{Key: key, Value: value}:
 				return false // yay.
 			}
 		},
@@ -18,6 +18,7 @@ var (
 	cacheSize        = 100
 	numRecent  int64 = 5
 	storeEvery int64 = 3
+	pruning          = sdk.NewPruningStrategy(numRecent, storeEvery, 1)
 )
 
 var (
@@ -47,7 +48,7 @@ func newTree(t *testing.T, db dbm.DB) (*iavl.VersionedTree, CommitID) {
 func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	key := "hello"
 
@@ -72,7 +73,7 @@ func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 func TestIAVLIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 	iter := iavlStore.Iterator([]byte("aloha"), []byte("hellz"))
 	expected := []string{"aloha", "hello"}
 	var i int
@@ -145,7 +146,7 @@ func TestIAVLIterator(t *testing.T) {
 func TestIAVLSubspaceIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -207,7 +208,7 @@ func TestIAVLSubspaceIterator(t *testing.T) {
 func TestIAVLReverseSubspaceIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -326,7 +327,7 @@ type pruneState struct {
 func testPruning(t *testing.T, numRecent int64, storeEvery int64, states []pruneState) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, sdk.NewPruningStrategy(numRecent, storeEvery, 1))
 	for step, state := range states {
 		for _, ver := range state.stored {
 			require.True(t, iavlStore.VersionExists(ver),
@@ -345,7 +346,7 @@ func testPruning(t *testing.T, numRecent int64, storeEvery int64, states []prune
 func TestIAVLNoPrune(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numRecent, int64(1))
+	iavlStore := newIAVLStore(tree, sdk.PruneNothing)
 	nextVersion(iavlStore)
 	for i := 1; i < 100; i++ {
 		for j := 1; j <= i; j++ {
@@ -360,7 +361,7 @@ func TestIAVLNoPrune(t *testing.T) {
 func TestIAVLPruneEverything(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, int64(0), int64(0))
+	iavlStore := newIAVLStore(tree, sdk.PruneEverything)
 	nextVersion(iavlStore)
 	for i := 1; i < 100; i++ {
 		for j := 1; j < i; j++ {
@@ -375,10 +376,59 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLPruningInterval(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, sdk.NewPruningStrategy(1, 0, 4))
+	for i := 0; i < 7; i++ {
+		nextVersion(iavlStore)
+	}
+
+	// versions 1 and 2 were released before the run at version 4, versions 3
+	// to 5 wait for the run at version 8
+	for _, ver := range []int64{1, 2} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	for _, ver := range []int64{3, 4, 5, 6, 7} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+
+	nextVersion(iavlStore)
+	for _, ver := range []int64{3, 4, 5, 6} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	require.True(t, iavlStore.VersionExists(7))
+	require.True(t, iavlStore.VersionExists(8))
+}
+
+func TestIAVLPruningBacklog(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, sdk.PruneNothing)
+	for i := 0; i < 3*pruneBatchSize; i++ {
+		nextVersion(iavlStore)
+	}
+
+	// switching to a stricter strategy deletes the old versions in batches
+	iavlStore.SetPruning(sdk.PruneEverything)
+	nextVersion(iavlStore)
+	require.False(t, iavlStore.VersionExists(1))
+	require.False(t, iavlStore.VersionExists(pruneBatchSize+1))
+	require.True(t, iavlStore.VersionExists(pruneBatchSize+2))
+
+	for i := 0; i < 2; i++ {
+		nextVersion(iavlStore)
+	}
+	for ver := int64(1); ver < 3*pruneBatchSize+3; ver++ {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	require.True(t, iavlStore.VersionExists(3*pruneBatchSize+3))
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	k1, v1 := []byte("key1"), []byte("val1")
 	k2, v2 := []byte("key2"), []byte("val2")
@@ -387,12 +437,12 @@ func TestIAVLStoreQuery(t *testing.T) {
 	ksub := []byte("key")
 	KVs0 := []KVPair{}
 	KVs1 := []KVPair{
-		{k1, v1},
-		{k2, v2},
+		{Key: k1, Value: v1},
+		{Key: k2, Value: v2},
 	}
 	KVs2 := []KVPair{
-		{k1, v3},
-		{k2, v2},
+		{Key: k1, Value: v3},
+		{Key: k2, Value: v2},
 	}
 	valExpSubEmpty := cdc.MustMarshalBinary(KVs0)
 	valExpSub1 := cdc.MustMarshalBinary(KVs1)
@@ -408,6 +458,14 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSubEmpty, qres.Value)
 
+	// the empty tree is proven by its empty root
+	querySub.Prove = true
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Empty(t, qres.Log)
+	require.Equal(t, valExpSubEmpty, qres.Value)
+	querySub.Prove = false
+
 	// set data
 	iavlStore.Set(k1, v1)
 	iavlStore.Set(k2, v2)
@@ -425,6 +483,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 
 	// but yes on the new version
 	query.Height = cid.Version
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(query)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v1, qres.Value)
@@ -445,6 +504,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 
 	// update to latest in the query and we are happy
 	query.Height = cid.Version
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(query)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v3, qres.Value)
@@ -458,6 +518,15 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
 
+	// the proven subspace holds the same entries, its proof covering them all
+	querySub.Prove = true
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSub2, qres.Value)
+	var proof iavl.RangeProof
+	require.Nil(t, cdc.UnmarshalBinary(qres.Proof, &proof))
+	require.Nil(t, VerifySubspaceProof(ksub, KVs2, cid.Hash, &proof))
+
 	// default (height 0) will show latest -1
 	query0 := abci.RequestQuery{Path: "/store", Data: k1}
 	qres = iavlStore.Query(query0)
@@ -474,7 +543,7 @@ func BenchmarkIAVLIteratorNext(b *testing.B) {
 		value := cmn.RandBytes(50)
 		tree.Set(key, value)
 	}
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, pruning)
 	iterators := make([]Iterator, b.N/treeSize)
 	for i := 0; i < len(iterators); i++ {
 		iterators[i] = iavlStore.Iterator([]byte{0}, []byte{255, 255, 255, 255, 255})
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"container/list"
+	"io"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// InterBlockCacher is implemented by the CommitMultiStores able to keep the
+// values of their stores in a cache persisting across blocks.
+type InterBlockCacher interface {
+	// SetInterBlockCache enables the cache of at most size values for the
+	// mounted IAVL store of the key. It must be called before loading.
+	SetInterBlockCache(key StoreKey, size int)
+}
+
+var _ CommitKVStore = (*interBlockCacheStore)(nil)
+var _ Queryable = (*interBlockCacheStore)(nil)
+
+// interBlockCacheStore wraps a CommitKVStore with a size-bounded LRU cache of
+// the values read from it, absent ones included. Unlike the cacheKVStores of
+// a block it persists across blocks, so the hot keys aren't loaded from the
+// IAVL tree again in each block. The cached value of a key is invalidated on
+// write, the iterators read the parent directly.
+type interBlockCacheStore struct {
+	parent CommitKVStore
+
+	mtx     sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // of *interBlockCacheEntry, from the most recently used
+}
+
+type interBlockCacheEntry struct {
+	key   string
+	value []byte
+}
+
+func newInterBlockCacheStore(parent CommitKVStore, size int) *interBlockCacheStore {
+	return &interBlockCacheStore{
+		parent:  parent,
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// Implements Store.
+func (st *interBlockCacheStore) GetStoreType() StoreType {
+	return st.parent.GetStoreType()
+}
+
+// Implements Committer.
+func (st *interBlockCacheStore) Commit() CommitID {
+	return st.parent.Commit()
+}
+
+// Implements Committer.
+func (st *interBlockCacheStore) LastCommitID() CommitID {
+	return st.parent.LastCommitID()
+}
+
+// Implements Committer.
+func (st *interBlockCacheStore) SetPruning(pruning PruningStrategy) {
+	st.parent.SetPruning(pruning)
+}
+
+// Implements Queryable. Queries are served by the parent, which answers them
+// from its persisted versions.
+func (st *interBlockCacheStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	return st.parent.(Queryable).Query(req)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Get(key []byte) []byte {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	if elem, ok := st.entries[string(key)]; ok {
+		st.lru.MoveToFront(elem)
+		return elem.Value.(*interBlockCacheEntry).value
+	}
+
+	value := st.parent.Get(key)
+	st.add(string(key), value)
+	return value
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Has(key []byte) bool {
+	return st.Get(key) != nil
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Set(key, value []byte) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	st.invalidate(string(key))
+	st.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Delete(key []byte) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	st.invalidate(string(key))
+	st.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Iterator(start, end []byte) Iterator {
+	return st.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) ReverseIterator(start, end []byte) Iterator {
+	return st.parent.ReverseIterator(start, end)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, st)
+}
+
+// Implements CacheWrapper.
+func (st *interBlockCacheStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (st *interBlockCacheStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// add caches the value of the key, evicting the least recently used value if
+// the cache is full.
+func (st *interBlockCacheStore) add(key string, value []byte) {
+	if st.lru.Len() >= st.size {
+		oldest := st.lru.Back()
+		st.lru.Remove(oldest)
+		delete(st.entries, oldest.Value.(*interBlockCacheEntry).key)
+	}
+	st.entries[key] = st.lru.PushFront(&interBlockCacheEntry{key, value})
+}
+
+func (st *interBlockCacheStore) invalidate(key string) {
+	if elem, ok := st.entries[key]; ok {
+		st.lru.Remove(elem)
+		delete(st.entries, key)
+	}
+}
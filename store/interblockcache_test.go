@@ -0,0 +1,122 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// countingKVStore counts the reads reaching the parent of a cache
+type countingKVStore struct {
+	CommitKVStore
+	gets int
+}
+
+func (st *countingKVStore) Get(key []byte) []byte {
+	st.gets++
+	return st.CommitKVStore.Get(key)
+}
+
+func TestInterBlockCacheStore(t *testing.T) {
+	tree, _ := newTree(t, dbm.NewMemDB())
+	parent := &countingKVStore{CommitKVStore: newIAVLStore(tree, pruning)}
+	store := newInterBlockCacheStore(parent, 2)
+
+	// values and absent keys are read from the parent once
+	require.Equal(t, []byte("goodbye"), store.Get([]byte("hello")))
+	require.Equal(t, []byte("goodbye"), store.Get([]byte("hello")))
+	require.False(t, store.Has([]byte("missing")))
+	require.False(t, store.Has([]byte("missing")))
+	require.Equal(t, 2, parent.gets)
+
+	// writes invalidate the cached values
+	store.Set([]byte("hello"), []byte("bye"))
+	require.Equal(t, []byte("bye"), store.Get([]byte("hello")))
+	store.Delete([]byte("hello"))
+	require.Nil(t, store.Get([]byte("hello")))
+	require.Equal(t, 4, parent.gets)
+
+	// the writes of the cache wraps of a block go through the cache
+	cache := store.CacheWrap().(CacheKVStore)
+	cache.Set([]byte("hello"), []byte("again"))
+	cache.Write()
+	require.Equal(t, []byte("again"), store.Get([]byte("hello")))
+	require.Equal(t, 5, parent.gets)
+
+	// the least recently used value is evicted
+	store.Get([]byte("aloha"))
+	store.Get([]byte("missing"))
+	require.Equal(t, 7, parent.gets)
+	store.Get([]byte("aloha"))
+	require.Equal(t, 7, parent.gets)
+	store.Get([]byte("hello"))
+	require.Equal(t, 8, parent.gets)
+	require.Equal(t, 2, store.lru.Len())
+}
+
+func TestMultistoreInterBlockCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	key1 := store.keysByName["store1"]
+	store.SetInterBlockCache(key1, 10)
+	require.Panics(t, func() { store.SetInterBlockCache(sdk.NewKVStoreKey("store4"), 10) })
+	require.Nil(t, store.LoadLatestVersion())
+	_, ok := store.GetCommitKVStore(key1).(*interBlockCacheStore)
+	require.True(t, ok)
+
+	// the cache persists across blocks without changing the committed state
+	store.GetKVStore(key1).Set([]byte("counter"), []byte("0"))
+	store.Commit()
+	for i := 0; i < 3; i++ {
+		cms := store.CacheMultiStore()
+		kv := cms.GetKVStore(key1)
+		require.Equal(t, []byte(fmt.Sprintf("%d", i)), kv.Get([]byte("counter")))
+		kv.Set([]byte("counter"), []byte(fmt.Sprintf("%d", i+1)))
+		cms.Write()
+		store.Commit()
+	}
+
+	uncached := newMultiStoreWithMounts(db)
+	require.Nil(t, uncached.LoadLatestVersion())
+	require.Equal(t, store.LastCommitID(), uncached.LastCommitID())
+	require.Equal(t, []byte("3"), uncached.getStoreByName("store1").(KVStore).Get([]byte("counter")))
+}
+
+func benchmarkIAVLGetAcrossBlocks(b *testing.B, size int) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = cmn.RandBytes(20)
+		tree.Set(keys[i], cmn.RandBytes(100))
+	}
+	_, _, err := tree.SaveVersion()
+	require.Nil(b, err)
+	var store CommitKVStore = newIAVLStore(tree, sdk.PruneNothing)
+	if size > 0 {
+		store = newInterBlockCacheStore(store, size)
+	}
+
+	// each block reads the hot keys through a fresh cache wrap
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		block := store.CacheWrap().(CacheKVStore)
+		for _, key := range keys[:100] {
+			block.Get(key)
+		}
+	}
+}
+
+func BenchmarkIAVLGetAcrossBlocks(b *testing.B) {
+	benchmarkIAVLGetAcrossBlocks(b, 0)
+}
+
+func BenchmarkIAVLGetAcrossBlocksWithInterBlockCache(b *testing.B) {
+	benchmarkIAVLGetAcrossBlocks(b, 1000)
+}
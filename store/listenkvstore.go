@@ -0,0 +1,56 @@
+package store
+
+import (
+	"io"
+)
+
+// listenKVStore wraps a store of a listening cacheMultiStore, recording the
+// changes written to it in order. The cache wraps of the store write through
+// it, so only the changes of the txs which are written are recorded.
+type listenKVStore struct {
+	CacheKVStore
+	storeKey  string
+	changeSet *changeSetBuffer
+}
+
+var _ CacheKVStore = listenKVStore{}
+
+func newListenKVStore(parent CacheKVStore, storeKey string, changeSet *changeSetBuffer) listenKVStore {
+	return listenKVStore{
+		CacheKVStore: parent,
+		storeKey:     storeKey,
+		changeSet:    changeSet,
+	}
+}
+
+// Implements KVStore.
+func (lkv listenKVStore) Set(key []byte, value []byte) {
+	lkv.CacheKVStore.Set(key, value)
+	lkv.changeSet.record(lkv.storeKey, key, value, false)
+}
+
+// Implements KVStore.
+func (lkv listenKVStore) Delete(key []byte) {
+	lkv.CacheKVStore.Delete(key)
+	lkv.changeSet.record(lkv.storeKey, key, nil, true)
+}
+
+// Implements KVStore.
+func (lkv listenKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{lkv, prefix}
+}
+
+// Implements KVStore.
+func (lkv listenKVStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, lkv)
+}
+
+// Implements CacheWrapper.
+func (lkv listenKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(lkv)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (lkv listenKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(lkv, w, tc))
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ KVStore = (*memoryStore)(nil)
+
+// memoryStore is a wrapper for a MemDB with Commiter implementation. Unlike
+// the transientStore its contents persist across blocks, for the data living
+// as long as the process without being part of the state, which the modules
+// rebuild when the app is loaded.
+type memoryStore struct {
+	dbStoreAdapter
+}
+
+// Constructs new MemDB adapter
+func newMemoryStore() *memoryStore {
+	return &memoryStore{dbStoreAdapter{dbm.NewMemDB()}}
+}
+
+// Implements Store.
+func (ms *memoryStore) GetStoreType() StoreType {
+	return sdk.StoreTypeMemory
+}
+
+// Implements CommitStore
+// Commit keeps the contents of the memoryStore.
+func (ms *memoryStore) Commit() (id CommitID) {
+	return
+}
+
+// Implements CommitStore
+func (ms *memoryStore) SetPruning(pruning PruningStrategy) {
+}
+
+// Implements CommitStore
+func (ms *memoryStore) LastCommitID() (id CommitID) {
+	return
+}
+
+// Implements KVStore
+func (ms *memoryStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ms, prefix}
+}
+
+// Implements KVStore
+func (ms *memoryStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, ms)
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMemoryStore(t *testing.T) {
+	mstore := newMemoryStore()
+
+	require.Nil(t, mstore.Get(k))
+
+	mstore.Set(k, v)
+
+	require.Equal(t, v, mstore.Get(k))
+
+	mstore.Commit()
+
+	require.Equal(t, v, mstore.Get(k))
+}
+
+func TestMultistoreMemoryStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	memKey := sdk.NewMemoryStoreKey("memory")
+	store.MountStoreWithDB(memKey, sdk.StoreTypeMemory, nil)
+	require.Nil(t, store.LoadLatestVersion())
+
+	// the writes of a tx are cached until written
+	cms := store.CacheMultiStore()
+	cms.GetKVStore(memKey).Set(k, v)
+	require.Nil(t, store.GetKVStore(memKey).Get(k))
+	cms.Write()
+	require.Equal(t, v, store.GetKVStore(memKey).Get(k))
+
+	// the contents survive the commits without being part of the state
+	store.Commit()
+	commitID := store.Commit()
+	require.Equal(t, v, store.GetKVStore(memKey).Get(k))
+	cInfo, err := getCommitInfo(db, commitID.Version)
+	require.Nil(t, err)
+	for _, storeInfo := range cInfo.StoreInfos {
+		require.NotEqual(t, "memory", storeInfo.Name)
+	}
+
+	// a reloaded memory store is empty
+	store = newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(memKey, sdk.StoreTypeMemory, nil)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Nil(t, store.GetKVStore(memKey).Get(k))
+
+	store = newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("memory"), sdk.StoreTypeMemory, nil)
+	require.NotNil(t, store.LoadLatestVersion())
+}
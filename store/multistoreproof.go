@@ -2,9 +2,12 @@ package store
 
 import (
 	"bytes"
+
 	"github.com/pkg/errors"
 	"github.com/tendermint/iavl"
 	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // MultiStoreProof defines a collection of store proofs in a multi-store
@@ -33,13 +36,16 @@ func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []store
 func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHash []byte) ([]byte, error) {
 	var substoreCommitHash []byte
 	var height int64
+	found := false
 	for _, storeInfo := range storeInfos {
 		if storeInfo.Name == storeName {
 			substoreCommitHash = storeInfo.Core.CommitID.Hash
 			height = storeInfo.Core.CommitID.Version
+			found = true
 		}
 	}
-	if len(substoreCommitHash) == 0 {
+	// the commit hash of an empty store is empty
+	if !found {
 		return nil, cmn.NewError("failed to get substore root commit hash by store name")
 	}
 
@@ -80,11 +86,90 @@ func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *
 	return nil
 }
 
+// VerifySubspaceProof verifies that the kvs are all the entries of the store
+// whose key starts with subspace, in order. The range proof must prove the
+// presence of each of them and the absence of other keys at the edges of the
+// range, its leaves being adjacent in the tree.
+func VerifySubspaceProof(subspace []byte, kvs []KVPair, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	// an empty store has no entries to prove
+	if len(substoreCommitHash) == 0 {
+		if len(kvs) != 0 {
+			return errors.Errorf("empty store has %d entries in the subspace", len(kvs))
+		}
+		return nil
+	}
+
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return errors.Wrap(err, "proof root hash doesn't equal to substore commit root hash")
+	}
+
+	// the entries are in the proof
+	for _, kv := range kvs {
+		err = rangeProof.VerifyItem(kv.Key, kv.Value)
+		if err != nil {
+			return errors.Wrap(err, "failed in existence verification")
+		}
+	}
+
+	// no other entry of the proof is in the range, which may extend past it
+	// to the leaf following it
+	start, end := subspace, sdk.PrefixEndBytes(subspace)
+	var inRange [][]byte
+	for _, key := range rangeProof.Keys() {
+		if bytes.Compare(key, start) >= 0 && (end == nil || bytes.Compare(key, end) < 0) {
+			inRange = append(inRange, key)
+		}
+	}
+	if len(inRange) != len(kvs) {
+		return errors.Errorf("proof has %d entries in the subspace, got %d", len(inRange), len(kvs))
+	}
+	for i, key := range inRange {
+		if !bytes.Equal(key, kvs[i].Key) {
+			return errors.Errorf("entry %d of the subspace is %X, got %X", i, key, kvs[i].Key)
+		}
+	}
+
+	// the proof covers the edges of the range: the start key is present or
+	// proven absent, and so is the end key, or, without end, any key after the
+	// last leaf of the proof
+	if len(kvs) == 0 || !bytes.Equal(kvs[0].Key, start) {
+		err = rangeProof.VerifyAbsence(start)
+		if err != nil {
+			return errors.Wrap(err, "failed in absence verification of the range start")
+		}
+	}
+	if end == nil {
+		keys := rangeProof.Keys()
+		if len(keys) == 0 {
+			return errors.New("range proof has no leaves")
+		}
+		end = append(append([]byte{}, keys[len(keys)-1]...), 0x00)
+	}
+	if !containsKey(rangeProof.Keys(), end) {
+		err = rangeProof.VerifyAbsence(end)
+		if err != nil {
+			return errors.Wrap(err, "failed in absence verification of the range end")
+		}
+	}
+
+	return nil
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
 // RequireProof return whether proof is require for the subpath
 func RequireProof(subpath string) bool {
-	// Currently, only when query subpath is "/store" or "/key", will proof be included in response.
+	// Currently, only when query subpath is "/store", "/key" or "/subspace", will proof be included in response.
 	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go:212
-	if subpath == "/store" || subpath == "/key" {
+	if subpath == "/store" || subpath == "/key" || subpath == "/subspace" {
 		return true
 	}
 	return false
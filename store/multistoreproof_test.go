@@ -2,10 +2,13 @@ package store
 
 import (
 	"encoding/hex"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tendermint/iavl"
 	cmn "github.com/tendermint/tendermint/libs/common"
-	"testing"
+	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
 func TestVerifyMultiStoreCommitInfo(t *testing.T) {
@@ -118,3 +121,50 @@ func TestVerifyRangeProof(t *testing.T) {
 	err = VerifyRangeProof(key, val, root, proof)
 	assert.Nil(t, err)
 }
+
+func TestVerifySubspaceProof(t *testing.T) {
+	tree := iavl.NewVersionedTree(dbm.NewMemDB(), 0)
+	for _, key := range [][]byte{{0x11}, {0x32}, {0x50}, {0x50, 0x01}, {0x72}, {0xff}, {0xff, 0x01}} {
+		tree.Set(key, append([]byte("value"), key...))
+	}
+	root, version, err := tree.SaveVersion()
+	require.Nil(t, err)
+
+	// complete subspaces, in the middle of the tree, empty or without end
+	for _, subspace := range [][]byte{{0x50}, {0x40}, {0xff}} {
+		kvs, proof, err := getVersionedSubspace(tree, subspace, version)
+		require.Nil(t, err)
+		err = VerifySubspaceProof(subspace, kvs, root, proof)
+		assert.Nil(t, err, "subspace %X", subspace)
+	}
+
+	// an entry is missing or altered
+	kvs, proof, err := getVersionedSubspace(tree, []byte{0x50}, version)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(kvs))
+	err = VerifySubspaceProof([]byte{0x50}, kvs[:1], root, proof)
+	assert.NotNil(t, err)
+	err = VerifySubspaceProof([]byte{0x50}, []KVPair{kvs[0], {Key: kvs[1].Key, Value: []byte("other")}}, root, proof)
+	assert.NotNil(t, err)
+
+	// the proof doesn't cover the whole subspace
+	keys, values, partial, err := tree.GetVersionedRangeWithProof([]byte{0x50}, []byte{0x51}, 1, version)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(keys))
+	err = VerifySubspaceProof([]byte{0x50}, []KVPair{{Key: keys[0], Value: values[0]}}, root, partial)
+	assert.NotNil(t, err)
+
+	// the proof is for another root
+	err = VerifySubspaceProof([]byte{0x50}, kvs, []byte("root"), proof)
+	assert.NotNil(t, err)
+
+	// an empty tree has no entries, proven by its empty root
+	empty := iavl.NewVersionedTree(dbm.NewMemDB(), 0)
+	_, version, err = empty.SaveVersion()
+	require.Nil(t, err)
+	kvs, proof, err = getVersionedSubspace(empty, []byte{0x50}, version)
+	require.Nil(t, err)
+	require.Empty(t, kvs)
+	assert.Nil(t, VerifySubspaceProof([]byte{0x50}, kvs, nil, proof))
+	assert.NotNil(t, VerifySubspaceProof([]byte{0x50}, []KVPair{{Key: []byte{0x50}}}, nil, proof))
+}
@@ -3,7 +3,9 @@ package store
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 
 	"golang.org/x/crypto/ripemd160"
 
@@ -32,15 +34,22 @@ type rootMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	listeners []StreamingListener
+	changeSet *changeSetBuffer
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
 var _ Queryable = (*rootMultiStore)(nil)
+var _ Snapshotter = (*rootMultiStore)(nil)
+var _ Listenable = (*rootMultiStore)(nil)
+var _ InterBlockCacher = (*rootMultiStore)(nil)
 
 // nolint
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -79,6 +88,22 @@ func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.D
 	rs.keysByName[key.Name()] = key
 }
 
+// Implements InterBlockCacher.
+func (rs *rootMultiStore) SetInterBlockCache(key StoreKey, size int) {
+	params, ok := rs.storesParams[key]
+	if !ok {
+		panic(fmt.Sprintf("rootMultiStore store %v isn't mounted", key))
+	}
+	if params.typ != sdk.StoreTypeIAVL {
+		panic(fmt.Sprintf("rootMultiStore cannot cache store %v of type %v", key, params.typ))
+	}
+	if size <= 0 {
+		panic(fmt.Sprintf("invalid inter-block cache size %d", size))
+	}
+	params.cacheSize = size
+	rs.storesParams[key] = params
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) GetCommitStore(key StoreKey) CommitStore {
 	return rs.stores[key]
@@ -92,11 +117,32 @@ func (rs *rootMultiStore) GetCommitKVStore(key StoreKey) CommitKVStore {
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadLatestVersion() error {
 	ver := getLatestVersion(rs.db)
-	return rs.LoadVersion(ver)
+	return rs.loadVersion(ver, nil)
 }
 
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadVersion(ver int64) error {
+	return rs.loadVersion(ver, nil)
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) LoadLatestVersionAndUpgrade(upgrades *StoreUpgrades) error {
+	ver := getLatestVersion(rs.db)
+	return rs.loadVersion(ver, upgrades)
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) error {
+	return rs.loadVersion(ver, upgrades)
+}
+
+// loadVersion loads the stores of a version, applying the upgrades needed by
+// the mounted stores: a mounted store missing from the version is created
+// if added, or takes over the data of the store it is renamed from, and the
+// stores of the version no longer mounted must be deleted or renamed. Once
+// the next version is committed with the new set of stores, loading it
+// applies no upgrade, so every node applies them once.
+func (rs *rootMultiStore) loadVersion(ver int64, upgrades *StoreUpgrades) error {
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -119,12 +165,46 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	if err != nil {
 		return err
 	}
+	storeInfos := make(map[string]storeInfo, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		storeInfos[storeInfo.Name] = storeInfo
+	}
+
+	// The stores of the version must still be mounted, unless deleted or
+	// renamed.
+	for name := range storeInfos {
+		if _, ok := rs.keysByName[name]; ok {
+			continue
+		}
+		if !upgrades.IsDeleted(name) && !upgrades.IsRenamed(name) {
+			return fmt.Errorf("store %s of version %d is not mounted", name, ver)
+		}
+	}
 
 	// Load each Store
 	var newStores = make(map[StoreKey]CommitStore)
-	for _, storeInfo := range cInfo.StoreInfos {
-		key, commitID := rs.nameToKey(storeInfo.Name), storeInfo.Core.CommitID
-		storeParams := rs.storesParams[key]
+	for key, storeParams := range rs.storesParams {
+		name := key.Name()
+		storeInfo, ok := storeInfos[name]
+		commitID := storeInfo.Core.CommitID
+
+		// TODO: detecting transient is quite adhoc
+		if !ok && storeParams.typ != sdk.StoreTypeTransient && storeParams.typ != sdk.StoreTypeMemory {
+			oldName := upgrades.RenamedFrom(name)
+			switch {
+			case upgrades.IsAdded(name):
+				commitID, err = rs.addStore(storeParams, ver)
+			case oldName != "":
+				commitID, err = rs.renameStore(oldName, storeInfos, storeParams)
+			default:
+				// If any nontransient CommitStoreLoaders were not used, return error.
+				err = fmt.Errorf("unused CommitStoreLoader: %v", key)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
 		store, err := rs.loadCommitStoreFromParams(key, commitID, storeParams)
 		if err != nil {
 			return fmt.Errorf("failed to load rootMultiStore: %v", err)
@@ -132,13 +212,12 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 		newStores[key] = store
 	}
 
-	// TODO: detecting transient is quite adhoc
-	// If any nontransient CommitStoreLoaders were not used, return error.
-	for key, param := range rs.storesParams {
-		if param.typ != sdk.StoreTypeTransient {
-			if _, ok := newStores[key]; !ok {
-				return fmt.Errorf("unused CommitStoreLoader: %v", key)
+	for name := range storeInfos {
+		if upgrades.IsDeleted(name) {
+			if _, ok := rs.keysByName[name]; ok {
+				return fmt.Errorf("cannot delete mounted store %s", name)
 			}
+			deleteAll(dbm.NewPrefixDB(rs.db, storePrefix(name)))
 		}
 	}
 
@@ -148,6 +227,46 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	return nil
 }
 
+// addStore creates the empty tree of an added store directly at version ver,
+// so its versions keep matching the versions of the rootMultiStore.
+func (rs *rootMultiStore) addStore(params storeParams, ver int64) (CommitID, error) {
+	if params.typ != sdk.StoreTypeIAVL {
+		return CommitID{}, fmt.Errorf("cannot add store %s of type %v", params.key.Name(), params.typ)
+	}
+	setIAVLRoot(rs.storeDB(params), ver, nil)
+	return CommitID{Version: ver}, nil
+}
+
+// renameStore moves the data of the store oldName of the loaded version to
+// the store it is renamed to, which keeps its commit ID. The data is copied
+// and deleted in a single batch, so an interrupted upgrade leaves it in the
+// old store.
+func (rs *rootMultiStore) renameStore(oldName string, storeInfos map[string]storeInfo, params storeParams) (CommitID, error) {
+	storeInfo, ok := storeInfos[oldName]
+	if !ok {
+		return CommitID{}, fmt.Errorf("cannot rename store %s to %s: no such store", oldName, params.key.Name())
+	}
+	if _, ok := rs.keysByName[oldName]; ok {
+		return CommitID{}, fmt.Errorf("cannot rename mounted store %s", oldName)
+	}
+	if params.db != nil {
+		return CommitID{}, fmt.Errorf("cannot rename store %s to %s mounted with its own db", oldName, params.key.Name())
+	}
+
+	oldPrefix, newPrefix := storePrefix(oldName), storePrefix(params.key.Name())
+	batch := rs.db.NewBatch()
+	iter := dbm.IteratePrefix(rs.db, oldPrefix)
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		batch.Set(append(append([]byte{}, newPrefix...), key[len(oldPrefix):]...), iter.Value())
+		batch.Delete(key)
+	}
+	iter.Close()
+	batch.Write()
+
+	return storeInfo.Core.CommitID, nil
+}
+
 // WithTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (rs *rootMultiStore) WithTracer(w io.Writer) MultiStore {
@@ -182,6 +301,40 @@ func (rs *rootMultiStore) ResetTraceContext() MultiStore {
 	return rs
 }
 
+// AddListener implements the Listenable interface.
+func (rs *rootMultiStore) AddListener(listener StreamingListener) {
+	rs.listeners = append(rs.listeners, listener)
+}
+
+// ListeningEnabled implements the Listenable interface.
+func (rs *rootMultiStore) ListeningEnabled() bool {
+	return len(rs.listeners) != 0
+}
+
+// ListeningCacheMultiStore implements the Listenable interface.
+func (rs *rootMultiStore) ListeningCacheMultiStore() ListeningCacheMultiStore {
+	rs.changeSet = newChangeSetBuffer()
+	return newListeningCacheMultiStoreFromRMS(rs, rs.changeSet)
+}
+
+// streamChangeSet sends the recorded changes to the listeners as the change
+// set of the block at version. It panics if a listener fails, before the
+// version is persisted.
+func (rs *rootMultiStore) streamChangeSet(version int64) {
+	changeSet := BlockChangeSet{Height: version}
+	if rs.changeSet != nil {
+		changeSet.Changes = rs.changeSet.changes
+		rs.changeSet = nil
+	}
+
+	for _, listener := range rs.listeners {
+		err := listener.ListenCommit(changeSet)
+		if err != nil {
+			panic(fmt.Sprintf("failed to stream the change set of version %d: %v", version, err))
+		}
+	}
+}
+
 //----------------------------------------
 // +CommitStore
 
@@ -195,6 +348,9 @@ func (rs *rootMultiStore) Commit() CommitID {
 
 	// Commit stores.
 	version := rs.lastCommitID.Version + 1
+	if rs.ListeningEnabled() {
+		rs.streamChangeSet(version)
+	}
 	commitInfo := commitStores(version, rs.stores)
 
 	// Need to update atomically.
@@ -292,7 +448,8 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	req.Path = subpath
 	res := queryable.Query(req)
 
-	if !req.Prove || !RequireProof(subpath) {
+	// a failed query has no proof to wrap
+	if !req.Prove || !RequireProof(subpath) || len(res.Proof) == 0 {
 		return res
 	}
 
@@ -324,13 +481,22 @@ func parsePath(path string) (storeName string, subpath string, err sdk.Error) {
 
 //----------------------------------------
 
-func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitID, params storeParams) (store CommitStore, err error) {
-	var db dbm.DB
+// storeDB returns the db a mounted store persists to.
+func (rs *rootMultiStore) storeDB(params storeParams) dbm.DB {
 	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	}
+	return dbm.NewPrefixDB(rs.db, storePrefix(params.key.Name()))
+}
+
+// storePrefix returns the prefix of the stores persisted to the
+// rootMultiStore db.
+func storePrefix(name string) []byte {
+	return []byte("s/k:" + name + "/")
+}
+
+func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitID, params storeParams) (store CommitStore, err error) {
+	db := rs.storeDB(params)
 	switch params.typ {
 	case sdk.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
@@ -338,6 +504,9 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning)
+		if err == nil && params.cacheSize > 0 {
+			store = newInterBlockCacheStore(store.(CommitKVStore), params.cacheSize)
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -349,6 +518,14 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		}
 		store = newTransientStore()
 		return
+	case sdk.StoreTypeMemory:
+		_, ok := key.(*sdk.MemoryStoreKey)
+		if !ok {
+			err = fmt.Errorf("invalid StoreKey for StoreTypeMemory: %s", key.String())
+			return
+		}
+		store = newMemoryStore()
+		return
 	default:
 		panic(fmt.Sprintf("unrecognized store type %v", params.typ))
 	}
@@ -370,6 +547,9 @@ type storeParams struct {
 	key StoreKey
 	db  dbm.DB
 	typ StoreType
+
+	// size of the inter-block cache of the store, 0 if disabled
+	cacheSize int
 }
 
 //----------------------------------------
@@ -449,28 +629,65 @@ func getLatestVersion(db dbm.DB) int64 {
 	return latest
 }
 
+// Deletes all the entries of a db.
+func deleteAll(db dbm.DB) {
+	batch := db.NewBatch()
+	iter := db.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	iter.Close()
+	batch.Write()
+}
+
 // Set the latest version.
 func setLatestVersion(batch dbm.Batch, version int64) {
 	latestBytes, _ := cdc.MarshalBinary(version) // Does not error
 	batch.Set([]byte(latestVersionKey), latestBytes)
 }
 
-// Commits each store and returns a new commitInfo.
+// Commits the stores concurrently and returns a new commitInfo. The store
+// infos are sorted by store name, so the commitInfo and its encoding don't
+// depend on the order the stores are committed in. A panic of a store is
+// raised again once all the stores are committed.
 func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
-	storeInfos := make([]storeInfo, 0, len(storeMap))
-
-	for key, store := range storeMap {
-		// Commit
-		commitID := store.Commit()
+	keys := make([]StoreKey, 0, len(storeMap))
+	for key := range storeMap {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name() < keys[j].Name() })
+
+	commitIDs := make([]CommitID, len(keys))
+	panics := make([]interface{}, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, store CommitStore) {
+			defer wg.Done()
+			defer func() { panics[i] = recover() }()
+			commitIDs[i] = store.Commit()
+		}(i, storeMap[key])
+	}
+	wg.Wait()
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
 
-		if store.GetStoreType() == sdk.StoreTypeTransient {
+	storeInfos := make([]storeInfo, 0, len(keys))
+	for i, key := range keys {
+		// the memory stores aren't part of the state. The transient stores
+		// report StoreTypeDB, so they remain in the commitInfo with an empty
+		// commit ID as before.
+		if storeMap[key].GetStoreType() == sdk.StoreTypeMemory {
 			continue
 		}
 
 		// Record CommitID
 		si := storeInfo{}
 		si.Name = key.Name()
-		si.Core.CommitID = commitID
+		si.Core.CommitID = commitIDs[i]
 		// si.Core.StoreType = store.GetStoreType()
 		storeInfos = append(storeInfos, si)
 	}
@@ -1,11 +1,14 @@
 package store
 
 import (
+	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -99,6 +102,71 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestMultistoreLoadWithUpgrade(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	k, v := []byte("key"), []byte("value")
+	for _, name := range []string{"store1", "store2", "store3"} {
+		store.getStoreByName(name).(KVStore).Set(k, v)
+	}
+	store.Commit()
+	store.Commit()
+
+	// store4 is added, store2 renamed to restore2 and store3 deleted
+	upgrades := &StoreUpgrades{
+		Added:   []string{"store4"},
+		Renamed: []StoreRename{{OldKey: "store2", NewKey: "restore2"}},
+		Deleted: []string{"store3"},
+	}
+	newUpgradedStore := func() *rootMultiStore {
+		store := NewCommitMultiStore(db)
+		for _, name := range []string{"store1", "restore2", "store4"} {
+			store.MountStoreWithDB(sdk.NewKVStoreKey(name), sdk.StoreTypeIAVL, nil)
+		}
+		return store
+	}
+
+	// the mounted stores must match the version unless upgraded
+	store = newUpgradedStore()
+	require.NotNil(t, store.LoadLatestVersion())
+	store = newMultiStoreWithMounts(db)
+	require.NotNil(t, store.LoadLatestVersionAndUpgrade(&StoreUpgrades{Deleted: []string{"store3"}}))
+
+	store = newUpgradedStore()
+	require.Nil(t, store.LoadLatestVersionAndUpgrade(upgrades))
+	require.Equal(t, int64(2), store.LastCommitID().Version)
+	require.Equal(t, v, store.getStoreByName("store1").(KVStore).Get(k))
+	require.Equal(t, v, store.getStoreByName("restore2").(KVStore).Get(k))
+	store4 := store.getStoreByName("store4").(*iavlStore)
+	require.Equal(t, int64(2), store4.LastCommitID().Version)
+	require.False(t, store4.VersionExists(1))
+	require.Nil(t, store4.Get(k))
+	store4.Set(k, v)
+
+	// the stores added keep the versions of the multistore
+	commitID := store.Commit()
+	require.Equal(t, int64(3), commitID.Version)
+	require.Equal(t, getExpectedCommitID(store, 3), commitID)
+	require.Equal(t, int64(3), store4.LastCommitID().Version)
+
+	// the data of the deleted and renamed stores is gone
+	for _, name := range []string{"store2", "store3"} {
+		iter := dbm.NewPrefixDB(db, storePrefix(name)).Iterator(nil, nil)
+		require.False(t, iter.Valid(), name)
+		iter.Close()
+	}
+
+	// the upgrades were applied once, the next version loads with or without
+	store = newUpgradedStore()
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	store = newUpgradedStore()
+	require.Nil(t, store.LoadLatestVersionAndUpgrade(upgrades))
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, v, store.getStoreByName("store4").(KVStore).Get(k))
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
@@ -187,6 +255,52 @@ func TestMultiStoreQuery(t *testing.T) {
 	require.Equal(t, v2, qres.Value)
 }
 
+// The stores are committed concurrently, which must give the same commit info
+// as committing them one after the other. Run with -race to check the
+// concurrent commits.
+func TestMultistoreParallelCommit(t *testing.T) {
+	names := []string{"main", "acc", "stake", "slashing", "gov", "fee", "params"}
+	newStore := func(db dbm.DB) *rootMultiStore {
+		store := NewCommitMultiStore(db)
+		for _, name := range names {
+			store.MountStoreWithDB(sdk.NewKVStoreKey(name), sdk.StoreTypeIAVL, nil)
+		}
+		store.MountStoreWithDB(sdk.NewTransientStoreKey("transient"), sdk.StoreTypeTransient, nil)
+		require.Nil(t, store.LoadLatestVersion())
+		return store
+	}
+	db := dbm.NewMemDB()
+	parallel := newStore(db)
+	sequential := newStore(dbm.NewMemDB())
+
+	for version := int64(1); version <= 5; version++ {
+		for _, name := range append(names, "transient") {
+			key := []byte(cmn.RandStr(8))
+			value := []byte(cmn.RandStr(32))
+			parallel.getStoreByName(name).(KVStore).Set(key, value)
+			sequential.getStoreByName(name).(KVStore).Set(key, value)
+		}
+		commitID := parallel.Commit()
+
+		// commit the mounted stores one after the other, sorted by name
+		var mounted []string
+		for name := range sequential.keysByName {
+			mounted = append(mounted, name)
+		}
+		sort.Strings(mounted)
+		var storeInfos []storeInfo
+		for _, name := range mounted {
+			si := storeInfo{Name: name}
+			si.Core.CommitID = sequential.getStoreByName(name).(CommitStore).Commit()
+			storeInfos = append(storeInfos, si)
+		}
+		expected := commitInfo{Version: version, StoreInfos: storeInfos}
+
+		require.Equal(t, expected.CommitID(), commitID)
+		require.Equal(t, cdc.MustMarshalBinary(expected), db.Get([]byte(fmt.Sprintf(commitInfoKeyFmt, version))))
+	}
+}
+
 //-----------------------------------------------------------------------
 // utils
 
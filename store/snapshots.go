@@ -0,0 +1,392 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// default size above which the entries of a snapshot are split into a
+	// new chunk
+	defaultSnapshotChunkSize = 4 << 20
+
+	snapshotManifestsDir = "manifests"
+	snapshotChunksDir    = "chunks"
+)
+
+// Snapshotter is implemented by the CommitMultiStores able to take state-sync
+// snapshots of their last committed version and to restore them.
+type Snapshotter interface {
+	Snapshot(snapshots *SnapshotStore, height int64) (SnapshotManifest, error)
+	Restore(snapshots *SnapshotStore, height int64, hash []byte) error
+}
+
+// SnapshotManifest describes a snapshot of the rootMultiStore at a height.
+// Hash is the commitInfo hash of the height, i.e. the app hash of its block,
+// which the store infos are verified against. The chunks are listed by their
+// hash, in the order they must be restored.
+type SnapshotManifest struct {
+	Height     int64          `json:"height"`
+	Hash       cmn.HexBytes   `json:"hash"`
+	StoreInfos []storeInfo    `json:"stores"`
+	Chunks     []cmn.HexBytes `json:"chunks"`
+}
+
+func (m SnapshotManifest) commitInfo() commitInfo {
+	return commitInfo{
+		Version:    m.Height,
+		StoreInfos: m.StoreInfos,
+	}
+}
+
+// verify checks the store infos of the manifest against its hash.
+func (m SnapshotManifest) verify() error {
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid snapshot height %d", m.Height)
+	}
+	if !bytes.Equal(m.commitInfo().Hash(), m.Hash) {
+		return fmt.Errorf("snapshot %d: store infos don't match hash %X", m.Height, m.Hash)
+	}
+	return nil
+}
+
+// snapshotItem is an encoded node of the tree of a mounted store. Chunks are
+// amino encoded lists of items.
+type snapshotItem struct {
+	Store string
+	Node  []byte
+}
+
+//----------------------------------------
+// SnapshotStore
+
+// SnapshotStore keeps snapshots in a directory, the manifests under
+// manifests/<height> and the chunks under chunks/<hash>. Snapshots can be
+// shared with other nodes by copying the directory.
+type SnapshotStore struct {
+	dir       string
+	chunkSize int
+}
+
+// NewSnapshotStore returns the snapshot store kept in dir.
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{
+		dir:       dir,
+		chunkSize: defaultSnapshotChunkSize,
+	}
+}
+
+// List returns the manifests of the snapshots, from the oldest.
+func (ss *SnapshotStore) List() ([]SnapshotManifest, error) {
+	files, err := ioutil.ReadDir(filepath.Join(ss.dir, snapshotManifestsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var heights []int64
+	for _, file := range files {
+		height, err := strconv.ParseInt(file.Name(), 10, 64)
+		if err != nil {
+			// not a manifest
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	manifests := make([]SnapshotManifest, 0, len(heights))
+	for _, height := range heights {
+		manifest, err := ss.Get(height)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// Get returns the verified manifest of the snapshot at height.
+func (ss *SnapshotStore) Get(height int64) (manifest SnapshotManifest, err error) {
+	bz, err := ioutil.ReadFile(ss.manifestPath(height))
+	if os.IsNotExist(err) {
+		return manifest, fmt.Errorf("no snapshot at height %d", height)
+	}
+	if err != nil {
+		return manifest, err
+	}
+	err = cdc.UnmarshalJSON(bz, &manifest)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to decode snapshot %d: %v", height, err)
+	}
+	if manifest.Height != height {
+		return manifest, fmt.Errorf("snapshot %d has height %d", height, manifest.Height)
+	}
+	return manifest, manifest.verify()
+}
+
+// Delete removes the snapshot at height along with the chunks no other
+// snapshot references.
+func (ss *SnapshotStore) Delete(height int64) error {
+	manifest, err := ss.Get(height)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(ss.manifestPath(height))
+	if err != nil {
+		return err
+	}
+
+	manifests, err := ss.List()
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, m := range manifests {
+		for _, hash := range m.Chunks {
+			used[hash.String()] = true
+		}
+	}
+	for _, hash := range manifest.Chunks {
+		if used[hash.String()] {
+			continue
+		}
+		err = os.Remove(ss.chunkPath(hash))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune deletes all but the keepRecent latest snapshots.
+func (ss *SnapshotStore) Prune(keepRecent int) error {
+	manifests, err := ss.List()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(manifests)-keepRecent; i++ {
+		err = ss.Delete(manifests[i].Height)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveManifest saves the manifest once all its chunks are saved, so partial
+// snapshots are never listed.
+func (ss *SnapshotStore) saveManifest(manifest SnapshotManifest) error {
+	bz, err := cdc.MarshalJSON(manifest)
+	if err != nil {
+		return err
+	}
+	err = cmn.EnsureDir(filepath.Join(ss.dir, snapshotManifestsDir), 0755)
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFileAtomic(ss.manifestPath(manifest.Height), bz, 0644)
+}
+
+// saveChunk saves the chunk under its hash, which is returned.
+func (ss *SnapshotStore) saveChunk(chunk []byte) (cmn.HexBytes, error) {
+	sum := sha256.Sum256(chunk)
+	hash := cmn.HexBytes(sum[:])
+	err := cmn.EnsureDir(filepath.Join(ss.dir, snapshotChunksDir), 0755)
+	if err != nil {
+		return nil, err
+	}
+	return hash, ioutil.WriteFile(ss.chunkPath(hash), chunk, 0644)
+}
+
+// loadChunk returns the chunk saved under hash, checking its content.
+func (ss *SnapshotStore) loadChunk(hash cmn.HexBytes) ([]byte, error) {
+	chunk, err := ioutil.ReadFile(ss.chunkPath(hash))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(chunk)
+	if !bytes.Equal(sum[:], hash) {
+		return nil, fmt.Errorf("chunk %s doesn't match its hash", hash)
+	}
+	return chunk, nil
+}
+
+func (ss *SnapshotStore) manifestPath(height int64) string {
+	return filepath.Join(ss.dir, snapshotManifestsDir, strconv.FormatInt(height, 10))
+}
+
+func (ss *SnapshotStore) chunkPath(hash cmn.HexBytes) string {
+	return filepath.Join(ss.dir, snapshotChunksDir, strings.ToLower(hash.String()))
+}
+
+//----------------------------------------
+// rootMultiStore snapshots
+
+// Snapshot saves a snapshot of the committed version height to the snapshot
+// store. Only the nodes of the trees of the IAVL stores at that version are
+// saved, split into chunks, leaving out the previous versions and the
+// orphaned nodes. It can run concurrently with the following commits, as
+// long as height isn't pruned in the meantime.
+func (rs *rootMultiStore) Snapshot(snapshots *SnapshotStore, height int64) (manifest SnapshotManifest, err error) {
+	if height <= 0 {
+		return manifest, fmt.Errorf("no committed version to snapshot")
+	}
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return manifest, err
+	}
+	manifest = SnapshotManifest{
+		Height:     height,
+		Hash:       cInfo.Hash(),
+		StoreInfos: cInfo.StoreInfos,
+	}
+
+	var items []snapshotItem
+	size := 0
+	flush := func() error {
+		if len(items) == 0 {
+			return nil
+		}
+		hash, err := snapshots.saveChunk(cdc.MustMarshalBinary(items))
+		if err != nil {
+			return err
+		}
+		manifest.Chunks = append(manifest.Chunks, hash)
+		items, size = nil, 0
+		return nil
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		// the transient stores are in the commitInfo, with nothing to save
+		params := rs.storesParams[rs.nameToKey(storeInfo.Name)]
+		if params.typ != sdk.StoreTypeIAVL {
+			continue
+		}
+
+		root := storeInfo.Core.CommitID.Hash
+		err = walkIAVLTree(rs.storeDB(params), root, func(bz []byte) error {
+			items = append(items, snapshotItem{storeInfo.Name, bz})
+			size += len(bz)
+			if size < snapshots.chunkSize {
+				return nil
+			}
+			return flush()
+		})
+		if err != nil {
+			return manifest, fmt.Errorf("failed to snapshot store %s: %v", storeInfo.Name, err)
+		}
+	}
+	if err = flush(); err != nil {
+		return manifest, err
+	}
+
+	return manifest, snapshots.saveManifest(manifest)
+}
+
+// Restore rebuilds the IAVL stores from the chunks of the snapshot at height
+// and loads that version. The hash of the snapshot must be the trusted app
+// hash of height, e.g. from a light client, as the snapshot itself comes from
+// an untrusted peer. The store infos of the manifest are verified against it
+// and the restored trees against the store infos, their roots being
+// recomputed from their leaves, before the version is persisted as the
+// latest one. Only an empty rootMultiStore can be restored.
+func (rs *rootMultiStore) Restore(snapshots *SnapshotStore, height int64, hash []byte) error {
+	if rs.lastCommitID.Version != 0 || getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("cannot restore a snapshot over committed versions")
+	}
+	manifest, err := snapshots.Get(height)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(manifest.Hash, hash) {
+		return fmt.Errorf("snapshot %d doesn't match the trusted hash %X", height, hash)
+	}
+
+	// the nodes are saved under the hash of their content, so the trees only
+	// reach the nodes matching the hashes they reference
+	restored := make(map[string]int)
+	for _, chunkHash := range manifest.Chunks {
+		chunk, err := snapshots.loadChunk(chunkHash)
+		if err != nil {
+			return err
+		}
+		var items []snapshotItem
+		err = cdc.UnmarshalBinary(chunk, &items)
+		if err != nil {
+			return fmt.Errorf("failed to decode chunk %s: %v", chunkHash, err)
+		}
+		for _, item := range items {
+			key, ok := rs.keysByName[item.Store]
+			if !ok {
+				return fmt.Errorf("snapshot has nodes of unmounted store %s", item.Store)
+			}
+			node, err := decodeIAVLNode(item.Node)
+			if err != nil {
+				return fmt.Errorf("snapshot has an invalid node of store %s: %v", item.Store, err)
+			}
+			rs.storeDB(rs.storesParams[key]).Set(iavlNodeKey(node.hash()), item.Node)
+			restored[item.Store]++
+		}
+	}
+
+	for _, storeInfo := range manifest.StoreInfos {
+		key, ok := rs.keysByName[storeInfo.Name]
+		if !ok {
+			return fmt.Errorf("snapshot has unmounted store %s", storeInfo.Name)
+		}
+		if rs.storesParams[key].typ != sdk.StoreTypeIAVL {
+			continue
+		}
+		commitID := storeInfo.Core.CommitID
+		if commitID.Version != height {
+			return fmt.Errorf("snapshot has store %s at version %d", storeInfo.Name, commitID.Version)
+		}
+
+		db := rs.storeDB(rs.storesParams[key])
+		nodes := 0
+		err = walkIAVLTree(db, commitID.Hash, func([]byte) error {
+			nodes++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore store %s: %v", storeInfo.Name, err)
+		}
+		if nodes != restored[storeInfo.Name] {
+			return fmt.Errorf("snapshot has %d nodes of store %s outside of its tree", restored[storeInfo.Name]-nodes, storeInfo.Name)
+		}
+		delete(restored, storeInfo.Name)
+		setIAVLRoot(db, height, commitID.Hash)
+
+		store, err := rs.loadCommitStoreFromParams(key, commitID, rs.storesParams[key])
+		if err != nil {
+			return fmt.Errorf("failed to restore store %s: %v", storeInfo.Name, err)
+		}
+		if !bytes.Equal(store.LastCommitID().Hash, commitID.Hash) {
+			return fmt.Errorf("restored store %s doesn't match hash %X", storeInfo.Name, commitID.Hash)
+		}
+	}
+	for name := range restored {
+		return fmt.Errorf("snapshot has nodes of store %s missing from its manifest", name)
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, height, manifest.commitInfo())
+	setLatestVersion(batch, height)
+	batch.Write()
+
+	return rs.LoadVersion(height)
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newSnapshotStore(t *testing.T) (*SnapshotStore, func()) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	snapshots := NewSnapshotStore(dir)
+	snapshots.chunkSize = 100
+	return snapshots, func() { os.RemoveAll(dir) }
+}
+
+// newSnapshotMultiStore returns a multistore mounting a transient store
+// along with its IAVL stores, like the apps do.
+func newSnapshotMultiStore() *rootMultiStore {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.MountStoreWithDB(sdk.NewTransientStoreKey("transient"), sdk.StoreTypeTransient, nil)
+	return store
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	snapshots, cleanup := newSnapshotStore(t)
+	defer cleanup()
+
+	src := newSnapshotMultiStore()
+	require.Nil(t, src.LoadLatestVersion())
+
+	// nothing to snapshot before the first commit
+	_, err := src.Snapshot(snapshots, 0)
+	require.NotNil(t, err)
+
+	for i := 0; i < 5; i++ {
+		for _, name := range []string{"store1", "store2", "store3"} {
+			store := src.getStoreByName(name).(KVStore)
+			store.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("%s-%d", name, i)))
+		}
+		src.getStoreByName("transient").(KVStore).Set([]byte("key"), []byte("transient"))
+		src.Commit()
+	}
+	manifest, err := src.Snapshot(snapshots, 5)
+	require.Nil(t, err)
+	require.Equal(t, int64(5), manifest.Height)
+	require.Equal(t, src.LastCommitID().Hash, []byte(manifest.Hash))
+	require.True(t, len(manifest.Chunks) > 1)
+
+	manifests, err := snapshots.List()
+	require.Nil(t, err)
+	require.Equal(t, []SnapshotManifest{manifest}, manifests)
+
+	// the snapshot must match the trusted hash
+	dest := newSnapshotMultiStore()
+	require.Nil(t, dest.LoadLatestVersion())
+	require.NotNil(t, dest.Restore(snapshots, 4, manifest.Hash))
+	require.NotNil(t, dest.Restore(snapshots, 5, []byte("untrusted")))
+
+	// the restored store has the same state and hash, without the previous
+	// versions
+	require.Nil(t, dest.Restore(snapshots, 5, manifest.Hash))
+	require.Equal(t, src.LastCommitID(), dest.LastCommitID())
+	store := dest.getStoreByName("store2").(KVStore)
+	require.Equal(t, []byte("store2-3"), store.Get([]byte("key3")))
+	require.False(t, store.(*iavlStore).VersionExists(4))
+
+	// a store can only be restored once
+	require.NotNil(t, dest.Restore(snapshots, 5, manifest.Hash))
+
+	// the restored store keeps committing
+	store.Set([]byte("key5"), []byte("store2-5"))
+	require.Equal(t, int64(6), dest.Commit().Version)
+
+	// tampered nodes are rejected, even in chunks matching the manifest
+	chunk, err := snapshots.loadChunk(manifest.Chunks[0])
+	require.Nil(t, err)
+	var items []snapshotItem
+	require.Nil(t, cdc.UnmarshalBinary(chunk, &items))
+	node := items[len(items)-1].Node
+	node[len(node)-1]++
+	tampered := manifest
+	tampered.Chunks = append([]cmn.HexBytes{}, manifest.Chunks...)
+	tampered.Chunks[0], err = snapshots.saveChunk(cdc.MustMarshalBinary(items))
+	require.Nil(t, err)
+	require.Nil(t, snapshots.saveManifest(tampered))
+	dest = newSnapshotMultiStore()
+	require.Nil(t, dest.LoadLatestVersion())
+	require.NotNil(t, dest.Restore(snapshots, 5, manifest.Hash))
+	require.Nil(t, snapshots.saveManifest(manifest))
+
+	// corrupted chunks are rejected
+	err = ioutil.WriteFile(snapshots.chunkPath(manifest.Chunks[0]), []byte("corrupted"), 0644)
+	require.Nil(t, err)
+	dest = newSnapshotMultiStore()
+	require.Nil(t, dest.LoadLatestVersion())
+	require.NotNil(t, dest.Restore(snapshots, 5, manifest.Hash))
+}
+
+func TestSnapshotPrune(t *testing.T) {
+	snapshots, cleanup := newSnapshotStore(t)
+	defer cleanup()
+
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	for i := 0; i < 3; i++ {
+		store.getStoreByName("store1").(KVStore).Set([]byte("key"), []byte{byte(i)})
+		_, err := store.Snapshot(snapshots, store.Commit().Version)
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, snapshots.Prune(2))
+	manifests, err := snapshots.List()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(manifests))
+	require.Equal(t, int64(2), manifests[0].Height)
+
+	// the chunks of the deleted snapshot are removed, the others are kept
+	_, err = snapshots.Get(1)
+	require.NotNil(t, err)
+	for _, manifest := range manifests {
+		for _, hash := range manifest.Chunks {
+			_, err = snapshots.loadChunk(hash)
+			require.Nil(t, err)
+		}
+	}
+	files, err := ioutil.ReadDir(snapshots.dir + "/" + snapshotChunksDir)
+	require.Nil(t, err)
+	chunks := make(map[string]bool)
+	for _, manifest := range manifests {
+		for _, hash := range manifest.Chunks {
+			chunks[hash.String()] = true
+		}
+	}
+	require.Equal(t, len(chunks), len(files))
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"io"
+	"os"
+)
+
+// maximum size of a change set record read by ReadBlockChangeSet
+const maxChangeSetRecordSize = 1 << 30
+
+// StoreKVPair is a change of a key of a store, Value being nil when the key
+// is deleted. TxIndex is the index in the block of the tx that made the
+// change, -1 for the changes made outside of the txs, i.e. in InitChain,
+// BeginBlock and EndBlock.
+type StoreKVPair struct {
+	StoreKey string `json:"store_key"`
+	TxIndex  int64  `json:"tx_index"`
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
+}
+
+// BlockChangeSet is the ordered list of the changes made to the deliver state
+// of the block at Height. The changes of failed txs and of the check state are
+// never part of it.
+type BlockChangeSet struct {
+	Height  int64         `json:"height"`
+	Changes []StoreKVPair `json:"changes"`
+}
+
+// StreamingListener receives the change set of each block committed by a
+// rootMultiStore, before it is persisted. An error halts the node so no
+// block is missing from the stream, the change set of the block is then
+// streamed again once it is replayed.
+type StreamingListener interface {
+	ListenCommit(changeSet BlockChangeSet) error
+}
+
+// Listenable is implemented by the CommitMultiStores streaming the change sets
+// of their committed blocks.
+type Listenable interface {
+	AddListener(listener StreamingListener)
+	ListeningEnabled() bool
+
+	// ListeningCacheMultiStore returns a CacheMultiStore recording the changes
+	// written to it for the change set of the next committed block, replacing
+	// the one returned before.
+	ListeningCacheMultiStore() ListeningCacheMultiStore
+}
+
+// ListeningCacheMultiStore is the CacheMultiStore of the deliver state of a
+// block, recording its changes.
+type ListeningCacheMultiStore interface {
+	CacheMultiStore
+
+	// SetTxIndex sets the tx index the next changes are annotated with.
+	SetTxIndex(txIndex int64)
+}
+
+// changeSetBuffer records the changes of the block being executed.
+type changeSetBuffer struct {
+	txIndex int64
+	changes []StoreKVPair
+}
+
+func newChangeSetBuffer() *changeSetBuffer {
+	return &changeSetBuffer{txIndex: -1}
+}
+
+func (buf *changeSetBuffer) record(storeKey string, key, value []byte, delete bool) {
+	buf.changes = append(buf.changes, StoreKVPair{
+		StoreKey: storeKey,
+		TxIndex:  buf.txIndex,
+		Delete:   delete,
+		Key:      key,
+		Value:    value,
+	})
+}
+
+//----------------------------------------
+// FileStreamingListener
+
+// FileStreamingListener appends the change set of each block to a file as a
+// length-prefixed amino binary record, synced before the block is persisted.
+// After a crash, the record of the last height may be repeated.
+type FileStreamingListener struct {
+	file *os.File
+}
+
+var _ StreamingListener = (*FileStreamingListener)(nil)
+
+// NewFileStreamingListener returns a listener appending to the file at path,
+// which is created if needed.
+func NewFileStreamingListener(path string) (*FileStreamingListener, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileStreamingListener{file: file}, nil
+}
+
+// ListenCommit implements StreamingListener.
+func (fl *FileStreamingListener) ListenCommit(changeSet BlockChangeSet) error {
+	bz, err := cdc.MarshalBinary(changeSet)
+	if err != nil {
+		return err
+	}
+	_, err = fl.file.Write(bz)
+	if err != nil {
+		return err
+	}
+	return fl.file.Sync()
+}
+
+// Close closes the file.
+func (fl *FileStreamingListener) Close() error {
+	return fl.file.Close()
+}
+
+// ReadBlockChangeSet reads the next record written by a FileStreamingListener.
+// It returns io.EOF once all the records are read.
+func ReadBlockChangeSet(r io.Reader) (changeSet BlockChangeSet, err error) {
+	_, err = cdc.UnmarshalBinaryReader(r, &changeSet, maxChangeSetRecordSize)
+	return changeSet, err
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+type changeSetRecorder struct {
+	changeSets []BlockChangeSet
+}
+
+func (r *changeSetRecorder) ListenCommit(changeSet BlockChangeSet) error {
+	r.changeSets = append(r.changeSets, changeSet)
+	return nil
+}
+
+func TestStreamingChangeSet(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	require.False(t, store.ListeningEnabled())
+	listener := &changeSetRecorder{}
+	store.AddListener(listener)
+	require.True(t, store.ListeningEnabled())
+
+	key1, key2 := store.keysByName["store1"], store.keysByName["store2"]
+	deliver := store.ListeningCacheMultiStore()
+	check := store.CacheMultiStore()
+	check.GetKVStore(key1).Set([]byte("check"), []byte("value"))
+
+	deliver.GetKVStore(key1).Set([]byte("begin"), []byte("value"))
+
+	// the changes of the written tx caches are recorded with their tx index
+	deliver.SetTxIndex(0)
+	tx := deliver.CacheMultiStore()
+	tx.GetKVStore(key2).Prefix([]byte("p/")).Set([]byte("a"), []byte("0"))
+	tx.GetKVStore(key1).Delete([]byte("begin"))
+	tx.Write()
+
+	deliver.SetTxIndex(1)
+	tx = deliver.CacheMultiStore()
+	tx.GetKVStore(key1).Set([]byte("discarded"), []byte("1"))
+
+	deliver.SetTxIndex(-1)
+	deliver.Write()
+	check.Write()
+	store.Commit()
+
+	require.Equal(t, []BlockChangeSet{{
+		Height: 1,
+		Changes: []StoreKVPair{
+			{StoreKey: "store1", TxIndex: -1, Key: []byte("begin"), Value: []byte("value")},
+			{StoreKey: "store1", TxIndex: 0, Delete: true, Key: []byte("begin")},
+			{StoreKey: "store2", TxIndex: 0, Key: []byte("p/a"), Value: []byte("0")},
+		},
+	}}, listener.changeSets)
+
+	// blocks without changes are streamed too
+	store.ListeningCacheMultiStore().Write()
+	store.Commit()
+	require.Equal(t, BlockChangeSet{Height: 2}, listener.changeSets[1])
+}
+
+func TestFileStreamingListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "changesets")
+
+	changeSets := []BlockChangeSet{
+		{Height: 1, Changes: []StoreKVPair{{StoreKey: "store1", TxIndex: -1, Key: []byte("a"), Value: []byte("1")}}},
+		{Height: 2},
+		{Height: 3, Changes: []StoreKVPair{{StoreKey: "store2", TxIndex: 4, Delete: true, Key: []byte("b")}}},
+	}
+	listener, err := NewFileStreamingListener(path)
+	require.Nil(t, err)
+	for _, changeSet := range changeSets[:2] {
+		require.Nil(t, listener.ListenCommit(changeSet))
+	}
+	require.Nil(t, listener.Close())
+
+	// the records are appended to the existing file
+	listener, err = NewFileStreamingListener(path)
+	require.Nil(t, err)
+	require.Nil(t, listener.ListenCommit(changeSets[2]))
+	require.Nil(t, listener.Close())
+
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer file.Close()
+	for _, expected := range changeSets {
+		changeSet, err := ReadBlockChangeSet(file)
+		require.Nil(t, err)
+		require.Equal(t, expected, changeSet)
+	}
+	_, err = ReadBlockChangeSet(file)
+	require.Equal(t, io.EOF, err)
+}
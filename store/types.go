@@ -24,6 +24,8 @@ type (
 	CommitID         = types.CommitID
 	StoreKey         = types.StoreKey
 	StoreType        = types.StoreType
+	StoreUpgrades    = types.StoreUpgrades
+	StoreRename      = types.StoreRename
 	Queryable        = types.Queryable
 	TraceContext     = types.TraceContext
 	Gas              = types.Gas
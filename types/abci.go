@@ -5,11 +5,19 @@ import abci "github.com/tendermint/tendermint/abci/types"
 // initialize application state at genesis
 type InitChainer func(ctx Context, req abci.RequestInitChain) abci.ResponseInitChain
 
+// perform the software upgrade scheduled at the height of a block, if any,
+// before the block is processed. An error, returned if the binary can't
+// perform the upgrade, halts the chain.
+type Upgrader func(ctx Context) error
+
 // run code before the transactions in a block
 type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock
 
 // run code after the transactions in a block and return updates to the validator set
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
 
+// rebuild the memory stores from the committed state when the app is loaded
+type MemStoreInitializer func(ctx Context)
+
 // respond to p2p filtering queries from Tendermint
 type PeerFilter func(info string) abci.ResponseQuery
@@ -277,8 +277,9 @@ func (coins Coins) Sort() Coins {
 // Parsing
 
 var (
-	// Denominations can be 3 ~ 16 characters long.
-	reDnm  = `[[:alpha:]][[:alnum:]]{2,15}`
+	// Denominations can be 3 ~ 16 characters long, IBC vouchers are prefixed
+	// with the chains they were transferred through, e.g. gaia-7001/steak.
+	reDnm  = `(?:[[:alnum:]][[:alnum:]._-]{0,49}/)*[[:alpha:]][[:alnum:]]{2,15}`
 	reAmt  = `[[:digit:]]+`
 	reSpc  = `[[:space:]]*`
 	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
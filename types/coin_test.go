@@ -282,6 +282,11 @@ func TestParse(t *testing.T) {
 		{"11me coin, 12you coin", false, nil}, // no spaces in coin names
 		{"1.2btc", false, nil},                // amount must be integer
 		{"5foo-bar", false, nil},              // once more, only letters in coin name
+		{"5gaia-7001/steak", true, Coins{{"gaia-7001/steak", NewInt(5)}}},
+		{"5hub/gaia-7001/steak,2foo", true, Coins{{"foo", NewInt(2)}, {"hub/gaia-7001/steak", NewInt(5)}}},
+		{"5gaia-7001/", false, nil},       // vouchers have a base denom
+		{"5/steak", false, nil},           // and a chain for every hop
+		{"5gaia-7001/st-eak", false, nil}, // the base denom is still only letters
 	}
 
 	for tcIndex, tc := range cases {
@@ -44,6 +44,8 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 	c = c.WithLogger(logger)
 	c = c.WithSigningValidators(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
+	c = c.WithIsCheckTx(isCheckTx)
+	c = c.WithMinGasPrices(DecCoins{})
 	return c
 }
 
@@ -77,6 +79,12 @@ func (c Context) TransientStore(key StoreKey) KVStore {
 	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), cachedTransientGasConfig)
 }
 
+// MemoryStore fetches a MemoryStore from the MultiStore. Being in memory
+// like the TransientStores, it is charged the same gas.
+func (c Context) MemoryStore(key StoreKey) KVStore {
+	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), cachedTransientGasConfig)
+}
+
 //----------------------------------------
 // With* (setting a value)
 
@@ -135,6 +143,8 @@ const (
 	contextKeyLogger
 	contextKeySigningValidators
 	contextKeyGasMeter
+	contextKeyIsCheckTx
+	contextKeyMinGasPrices
 )
 
 // NOTE: Do not expose MultiStore.
@@ -169,6 +179,12 @@ func (c Context) SigningValidators() []abci.SigningValidator {
 func (c Context) GasMeter() GasMeter {
 	return c.Value(contextKeyGasMeter).(GasMeter)
 }
+func (c Context) IsCheckTx() bool {
+	return c.Value(contextKeyIsCheckTx).(bool)
+}
+func (c Context) MinGasPrices() DecCoins {
+	return c.Value(contextKeyMinGasPrices).(DecCoins)
+}
 func (c Context) WithMultiStore(ms MultiStore) Context {
 	return c.withValue(contextKeyMultiStore, ms)
 }
@@ -201,6 +217,12 @@ func (c Context) WithSigningValidators(SigningValidators []abci.SigningValidator
 func (c Context) WithGasMeter(meter GasMeter) Context {
 	return c.withValue(contextKeyGasMeter, meter)
 }
+func (c Context) WithIsCheckTx(isCheckTx bool) Context {
+	return c.withValue(contextKeyIsCheckTx, isCheckTx)
+}
+func (c Context) WithMinGasPrices(gasPrices DecCoins) Context {
+	return c.withValue(contextKeyMinGasPrices, gasPrices)
+}
 
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
@@ -153,6 +153,8 @@ func TestContextWithCustom(t *testing.T) {
 	require.Panics(t, func() { ctx.Logger() })
 	require.Panics(t, func() { ctx.SigningValidators() })
 	require.Panics(t, func() { ctx.GasMeter() })
+	require.Panics(t, func() { ctx.IsCheckTx() })
+	require.Panics(t, func() { ctx.MinGasPrices() })
 
 	header := abci.Header{}
 	height := int64(1)
@@ -162,13 +164,15 @@ func TestContextWithCustom(t *testing.T) {
 	logger := NewMockLogger()
 	signvals := []abci.SigningValidator{{}}
 	meter := types.NewGasMeter(10000)
+	minGasPrices := types.DecCoins{types.NewDecCoinFromDec("steak", types.NewDecWithPrec(25, 3))}
 
 	ctx = types.NewContext(nil, header, ischeck, logger).
 		WithBlockHeight(height).
 		WithChainID(chainid).
 		WithTxBytes(txbytes).
 		WithSigningValidators(signvals).
-		WithGasMeter(meter)
+		WithGasMeter(meter).
+		WithMinGasPrices(minGasPrices)
 
 	require.Equal(t, header, ctx.BlockHeader())
 	require.Equal(t, height, ctx.BlockHeight())
@@ -177,5 +181,7 @@ func TestContextWithCustom(t *testing.T) {
 	require.Equal(t, logger, ctx.Logger())
 	require.Equal(t, signvals, ctx.SigningValidators())
 	require.Equal(t, meter, ctx.GasMeter())
+	require.Equal(t, ischeck, ctx.IsCheckTx())
+	require.Equal(t, minGasPrices, ctx.MinGasPrices())
 
 }
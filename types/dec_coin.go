@@ -0,0 +1,326 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Decimal Coin
+
+// DecCoin holds some amount of one currency with fractional precision. It is
+// used where the intermediate results of a computation (such as a share of
+// collected fees) must be tracked exactly before being truncated into Coins.
+type DecCoin struct {
+	Denom  string `json:"denom"`
+	Amount Dec    `json:"amount"`
+}
+
+func NewDecCoin(denom string, amount int64) DecCoin {
+	return DecCoin{
+		Denom:  denom,
+		Amount: NewDec(amount),
+	}
+}
+
+func NewDecCoinFromDec(denom string, amount Dec) DecCoin {
+	return DecCoin{
+		Denom:  denom,
+		Amount: amount,
+	}
+}
+
+func NewDecCoinFromCoin(coin Coin) DecCoin {
+	return DecCoin{
+		Denom:  coin.Denom,
+		Amount: NewDecFromInt(coin.Amount),
+	}
+}
+
+// Adds amounts of two coins with same denom
+func (coin DecCoin) Plus(coinB DecCoin) DecCoin {
+	if coin.Denom != coinB.Denom {
+		panic(fmt.Sprintf("coin denom different: %v %v\n", coin.Denom, coinB.Denom))
+	}
+	return DecCoin{coin.Denom, coin.Amount.Add(coinB.Amount)}
+}
+
+// Subtracts amounts of two coins with same denom
+func (coin DecCoin) Minus(coinB DecCoin) DecCoin {
+	if coin.Denom != coinB.Denom {
+		panic(fmt.Sprintf("coin denom different: %v %v\n", coin.Denom, coinB.Denom))
+	}
+	return DecCoin{coin.Denom, coin.Amount.Sub(coinB.Amount)}
+}
+
+// return the decimal coins with trunctated decimals, and return the change
+func (coin DecCoin) TruncateDecimal() (Coin, DecCoin) {
+	truncated := coin.Amount.TruncateInt()
+	change := coin.Amount.Sub(NewDecFromInt(truncated))
+	return NewCoin(coin.Denom, truncated), DecCoin{coin.Denom, change}
+}
+
+// IsPositive returns true if coin amount is positive
+func (coin DecCoin) IsPositive() bool {
+	return coin.Amount.GT(ZeroDec())
+}
+
+// String provides a human-readable representation of a coin
+func (coin DecCoin) String() string {
+	return fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
+}
+
+// ----------------------------------------------------------------------------
+// Decimal Coins
+
+// coins with decimal
+type DecCoins []DecCoin
+
+func NewDecCoins(coins Coins) DecCoins {
+	dcs := make(DecCoins, len(coins))
+	for i, coin := range coins {
+		dcs[i] = NewDecCoinFromCoin(coin)
+	}
+	return dcs
+}
+
+// String provides a human-readable representation of decimal coins
+func (coins DecCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+
+	out := ""
+	for _, coin := range coins {
+		out += fmt.Sprintf("%v,", coin.String())
+	}
+	return out[:len(out)-1]
+}
+
+// return the coins with trunctated decimals, and return the change
+func (coins DecCoins) TruncateDecimal() (Coins, DecCoins) {
+	changeSum := DecCoins{}
+	out := make(Coins, 0, len(coins))
+	for _, coin := range coins {
+		truncated, change := coin.TruncateDecimal()
+		if !truncated.IsZero() {
+			out = append(out, truncated)
+		}
+		changeSum = changeSum.Plus(DecCoins{change})
+	}
+	return out, changeSum
+}
+
+// Plus combines two sets of coins
+// CONTRACT: Plus will never return Coins where one Coin has a 0 amount.
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := ([]DecCoin)(nil)
+	indexA, indexB := 0, 0
+	lenA, lenB := len(coins), len(coinsB)
+	for {
+		if indexA == lenA {
+			if indexB == lenB {
+				return sum
+			}
+			return append(sum, removeZeroDecCoins(coinsB[indexB:])...)
+		} else if indexB == lenB {
+			return append(sum, removeZeroDecCoins(coins[indexA:])...)
+		}
+		coinA, coinB := coins[indexA], coinsB[indexB]
+		switch strings.Compare(coinA.Denom, coinB.Denom) {
+		case -1:
+			if !coinA.Amount.IsZero() {
+				sum = append(sum, coinA)
+			}
+			indexA++
+		case 0:
+			res := coinA.Plus(coinB)
+			if !res.Amount.IsZero() {
+				sum = append(sum, res)
+			}
+			indexA++
+			indexB++
+		case 1:
+			if !coinB.Amount.IsZero() {
+				sum = append(sum, coinB)
+			}
+			indexB++
+		}
+	}
+}
+
+// Negative returns a set of coins with all amount negative
+func (coins DecCoins) Negative() DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Neg(),
+		})
+	}
+	return res
+}
+
+// Minus subtracts a set of coins from another (adds the inverse)
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	return coins.Plus(coinsB.Negative())
+}
+
+// multiply all the coins by a decimal
+func (coins DecCoins) MulDec(d Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		product := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Mul(d),
+		}
+		if !product.Amount.IsZero() {
+			res = append(res, product)
+		}
+	}
+	return res
+}
+
+// multiply all the coins by a decimal, truncating
+func (coins DecCoins) MulDecTruncate(d Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		product := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.MulTruncate(d),
+		}
+		if !product.Amount.IsZero() {
+			res = append(res, product)
+		}
+	}
+	return res
+}
+
+// divide all the coins by a decimal
+func (coins DecCoins) QuoDec(d Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		quotient := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Quo(d),
+		}
+		if !quotient.Amount.IsZero() {
+			res = append(res, quotient)
+		}
+	}
+	return res
+}
+
+// returns the amount of a denom from deccoins
+func (coins DecCoins) AmountOf(denom string) Dec {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return ZeroDec()
+}
+
+// IsZero returns true if there are no coins or all coins are zero
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEqual returns true if the two sets of DecCoins have the same value
+func (coins DecCoins) IsEqual(coinsB DecCoins) bool {
+	if len(coins) != len(coinsB) {
+		return false
+	}
+	for i := 0; i < len(coins); i++ {
+		if coins[i].Denom != coinsB[i].Denom || !coins[i].Amount.Equal(coinsB[i].Amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNotNegative returns true if there is no currency with a negative value
+// (even no coins is true here)
+func (coins DecCoins) IsNotNegative() bool {
+	for _, coin := range coins {
+		if coin.Amount.LT(ZeroDec()) {
+			return false
+		}
+	}
+	return true
+}
+
+func removeZeroDecCoins(coins DecCoins) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			res = append(res, coin)
+		}
+	}
+	return res
+}
+
+//----------------------------------------
+// Parsing
+
+var (
+	reDecAmt  = `[[:digit:]]+(?:\.[[:digit:]]+)?`
+	reDecCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDecAmt, reSpc, reDnm))
+)
+
+// ParseDecCoin parses a cli input for one decimal coin type, returning errors
+// if invalid. This returns an error on an empty string as well.
+func ParseDecCoin(coinStr string) (coin DecCoin, err error) {
+	coinStr = strings.TrimSpace(coinStr)
+
+	matches := reDecCoin.FindStringSubmatch(coinStr)
+	if matches == nil {
+		err = fmt.Errorf("invalid decimal coin expression: %s", coinStr)
+		return
+	}
+	denomStr, amountStr := matches[2], matches[1]
+
+	amount, decErr := NewDecFromStr(amountStr)
+	if decErr != nil {
+		err = fmt.Errorf("invalid decimal coin amount: %s", amountStr)
+		return
+	}
+
+	return DecCoin{denomStr, amount}, nil
+}
+
+// ParseDecCoins will parse out a list of decimal coins separated by commas.
+// If nothing is provided, it returns nil DecCoins. Returned coins are sorted
+// by denomination, which must be unique.
+func ParseDecCoins(coinsStr string) (coins DecCoins, err error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	coinStrs := strings.Split(coinsStr, ",")
+	for _, coinStr := range coinStrs {
+		coin, err := ParseDecCoin(coinStr)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, coin)
+	}
+
+	// Sort coins for determinism.
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Denom < coins[j].Denom })
+	for i := 1; i < len(coins); i++ {
+		if coins[i-1].Denom == coins[i].Denom {
+			return nil, fmt.Errorf("duplicate denomination %s", coins[i].Denom)
+		}
+	}
+
+	return coins, nil
+}
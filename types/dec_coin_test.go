@@ -0,0 +1,102 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlusDecCoins(t *testing.T) {
+	one := NewDec(1)
+	zero := NewDec(0)
+	two := NewDec(2)
+
+	cases := []struct {
+		inputOne DecCoins
+		inputTwo DecCoins
+		expected DecCoins
+	}{
+		{DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", two}, {"B", two}}},
+		{DecCoins{{"A", zero}, {"B", one}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"B", one}}},
+		{DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", one}, {"B", zero}}, DecCoins{{"A", one.Neg()}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", one}}, DecCoins{{"B", one}}, DecCoins{{"A", one}, {"B", one}}},
+	}
+
+	for tcIndex, tc := range cases {
+		res := tc.inputOne.Plus(tc.inputTwo)
+		require.True(t, tc.expected.IsEqual(res), "sum of coins is incorrect, tc #%d, exp %v, got %v", tcIndex, tc.expected, res)
+	}
+}
+
+func TestMinusDecCoins(t *testing.T) {
+	coinsA := DecCoins{NewDecCoin("atom", 5), NewDecCoin("steak", 10)}
+	coinsB := DecCoins{NewDecCoin("steak", 4)}
+
+	res := coinsA.Minus(coinsB)
+	require.True(t, DecCoins{NewDecCoin("atom", 5), NewDecCoin("steak", 6)}.IsEqual(res))
+	require.True(t, res.IsNotNegative())
+
+	res = coinsB.Minus(coinsA)
+	require.False(t, res.IsNotNegative())
+}
+
+func TestMulQuoDecCoins(t *testing.T) {
+	coins := DecCoins{NewDecCoin("atom", 10), NewDecCoin("steak", 3)}
+
+	res := coins.MulDec(NewDecWithPrec(5, 1))
+	exp := DecCoins{NewDecCoin("atom", 5), NewDecCoinFromDec("steak", NewDecWithPrec(15, 1))}
+	require.True(t, exp.IsEqual(res), "exp %v, got %v", exp, res)
+
+	res = coins.QuoDec(NewDec(2))
+	require.True(t, exp.IsEqual(res), "exp %v, got %v", exp, res)
+
+	res = coins.MulDecTruncate(NewDecWithPrec(5, 1))
+	require.True(t, exp.IsEqual(res), "exp %v, got %v", exp, res)
+
+	// zero products are dropped
+	res = coins.MulDec(ZeroDec())
+	require.True(t, res.IsZero())
+	require.Equal(t, 0, len(res))
+}
+
+func TestTruncateDecCoins(t *testing.T) {
+	coins := DecCoins{
+		NewDecCoinFromDec("atom", NewDecWithPrec(25, 1)),
+		NewDecCoinFromDec("steak", NewDecWithPrec(5, 1)),
+	}
+
+	truncated, change := coins.TruncateDecimal()
+	require.True(t, Coins{NewInt64Coin("atom", 2)}.IsEqual(truncated), "got %v", truncated)
+	exp := DecCoins{
+		NewDecCoinFromDec("atom", NewDecWithPrec(5, 1)),
+		NewDecCoinFromDec("steak", NewDecWithPrec(5, 1)),
+	}
+	require.True(t, exp.IsEqual(change), "got %v", change)
+
+	// the truncated coins plus the change add up to the original amount
+	require.True(t, coins.IsEqual(NewDecCoins(truncated).Plus(change)))
+}
+
+func TestAmountOfDecCoins(t *testing.T) {
+	coins := DecCoins{NewDecCoin("atom", 1), NewDecCoin("steak", 7)}
+	require.True(t, NewDec(7).Equal(coins.AmountOf("steak")))
+	require.True(t, ZeroDec().Equal(coins.AmountOf("photon")))
+}
+
+func TestParseDecCoins(t *testing.T) {
+	coins, err := ParseDecCoins("0.025steak, 1atom")
+	require.NoError(t, err)
+	require.True(t, coins.IsEqual(DecCoins{NewDecCoin("atom", 1), NewDecCoinFromDec("steak", NewDecWithPrec(25, 3))}))
+
+	coins, err = ParseDecCoins("")
+	require.NoError(t, err)
+	require.Nil(t, coins)
+
+	_, err = ParseDecCoins("1atom,2atom")
+	require.Error(t, err)
+	_, err = ParseDecCoins(".5atom")
+	require.Error(t, err)
+	_, err = ParseDecCoins("-1atom")
+	require.Error(t, err)
+}
@@ -182,6 +182,12 @@ func (d Dec) LT(d2 Dec) bool    { return (d.Int).Cmp(d2.Int) < 0 }      // less
 func (d Dec) LTE(d2 Dec) bool   { return (d.Int).Cmp(d2.Int) <= 0 }     // less than or equal
 func (d Dec) Neg() Dec          { return Dec{new(big.Int).Neg(d.Int)} } // reverse the decimal sign
 
+// is the decimal uninitialized, e.g. an omitted field of a decoded struct
+func (d Dec) IsNil() bool { return d.Int == nil }
+
+// absolute value of the decimal
+func (d Dec) Abs() Dec { return Dec{new(big.Int).Abs(d.Int)} }
+
 // addition
 func (d Dec) Add(d2 Dec) Dec {
 	res := new(big.Int).Add(d.Int, d2.Int)
@@ -213,6 +219,17 @@ func (d Dec) Mul(d2 Dec) Dec {
 	return Dec{chopped}
 }
 
+// multiplication truncating the decimals beyond the precision
+func (d Dec) MulTruncate(d2 Dec) Dec {
+	mul := new(big.Int).Mul(d.Int, d2.Int)
+	chopped := mul.Quo(mul, precisionReuse)
+
+	if chopped.BitLen() > 255+DecimalPrecisionBits {
+		panic("Int overflow")
+	}
+	return Dec{chopped}
+}
+
 // quotient
 func (d Dec) Quo(d2 Dec) Dec {
 
@@ -229,6 +246,19 @@ func (d Dec) Quo(d2 Dec) Dec {
 	return Dec{chopped}
 }
 
+// quotient truncating the decimals beyond the precision
+func (d Dec) QuoTruncate(d2 Dec) Dec {
+
+	// multiply precision once
+	mul := new(big.Int).Mul(d.Int, precisionReuse)
+	quo := mul.Quo(mul, d2.Int)
+
+	if quo.BitLen() > 255+DecimalPrecisionBits {
+		panic("Int overflow")
+	}
+	return Dec{quo}
+}
+
 func (d Dec) String() string {
 	str := d.ToLeftPaddedWithDecimals(Precision)
 	placement := len(str) - Precision
@@ -321,6 +351,16 @@ func (d Dec) RoundInt() Int {
 	return NewIntFromBigInt(chopPrecisionAndRoundNonMutative(d.Int))
 }
 
+// TruncateInt truncates the decimals from the number and returns an Int
+func (d Dec) TruncateInt() Int {
+	return NewIntFromBigInt(new(big.Int).Quo(d.Int, precisionReuse))
+}
+
+// TruncateDec truncates the decimals from the number and returns a Dec
+func (d Dec) TruncateDec() Dec {
+	return NewDecFromBigInt(new(big.Int).Quo(d.Int, precisionReuse))
+}
+
 //___________________________________________________________________________________
 
 // reuse nil values
@@ -176,6 +176,24 @@ func TestArithmetic(t *testing.T) {
 	}
 }
 
+func TestTruncatedArithmetic(t *testing.T) {
+	// 2/3 is 0.6666666667 when rounded, 0.6666666666 when truncated
+	twoThirdsRounded := NewDec(2).Quo(NewDec(3))
+	twoThirdsTruncated := NewDec(2).QuoTruncate(NewDec(3))
+	require.True(t, twoThirdsRounded.GT(twoThirdsTruncated))
+	require.True(t, twoThirdsRounded.Sub(twoThirdsTruncated).Equal(NewDecWithPrec(1, Precision)))
+
+	// 0.6666666667 * 0.5 is 0.33333333335 before chopping
+	half := NewDecWithPrec(5, 1)
+	require.True(t, twoThirdsRounded.Mul(half).Equal(mustNewDecFromStr(t, "0.3333333334")))
+	require.True(t, twoThirdsRounded.MulTruncate(half).Equal(mustNewDecFromStr(t, "0.3333333333")))
+
+	// exact results are unaffected
+	require.True(t, NewDec(21).Equal(NewDec(3).MulTruncate(NewDec(7))))
+	require.True(t, NewDecWithPrec(5, 1).Equal(NewDec(2).QuoTruncate(NewDec(4))))
+	require.Panics(t, func() { NewDec(1).QuoTruncate(ZeroDec()) })
+}
+
 func TestBankerRoundChop(t *testing.T) {
 	tests := []struct {
 		d1  Dec
@@ -202,6 +220,32 @@ func TestBankerRoundChop(t *testing.T) {
 	}
 }
 
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		d1  Dec
+		exp int64
+	}{
+		{mustNewDecFromStr(t, "0"), 0},
+		{mustNewDecFromStr(t, "0.25"), 0},
+		{mustNewDecFromStr(t, "0.75"), 0},
+		{mustNewDecFromStr(t, "1"), 1},
+		{mustNewDecFromStr(t, "1.5"), 1},
+		{mustNewDecFromStr(t, "7.5"), 7},
+		{mustNewDecFromStr(t, "7.6"), 7},
+		{mustNewDecFromStr(t, "100.999"), 100},
+	}
+
+	for tcIndex, tc := range tests {
+		resNeg := tc.d1.Neg().TruncateInt()
+		require.True(t, NewInt(-1*tc.exp).Equal(resNeg), "negative tc %d", tcIndex)
+
+		resPos := tc.d1.TruncateInt()
+		require.True(t, NewInt(tc.exp).Equal(resPos), "positive tc %d", tcIndex)
+
+		require.True(t, NewDec(tc.exp).Equal(tc.d1.TruncateDec()), "truncated dec tc %d", tcIndex)
+	}
+}
+
 func TestToLeftPadded(t *testing.T) {
 	tests := []struct {
 		dec    Dec
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"bytes"
+
+	wire "github.com/cosmos/cosmos-sdk/wire"
+)
+
+// KeyPrefixDecoder decodes the values of the keys of a store starting with
+// Prefix, given their full key, with the app codec. It lets debugging tools
+// show the values of a store.
+type KeyPrefixDecoder struct {
+	Prefix []byte
+	Decode func(cdc *wire.Codec, key, value []byte) (interface{}, error)
+}
+
+// StoreDecoders are the key prefix decoders registered by the modules of an
+// app, by store name.
+type StoreDecoders map[string][]KeyPrefixDecoder
+
+// Register registers the key prefix decoders of the store of the given name.
+func (sd StoreDecoders) Register(storeName string, decoders ...KeyPrefixDecoder) {
+	sd[storeName] = append(sd[storeName], decoders...)
+}
+
+// Decode decodes the value of a key of the store with the decoder of the
+// longest prefix of the key. It returns false if no decoder matches the key.
+func (sd StoreDecoders) Decode(cdc *wire.Codec, storeName string, key, value []byte) (interface{}, bool, error) {
+	var match *KeyPrefixDecoder
+	for i, decoder := range sd[storeName] {
+		if !bytes.HasPrefix(key, decoder.Prefix) {
+			continue
+		}
+		if match == nil || len(decoder.Prefix) > len(match.Prefix) {
+			match = &sd[storeName][i]
+		}
+	}
+	if match == nil {
+		return nil, false, nil
+	}
+
+	decoded, err := match.Decode(cdc, key, value)
+	return decoded, true, err
+}
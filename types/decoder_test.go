@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wire "github.com/cosmos/cosmos-sdk/wire"
+)
+
+func TestStoreDecoders(t *testing.T) {
+	cdc := wire.NewCodec()
+	decodeAs := func(name string) func(*wire.Codec, []byte, []byte) (interface{}, error) {
+		return func(_ *wire.Codec, _, value []byte) (interface{}, error) {
+			return name + ":" + string(value), nil
+		}
+	}
+	decoders := make(StoreDecoders)
+	decoders.Register("store1",
+		KeyPrefixDecoder{Prefix: []byte{0x01}, Decode: decodeAs("short")},
+		KeyPrefixDecoder{Prefix: []byte{0x01, 0x02}, Decode: decodeAs("long")},
+	)
+
+	cases := []struct {
+		store    string
+		key      []byte
+		ok       bool
+		expected interface{}
+	}{
+		{"store1", []byte{0x01, 0x03}, true, "short:v"},
+		{"store1", []byte{0x01, 0x02, 0x03}, true, "long:v"},
+		{"store1", []byte{0x02}, false, nil},
+		{"store2", []byte{0x01}, false, nil},
+	}
+	for i, tc := range cases {
+		decoded, ok, err := decoders.Decode(cdc, tc.store, tc.key, []byte("v"))
+		require.Nil(t, err, "case %d", i)
+		require.Equal(t, tc.ok, ok, "case %d", i)
+		require.Equal(t, tc.expected, decoded, "case %d", i)
+	}
+}
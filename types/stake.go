@@ -46,6 +46,7 @@ type Validator interface {
 	GetTokens() Dec           // validation tokens
 	GetDelegatorShares() Dec  // Total out standing delegator shares
 	GetBondHeight() int64     // height in which the validator became active
+	GetCommission() Dec       // validator commission rate
 }
 
 // validator which fulfills abci validator interface for use in Tendermint
@@ -69,6 +70,7 @@ type ValidatorSet interface {
 
 	Validator(Context, ValAddress) Validator            // get a particular validator by operator
 	ValidatorByPubKey(Context, crypto.PubKey) Validator // get a particular validator by signing PubKey
+	ValidatorByConsAddr(Context, ConsAddress) Validator // get a particular validator by consensus address
 	TotalPower(Context) Dec                             // total power of the validator set
 
 	// slash the validator and delegators of the validator, specifying offence height, offence power, and slash fraction
@@ -103,9 +105,16 @@ type DelegationSet interface {
 // validator event hooks
 // These can be utilized to communicate between a staking keeper
 // and another keeper which must take particular actions when
-// validators are bonded and unbonded. The second keeper must implement
+// validators are created, bonded, unbonded or removed, or when
+// delegations are created or modified. The second keeper must implement
 // this interface, which then the staking keeper can call.
 type ValidatorHooks interface {
+	OnValidatorCreated(ctx Context, address ValAddress)         // Must be called when a validator is created
+	OnValidatorRemoved(ctx Context, address ValAddress)         // Must be called before a validator is deleted
 	OnValidatorBonded(ctx Context, address ConsAddress)         // Must be called when a validator is bonded
 	OnValidatorBeginUnbonding(ctx Context, address ConsAddress) // Must be called when a validator begins unbonding
+
+	BeforeDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called before a delegation is created
+	BeforeDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress) // Must be called before the shares of a delegation are modified or removed
+	AfterDelegationModified(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called after a delegation is created or its shares modified
 }
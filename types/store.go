@@ -11,20 +11,79 @@ import (
 
 // NOTE: These are implemented in cosmos-sdk/store.
 
-// PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+// PruningStrategy specifies how old states will be deleted over time. The
+// KeepRecent latest versions are kept along with every KeepEvery-th version,
+// the other versions are deleted in batches every Interval blocks.
+type PruningStrategy struct {
+	KeepRecent int64
+	KeepEvery  int64
+	Interval   int64
+}
 
-const (
+var (
 	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+	PruneSyncable = NewPruningStrategy(100, 10000, 1)
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = NewPruningStrategy(0, 0, 1)
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = NewPruningStrategy(0, 1, 1)
 )
 
+// NewPruningStrategy returns a pruning strategy keeping the keepRecent latest
+// versions and every keepEvery-th version, 0 keeping none of them, and
+// deleting the other versions every interval blocks.
+func NewPruningStrategy(keepRecent, keepEvery, interval int64) PruningStrategy {
+	return PruningStrategy{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// ParsePruningStrategy returns the strategy of the given name: syncable,
+// nothing, everything, or custom for the given keepRecent, keepEvery and
+// interval settings, which must be valid.
+func ParsePruningStrategy(name string, keepRecent, keepEvery, interval int64) (PruningStrategy, error) {
+	switch name {
+	case "syncable":
+		return PruneSyncable, nil
+	case "nothing":
+		return PruneNothing, nil
+	case "everything":
+		return PruneEverything, nil
+	case "custom":
+		strategy := NewPruningStrategy(keepRecent, keepEvery, interval)
+		return strategy, strategy.ValidateCustom()
+	default:
+		return PruningStrategy{}, fmt.Errorf("invalid pruning strategy: %s", name)
+	}
+}
+
+// ValidateCustom checks the settings of a custom strategy. Unlike
+// PruneEverything, a custom strategy must keep some past versions, so the
+// previous height can still be queried with proofs.
+func (ps PruningStrategy) ValidateCustom() error {
+	if ps.KeepRecent < 0 || ps.KeepEvery < 0 {
+		return fmt.Errorf("negative number of versions to keep: keep-recent %d, keep-every %d",
+			ps.KeepRecent, ps.KeepEvery)
+	}
+	if ps.KeepRecent == 0 && ps.KeepEvery == 0 {
+		return fmt.Errorf("keep-recent and keep-every cannot both be 0, use the everything strategy to keep no past versions")
+	}
+	if ps.Interval <= 0 {
+		return fmt.Errorf("pruning interval must be positive, got %d", ps.Interval)
+	}
+	return nil
+}
+
+// KeepVersion returns true if the version is kept as a waypoint once it is no
+// longer one of the recent versions.
+func (ps PruningStrategy) KeepVersion(version int64) bool {
+	return ps.KeepEvery != 0 && version%ps.KeepEvery == 0
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -112,6 +171,82 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Load the latest persisted version, applying the store upgrades
+	// needed by the mounted stores.
+	LoadLatestVersionAndUpgrade(upgrades *StoreUpgrades) error
+
+	// Load a specific persisted version, applying the store upgrades
+	// needed by the mounted stores.
+	LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) error
+}
+
+// StoreUpgrades lists the stores an upgrade adds, renames and deletes. They
+// are applied when the multistore is loaded, and the next commit records
+// the new set of stores.
+type StoreUpgrades struct {
+	Added   []string      `json:"added"`
+	Renamed []StoreRename `json:"renamed"`
+	Deleted []string      `json:"deleted"`
+}
+
+// StoreRename renames the store OldKey to NewKey, keeping its data.
+type StoreRename struct {
+	OldKey string `json:"old_key"`
+	NewKey string `json:"new_key"`
+}
+
+// IsAdded returns true if the store of the given name is added.
+func (s *StoreUpgrades) IsAdded(name string) bool {
+	if s == nil {
+		return false
+	}
+	for _, added := range s.Added {
+		if added == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted returns true if the store of the given name is deleted.
+func (s *StoreUpgrades) IsDeleted(name string) bool {
+	if s == nil {
+		return false
+	}
+	for _, deleted := range s.Deleted {
+		if deleted == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RenamedFrom returns the old name of the store of the given name, or an
+// empty string if it isn't renamed.
+func (s *StoreUpgrades) RenamedFrom(name string) string {
+	if s == nil {
+		return ""
+	}
+	for _, rename := range s.Renamed {
+		if rename.NewKey == name {
+			return rename.OldKey
+		}
+	}
+	return ""
+}
+
+// IsRenamed returns true if the store of the given name is renamed.
+func (s *StoreUpgrades) IsRenamed(name string) bool {
+	if s == nil {
+		return false
+	}
+	for _, rename := range s.Renamed {
+		if rename.OldKey == name {
+			return true
+		}
+	}
+	return false
 }
 
 //---------subsp-------------------------------
@@ -247,6 +382,7 @@ const (
 	StoreTypeDB
 	StoreTypeIAVL
 	StoreTypeTransient
+	StoreTypeMemory
 )
 
 //----------------------------------------
@@ -329,6 +465,29 @@ func (key *TransientStoreKey) String() string {
 	return fmt.Sprintf("TransientStoreKey{%p, %s}", key, key.name)
 }
 
+// MemoryStoreKey is used for indexing memory stores in a MultiStore
+type MemoryStoreKey struct {
+	name string
+}
+
+// Constructs new MemoryStoreKey
+// Must return a pointer according to the ocap principle
+func NewMemoryStoreKey(name string) *MemoryStoreKey {
+	return &MemoryStoreKey{
+		name: name,
+	}
+}
+
+// Implements StoreKey
+func (key *MemoryStoreKey) Name() string {
+	return key.name
+}
+
+// Implements StoreKey
+func (key *MemoryStoreKey) String() string {
+	return fmt.Sprintf("MemoryStoreKey{%p, %s}", key, key.name)
+}
+
 //----------------------------------------
 
 // key-value result for iterator queries
@@ -37,3 +37,33 @@ func TestCommitID(t *testing.T) {
 	}
 	require.False(t, nonempty.IsZero())
 }
+
+func TestParsePruningStrategy(t *testing.T) {
+	var testCases = []struct {
+		name                            string
+		keepRecent, keepEvery, interval int64
+		expected                        PruningStrategy
+		expectPass                      bool
+	}{
+		{"syncable", 0, 0, 0, PruneSyncable, true},
+		{"nothing", 0, 0, 0, PruneNothing, true},
+		{"everything", 0, 0, 0, PruneEverything, true},
+		{"custom", 10, 100, 5, NewPruningStrategy(10, 100, 5), true},
+		{"custom", 0, 100, 1, NewPruningStrategy(0, 100, 1), true},
+		{"custom", 10, 0, 1, NewPruningStrategy(10, 0, 1), true},
+		{"custom", 0, 0, 1, PruningStrategy{}, false},
+		{"custom", -1, 100, 1, PruningStrategy{}, false},
+		{"custom", 10, 100, 0, PruningStrategy{}, false},
+		{"archive", 0, 0, 0, PruningStrategy{}, false},
+	}
+
+	for i, tc := range testCases {
+		strategy, err := ParsePruningStrategy(tc.name, tc.keepRecent, tc.keepEvery, tc.interval)
+		if tc.expectPass {
+			require.Nil(t, err, "test case %d", i)
+			require.Equal(t, tc.expected, strategy, "test case %d", i)
+		} else {
+			require.NotNil(t, err, "test case %d", i)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	amino "github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
+
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 )
 
 // amino codec to marshal/unmarshal
@@ -16,9 +18,10 @@ func NewCodec() *Codec {
 	return cdc
 }
 
-// Register the go-crypto to the codec
+// Register the go-crypto to the codec, along with the multisig public key
 func RegisterCrypto(cdc *Codec) {
 	cryptoAmino.RegisterAmino(cdc)
+	multisig.RegisterAmino(cdc)
 }
 
 // attempt to make some pretty json
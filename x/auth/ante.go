@@ -3,7 +3,9 @@ package auth
 import (
 	"bytes"
 	"fmt"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -18,11 +20,26 @@ const (
 	maxMemoCharacters           = 100
 )
 
+// FeeGrantKeeper uses the fee allowances granted to the fee payers of txs
+type FeeGrantKeeper interface {
+	// UseGrantedFees charges fee to the allowance granter granted to grantee,
+	// returning an error if the allowance does not cover it.
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error
+}
+
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
-// nolint: gocyclo
+// Txs whose fee is paid by a fee granter are rejected.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return NewFeeGrantAnteHandler(am, fck, nil)
+}
+
+// NewFeeGrantAnteHandler returns an AnteHandler like NewAnteHandler, which
+// deducts the fees of txs with a fee granter from the granter, within the fee
+// allowance granted to the first signer.
+// nolint: gocyclo
+func NewFeeGrantAnteHandler(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) sdk.AnteHandler {
 
 	return func(
 		ctx sdk.Context, tx sdk.Tx, simulate bool,
@@ -65,6 +82,15 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 			return newCtx, err.Result(), true
 		}
 
+		// the minimum gas prices are local to each node, so they are only
+		// enforced in CheckTx and never in DeliverTx
+		if ctx.IsCheckTx() && !simulate {
+			res := ensureSufficientMempoolFees(ctx, stdTx.Fee)
+			if !res.IsOK() {
+				return newCtx, res, true
+			}
+		}
+
 		sigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
 		signerAddrs := stdTx.GetSigners()
 		msgs := tx.GetMsgs()
@@ -87,22 +113,28 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 			signerAddr, sig := signerAddrs[i], sigs[i]
 
 			// check signature, return account with incremented nonce
-			signBytes := StdSignBytes(newCtx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
+			signBytes := StdSignBytesWithFeeGranter(newCtx.ChainID(), accNums[i], sequences[i], fee, stdTx.FeeGranter, msgs, stdTx.GetMemo())
 			signerAcc, res := processSig(newCtx, am, signerAddr, sig, signBytes, simulate)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
 
-			// first sig pays the fees
-			// TODO: Add min fees
+			// first sig pays the fees, unless they are paid by a fee granter
 			// Can this function be moved outside of the loop?
-			if i == 0 && !fee.Amount.IsZero() {
+			if i == 0 && !fee.Amount.IsZero() && stdTx.FeeGranter != nil {
 				newCtx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-				signerAcc, res = deductFees(signerAcc, fee)
+				res = deductGrantedFees(newCtx, am, fgk, stdTx.FeeGranter, signerAddr, fee)
 				if !res.IsOK() {
 					return newCtx, res, true
 				}
-				fck.addCollectedFees(newCtx, fee.Amount)
+				fck.AddCollectedFees(newCtx, fee.Amount)
+			} else if i == 0 && !fee.Amount.IsZero() {
+				newCtx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
+				signerAcc, res = deductFees(newCtx.BlockHeader().Time, signerAcc, fee)
+				if !res.IsOK() {
+					return newCtx, res, true
+				}
+				fck.AddCollectedFees(newCtx, fee.Amount)
 			}
 
 			// Save the account.
@@ -182,7 +214,7 @@ func processSig(
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
 
-	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
+	consumeSignatureVerificationGas(ctx.GasMeter(), sig.Signature, pubKey)
 	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -217,29 +249,113 @@ func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey,
 	return pubKey, sdk.Result{}
 }
 
-func consumeSignatureVerificationGas(meter sdk.GasMeter, pubkey crypto.PubKey) {
-	switch pubkey.(type) {
+// consume the gas to verify sig, multisignatures are charged for each of
+// their sub-signatures
+func consumeSignatureVerificationGas(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey) {
+	switch pubkey := pubkey.(type) {
 	case ed25519.PubKeyEd25519:
 		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
 	case secp256k1.PubKeySecp256k1:
 		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
+	case multisig.PubKeyMultisigThreshold:
+		var multisignature multisig.Multisignature
+		err := msgCdc.UnmarshalBinaryBare(sig, &multisignature)
+		if err != nil || multisignature.BitArray.ValidateSize(len(pubkey.PubKeys)) != nil {
+			// charge for every key when the signature is unknown, i.e. when
+			// simulating
+			for _, subKey := range pubkey.PubKeys {
+				consumeSignatureVerificationGas(meter, nil, subKey)
+			}
+			return
+		}
+		consumeMultisignatureVerificationGas(meter, multisignature, pubkey)
 	default:
 		panic("Unrecognized signature type")
 	}
 }
 
+func consumeMultisignatureVerificationGas(meter sdk.GasMeter,
+	sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold) {
+
+	size := sig.BitArray.Size()
+	sigIndex := 0
+	for i := 0; i < size && i < len(pubkey.PubKeys) && sigIndex < len(sig.Sigs); i++ {
+		if sig.BitArray.GetIndex(i) {
+			consumeSignatureVerificationGas(meter, sig.Sigs[sigIndex], pubkey.PubKeys[i])
+			sigIndex++
+		}
+	}
+}
+
+// ensureSufficientMempoolFees checks that the fee pays at least the node's
+// minimum gas price for the requested gas in one of the denoms it accepts.
+// Any fee is sufficient if no minimum gas prices are set.
+func ensureSufficientMempoolFees(ctx sdk.Context, fee StdFee) sdk.Result {
+	minGasPrices := ctx.MinGasPrices()
+	if minGasPrices.IsZero() {
+		return sdk.Result{}
+	}
+
+	gas := sdk.NewDec(fee.Gas)
+	for _, gasPrice := range minGasPrices {
+		requiredFee := gasPrice.Amount.Mul(gas)
+		if sdk.NewDecFromInt(fee.Amount.AmountOf(gasPrice.Denom)).GTE(requiredFee) {
+			return sdk.Result{}
+		}
+	}
+
+	return sdk.ErrInsufficientFee(fmt.Sprintf(
+		"insufficient fee, got: %q, required gas prices: %q for %d gas", fee.Amount, minGasPrices, fee.Gas)).Result()
+}
+
+// Deduct the fee from the account of the fee granter, within the allowance it
+// granted to the fee payer.
+func deductGrantedFees(ctx sdk.Context, am AccountMapper, fgk FeeGrantKeeper,
+	granter, grantee sdk.AccAddress, fee StdFee) sdk.Result {
+
+	if fgk == nil {
+		return sdk.ErrUnauthorized("fee grants are not supported").Result()
+	}
+	if bytes.Equal(granter, grantee) {
+		return sdk.ErrUnauthorized("fee granter must not be the fee payer").Result()
+	}
+
+	granterAcc := am.GetAccount(ctx, granter)
+	if granterAcc == nil {
+		return sdk.ErrUnknownAddress(granter.String()).Result()
+	}
+
+	granterAcc, res := deductFees(ctx.BlockHeader().Time, granterAcc, fee)
+	if !res.IsOK() {
+		return res
+	}
+
+	// only use the allowance once the granter is known to cover the fee
+	err := fgk.UseGrantedFees(ctx, granter, grantee, fee.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	am.SetAccount(ctx, granterAcc)
+	return sdk.Result{}
+}
+
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountMapper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+func deductFees(blockTime time.Time, acc Account, fee StdFee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
-	newCoins := coins.Minus(feeAmount)
-	if !newCoins.IsNotNegative() {
-		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
+	// vesting coins cannot pay fees
+	spendableCoins := coins
+	if vacc, ok := acc.(VestingAccount); ok {
+		spendableCoins = vacc.SpendableCoins(blockTime)
+	}
+	if !spendableCoins.Minus(feeAmount).IsNotNegative() {
+		errMsg := fmt.Sprintf("%s < %s", spendableCoins, feeAmount)
 		return nil, sdk.ErrInsufficientFunds(errMsg).Result()
 	}
+	newCoins := coins.Minus(feeAmount)
 	err := acc.SetCoins(newCoins)
 	if err != nil {
 		// Handle w/ #870
@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/stretchr/testify/require"
@@ -92,6 +94,21 @@ func newTestTxWithMemo(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey,
 	return tx
 }
 
+func newTestTxWithFeeGranter(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, feeGranter sdk.AccAddress) sdk.Tx {
+	sigs := make([]StdSignature, len(privs))
+	for i, priv := range privs {
+		signBytes := StdSignBytesWithFeeGranter(ctx.ChainID(), accNums[i], seqs[i], fee, feeGranter, msgs, "")
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
+	}
+	tx := NewStdTx(msgs, fee, sigs, "")
+	tx.FeeGranter = feeGranter
+	return tx
+}
+
 // All signers sign over the same StdSignDoc. Should always create invalid signatures
 func newTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, signBytes []byte, memo string) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
@@ -338,6 +355,138 @@ func TestAnteHandlerFees(t *testing.T) {
 	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewInt64Coin("atom", 150)}))
 }
 
+// testFeeGrantKeeper grants a single allowance of the fees left to spend
+type testFeeGrantKeeper struct {
+	granter, grantee sdk.AccAddress
+	left             sdk.Coins
+}
+
+func (k *testFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	if !bytes.Equal(granter, k.granter) || !bytes.Equal(grantee, k.grantee) {
+		return sdk.ErrUnauthorized("no fee allowance")
+	}
+	left := k.left.Minus(fee)
+	if !left.IsNotNegative() {
+		return sdk.ErrUnauthorized("fee allowance exceeded")
+	}
+	k.left = left
+	return nil
+}
+
+// Test logic around fees paid by a fee granter.
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	_, addr2 := privAndAddr()
+
+	// set the accounts, the grantee cannot pay any fee
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{sdk.NewInt64Coin("atom", 200)})
+	mapper.SetAccount(ctx, acc2)
+
+	feeGrantKeeper := &testFeeGrantKeeper{granter: addr2, grantee: addr1, left: sdk.Coins{sdk.NewInt64Coin("atom", 300)}}
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+	msgs := []sdk.Msg{msg}
+
+	// fee grants are rejected without a fee grant keeper
+	tx = newTestTxWithFeeGranter(ctx, msgs, privs, accnums, seqs, fee, addr2)
+	checkInvalidTx(t, NewAnteHandler(mapper, feeCollector), ctx, tx, false, sdk.CodeUnauthorized)
+
+	anteHandler := NewFeeGrantAnteHandler(mapper, feeCollector, feeGrantKeeper)
+
+	// the fee granter is part of the sign bytes
+	stdTx := newTestTx(ctx, msgs, privs, accnums, seqs, fee).(StdTx)
+	stdTx.FeeGranter = addr2
+	checkInvalidTx(t, anteHandler, ctx, stdTx, false, sdk.CodeUnauthorized)
+
+	// the granter pays the fee within the allowance
+	tx = newTestTxWithFeeGranter(ctx, msgs, privs, accnums, seqs, fee, addr2)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 50)}, mapper.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 150)}, feeGrantKeeper.left)
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewInt64Coin("atom", 150)}))
+
+	// the granter does not have enough funds left, and the allowance is untouched
+	seqs = []int64{1}
+	tx = newTestTxWithFeeGranter(ctx, msgs, privs, accnums, seqs, fee, addr2)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeInsufficientFunds)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 150)}, feeGrantKeeper.left)
+
+	// the allowance does not cover the fee
+	acc2 = mapper.GetAccount(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{sdk.NewInt64Coin("atom", 1000)})
+	mapper.SetAccount(ctx, acc2)
+	feeGrantKeeper.left = sdk.Coins{sdk.NewInt64Coin("atom", 100)}
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 1000)}, mapper.GetAccount(ctx, addr2).GetCoins())
+
+	// the fee payer cannot be its own fee granter
+	tx = newTestTxWithFeeGranter(ctx, msgs, privs, accnums, seqs, fee, addr1)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+}
+
+// Test logic around the minimum gas prices of CheckTx.
+func TestAnteHandlerMinGasPrices(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	checkCtx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, true, log.NewNopLogger())
+	deliverCtx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(checkCtx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(checkCtx, acc1)
+
+	// the fee pays 150atom for 5000 gas, i.e. a gas price of 0.03atom
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	tx := newTestTx(checkCtx, msgs, privs, accnums, seqs, newStdFee())
+
+	// the gas price is below the minimum
+	minGasPrices, err := sdk.ParseDecCoins("0.031atom")
+	require.NoError(t, err)
+	checkInvalidTx(t, anteHandler, checkCtx.WithMinGasPrices(minGasPrices), tx, false, sdk.CodeInsufficientFee)
+
+	// the minimum is ignored when simulating and in DeliverTx
+	simulateCtx, _ := checkCtx.CacheContext()
+	checkValidTx(t, anteHandler, simulateCtx.WithMinGasPrices(minGasPrices), tx, true)
+	checkValidTx(t, anteHandler, deliverCtx.WithMinGasPrices(minGasPrices), tx, false)
+
+	// the fee must be paid in one of the accepted denoms
+	seqs = []int64{1}
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, newStdFee())
+	minGasPrices, err = sdk.ParseDecCoins("0.01steak")
+	require.NoError(t, err)
+	checkInvalidTx(t, anteHandler, checkCtx.WithMinGasPrices(minGasPrices), tx, false, sdk.CodeInsufficientFee)
+
+	minGasPrices, err = sdk.ParseDecCoins("0.01steak,0.03atom")
+	require.NoError(t, err)
+	checkValidTx(t, anteHandler, checkCtx.WithMinGasPrices(minGasPrices), tx, false)
+}
+
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	// setup
@@ -602,8 +751,23 @@ func TestProcessPubKey(t *testing.T) {
 }
 
 func TestConsumeSignatureVerificationGas(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	priv1, priv2 := ed25519.GenPrivKey(), secp256k1.GenPrivKey()
+	pubkeys := []crypto.PubKey{priv1.PubKey(), priv2.PubKey(), ed25519.GenPrivKey().PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := multisig.NewMultisig(len(pubkeys))
+	sig1, _ := priv1.Sign(msg)
+	sig2, _ := priv2.Sign(msg)
+	multisignature.AddSignature(sig1, 0)
+	multisignature.AddSignature(sig2, 1)
+	malformed := &multisig.Multisignature{
+		BitArray: &multisig.CompactBitArray{ExtraBitsStored: 200, Elems: []byte{0xff}},
+		Sigs:     [][]byte{sig1, sig2},
+	}
+
 	type args struct {
 		meter  sdk.GasMeter
+		sig    []byte
 		pubkey crypto.PubKey
 	}
 	tests := []struct {
@@ -612,16 +776,19 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		gasConsumed int64
 		wantPanic   bool
 	}{
-		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
-		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
-		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil}, 0, true},
+		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
+		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
+		{"Multisig", args{sdk.NewInfiniteGasMeter(), multisignature.Marshal(), multisigKey}, ed25519VerifyCost + secp256k1VerifyCost, false},
+		{"Multisig unknown signature", args{sdk.NewInfiniteGasMeter(), nil, multisigKey}, 2*ed25519VerifyCost + secp256k1VerifyCost, false},
+		{"Multisig malformed bit array", args{sdk.NewInfiniteGasMeter(), malformed.Marshal(), multisigKey}, 2*ed25519VerifyCost + secp256k1VerifyCost, false},
+		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil, nil}, 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantPanic {
-				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey) })
+				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey) })
 			} else {
-				consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey)
+				consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey)
 				require.Equal(t, tt.args.meter.GasConsumed(), tt.gasConsumed)
 			}
 		})
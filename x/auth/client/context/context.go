@@ -20,6 +20,7 @@ type TxContext struct {
 	ChainID       string
 	Memo          string
 	Fee           string
+	FeeGranter    string
 }
 
 // NewTxContextFromCLI returns a new initialized TxContext with parameters from
@@ -41,6 +42,7 @@ func NewTxContextFromCLI() TxContext {
 		Sequence:      viper.GetInt64(client.FlagSequence),
 		Fee:           viper.GetString(client.FlagFee),
 		Memo:          viper.GetString(client.FlagMemo),
+		FeeGranter:    viper.GetString(client.FlagFeeGranter),
 	}
 }
 
@@ -80,6 +82,12 @@ func (ctx TxContext) WithMemo(memo string) TxContext {
 	return ctx
 }
 
+// WithFeeGranter returns a copy of the context with an updated fee granter.
+func (ctx TxContext) WithFeeGranter(feeGranter string) TxContext {
+	ctx.FeeGranter = feeGranter
+	return ctx
+}
+
 // WithAccountNumber returns a copy of the context with an account number.
 func (ctx TxContext) WithAccountNumber(accnum int64) TxContext {
 	ctx.AccountNumber = accnum
@@ -87,7 +95,8 @@ func (ctx TxContext) WithAccountNumber(accnum int64) TxContext {
 }
 
 // Build builds a single message to be signed from a TxContext given a set of
-// messages. It returns an error if a fee is supplied but cannot be parsed.
+// messages. It returns an error if a fee or a fee granter is supplied but
+// cannot be parsed.
 func (ctx TxContext) Build(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 	chainID := ctx.ChainID
 	if chainID == "" {
@@ -104,6 +113,16 @@ func (ctx TxContext) Build(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 		fee = parsedFee
 	}
 
+	var feeGranter sdk.AccAddress
+	if ctx.FeeGranter != "" {
+		parsedFeeGranter, err := sdk.AccAddressFromBech32(ctx.FeeGranter)
+		if err != nil {
+			return auth.StdSignMsg{}, err
+		}
+
+		feeGranter = parsedFeeGranter
+	}
+
 	return auth.StdSignMsg{
 		ChainID:       ctx.ChainID,
 		AccountNumber: ctx.AccountNumber,
@@ -111,30 +130,43 @@ func (ctx TxContext) Build(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 		Memo:          ctx.Memo,
 		Msgs:          msgs,
 		Fee:           auth.NewStdFee(ctx.Gas, fee),
+		FeeGranter:    feeGranter,
 	}, nil
 }
 
 // Sign signs a transaction given a name, passphrase, and a single message to
 // signed. An error is returned if signing fails.
 func (ctx TxContext) Sign(name, passphrase string, msg auth.StdSignMsg) ([]byte, error) {
-	keybase, err := keys.GetKeyBase()
+	sig, err := ctx.MakeSignature(name, passphrase, msg)
 	if err != nil {
 		return nil, err
 	}
 
+	stdTx := auth.NewStdTx(msg.Msgs, msg.Fee, []auth.StdSignature{sig}, msg.Memo)
+	stdTx.FeeGranter = msg.FeeGranter
+	return ctx.Codec.MarshalBinary(stdTx)
+}
+
+// MakeSignature signs a single message given a name and a passphrase, and
+// returns the signature without building a transaction. It lets signatures be
+// collected offline, e.g. from the members of a multisig key.
+func (ctx TxContext) MakeSignature(name, passphrase string, msg auth.StdSignMsg) (auth.StdSignature, error) {
+	keybase, err := keys.GetKeyBase()
+	if err != nil {
+		return auth.StdSignature{}, err
+	}
+
 	sig, pubkey, err := keybase.Sign(name, passphrase, msg.Bytes())
 	if err != nil {
-		return nil, err
+		return auth.StdSignature{}, err
 	}
 
-	sigs := []auth.StdSignature{{
+	return auth.StdSignature{
 		AccountNumber: msg.AccountNumber,
 		Sequence:      msg.Sequence,
 		PubKey:        pubkey,
 		Signature:     sig,
-	}}
-
-	return ctx.Codec.MarshalBinary(auth.NewStdTx(msg.Msgs, msg.Fee, sigs, msg.Memo))
+	}, nil
 }
 
 // BuildAndSign builds a single message to be signed, and signs a transaction
@@ -175,5 +207,7 @@ func (ctx TxContext) BuildWithPubKey(name string, msgs []sdk.Msg) ([]byte, error
 		PubKey:        info.GetPubKey(),
 	}}
 
-	return ctx.Codec.MarshalBinary(auth.NewStdTx(msg.Msgs, msg.Fee, sigs, msg.Memo))
+	stdTx := auth.NewStdTx(msg.Msgs, msg.Fee, sigs, msg.Memo)
+	stdTx.FeeGranter = msg.FeeGranter
+	return ctx.Codec.MarshalBinary(stdTx)
 }
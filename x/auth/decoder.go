@@ -0,0 +1,28 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// StoreDecoders returns the decoders of the values of the account store.
+func StoreDecoders() []sdk.KeyPrefixDecoder {
+	return []sdk.KeyPrefixDecoder{
+		{
+			Prefix: []byte("account:"),
+			Decode: func(cdc *wire.Codec, _, value []byte) (interface{}, error) {
+				var acc Account
+				err := cdc.UnmarshalBinaryBare(value, &acc)
+				return acc, err
+			},
+		},
+		{
+			Prefix: globalAccountNumberKey,
+			Decode: func(cdc *wire.Codec, _, value []byte) (interface{}, error) {
+				var accNumber int64
+				err := cdc.UnmarshalBinary(value, &accNumber)
+				return accNumber, err
+			},
+		},
+	}
+}
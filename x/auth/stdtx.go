@@ -12,11 +12,14 @@ var _ sdk.Tx = (*StdTx)(nil)
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
 // NOTE: the first signature is the FeePayer (Signatures must not be nil).
+// If FeeGranter is set, the fee is paid by the granter instead, within the fee
+// allowance it granted to the FeePayer.
 type StdTx struct {
 	Msgs       []sdk.Msg      `json:"msg"`
 	Fee        StdFee         `json:"fee"`
 	Signatures []StdSignature `json:"signatures"`
 	Memo       string         `json:"memo"`
+	FeeGranter sdk.AccAddress `json:"fee_granter,omitempty"`
 }
 
 func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string) StdTx {
@@ -110,10 +113,13 @@ func (fee StdFee) Bytes() []byte {
 // as well as the ChainID (prevent cross chain replay)
 // and the Sequence numbers for each signature (prevent
 // inchain replay and enforce tx ordering per account).
+// The fee granter is omitted when the fee is paid by the FeePayer, so that
+// the sign bytes of such txs are unchanged.
 type StdSignDoc struct {
 	AccountNumber int64             `json:"account_number"`
 	ChainID       string            `json:"chain_id"`
 	Fee           json.RawMessage   `json:"fee"`
+	FeeGranter    sdk.AccAddress    `json:"fee_granter,omitempty"`
 	Memo          string            `json:"memo"`
 	Msgs          []json.RawMessage `json:"msgs"`
 	Sequence      int64             `json:"sequence"`
@@ -121,6 +127,12 @@ type StdSignDoc struct {
 
 // StdSignBytes returns the bytes to sign for a transaction.
 func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs []sdk.Msg, memo string) []byte {
+	return StdSignBytesWithFeeGranter(chainID, accnum, sequence, fee, nil, msgs, memo)
+}
+
+// StdSignBytesWithFeeGranter returns the bytes to sign for a transaction
+// whose fee is paid by feeGranter.
+func StdSignBytesWithFeeGranter(chainID string, accnum int64, sequence int64, fee StdFee, feeGranter sdk.AccAddress, msgs []sdk.Msg, memo string) []byte {
 	var msgsBytes []json.RawMessage
 	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
@@ -129,6 +141,7 @@ func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs
 		AccountNumber: accnum,
 		ChainID:       chainID,
 		Fee:           json.RawMessage(fee.Bytes()),
+		FeeGranter:    feeGranter,
 		Memo:          memo,
 		Msgs:          msgsBytes,
 		Sequence:      sequence,
@@ -143,17 +156,18 @@ func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs
 // a Msg with the other requirements for a StdSignDoc before
 // it is signed. For use in the CLI.
 type StdSignMsg struct {
-	ChainID       string    `json:"chain_id"`
-	AccountNumber int64     `json:"account_number"`
-	Sequence      int64     `json:"sequence"`
-	Fee           StdFee    `json:"fee"`
-	Msgs          []sdk.Msg `json:"msgs"`
-	Memo          string    `json:"memo"`
+	ChainID       string         `json:"chain_id"`
+	AccountNumber int64          `json:"account_number"`
+	Sequence      int64          `json:"sequence"`
+	Fee           StdFee         `json:"fee"`
+	Msgs          []sdk.Msg      `json:"msgs"`
+	Memo          string         `json:"memo"`
+	FeeGranter    sdk.AccAddress `json:"fee_granter,omitempty"`
 }
 
 // get message bytes
 func (msg StdSignMsg) Bytes() []byte {
-	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+	return StdSignBytesWithFeeGranter(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.FeeGranter, msg.Msgs, msg.Memo)
 }
 
 // Standard Signature
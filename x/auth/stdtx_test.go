@@ -31,12 +31,12 @@ func TestStdSignBytes(t *testing.T) {
 	msgs := []sdk.Msg{sdk.NewTestMsg(addr)}
 	fee := newStdFee()
 	signMsg := StdSignMsg{
-		"1234",
-		3,
-		6,
-		fee,
-		msgs,
-		"memo",
+		ChainID:       "1234",
+		AccountNumber: 3,
+		Sequence:      6,
+		Fee:           fee,
+		Msgs:          msgs,
+		Memo:          "memo",
 	}
 	require.Equal(t, fmt.Sprintf("{\"account_number\":\"3\",\"chain_id\":\"1234\",\"fee\":{\"amount\":[{\"amount\":\"150\",\"denom\":\"atom\"}],\"gas\":\"5000\"},\"memo\":\"memo\",\"msgs\":[[\"%s\"]],\"sequence\":\"6\"}", addr), string(signMsg.Bytes()))
 }
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingAccount is an account holding coins which vest over time. Only the
+// vested coins can be spent, while all of the coins can be delegated.
+type VestingAccount interface {
+	Account
+
+	// coins which can be spent at the given time
+	SpendableCoins(blockTime time.Time) sdk.Coins
+
+	// account for the delegation of an amount, vesting coins are delegated
+	// before the vested ones
+	TrackDelegation(blockTime time.Time, amount sdk.Coins)
+
+	// account for the undelegation of an amount, vested coins are undelegated
+	// before the vesting ones
+	TrackUndelegation(amount sdk.Coins)
+
+	GetVestedCoins(blockTime time.Time) sdk.Coins
+	GetVestingCoins(blockTime time.Time) sdk.Coins
+
+	GetStartTime() int64
+	GetEndTime() int64
+}
+
+//-----------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount implements the bookkeeping common to all vesting
+// accounts. The delegated coins are tracked separately for the vesting and the
+// free (vested) coins as they are no longer held by the account.
+type BaseVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // coins initially vesting
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // vested coins delegated
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // vesting coins delegated
+	EndTime          int64     `json:"end_time"`          // unix time at which all coins are vested
+}
+
+// coins which can be spent given the coins still vesting. Delegated vesting
+// coins count against the vesting amount, as the vesting coins are the first
+// to be delegated.
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendableCoins sdk.Coins
+	for _, coin := range bva.Coins {
+		// min(balance + delegated vesting - vesting, balance)
+		amount := coin.Amount.Add(bva.DelegatedVesting.AmountOf(coin.Denom)).Sub(vestingCoins.AmountOf(coin.Denom))
+		amount = sdk.MinInt(amount, coin.Amount)
+		if amount.Sign() > 0 {
+			spendableCoins = spendableCoins.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, amount)})
+		}
+	}
+	return spendableCoins
+}
+
+// split a delegation between the vesting coins which are not yet delegated
+// and the free coins
+func (bva *BaseVestingAccount) trackDelegation(vestingCoins, amount sdk.Coins) {
+	for _, coin := range amount {
+		// min(max(vesting - delegated vesting, 0), delegation)
+		vesting := vestingCoins.AmountOf(coin.Denom).Sub(bva.DelegatedVesting.AmountOf(coin.Denom))
+		if vesting.Sign() < 0 {
+			vesting = sdk.ZeroInt()
+		}
+		vesting = sdk.MinInt(vesting, coin.Amount)
+		free := coin.Amount.Sub(vesting)
+
+		if vesting.Sign() > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, vesting)})
+		}
+		if free.Sign() > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, free)})
+		}
+	}
+}
+
+// Implements VestingAccount.
+func (bva *BaseVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	for _, coin := range amount {
+		// the free coins are undelegated first, then the vesting ones
+		free := sdk.MinInt(bva.DelegatedFree.AmountOf(coin.Denom), coin.Amount)
+		vesting := sdk.MinInt(bva.DelegatedVesting.AmountOf(coin.Denom), coin.Amount.Sub(free))
+
+		if free.Sign() > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, free)})
+		}
+		if vesting.Sign() > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, vesting)})
+		}
+	}
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetEndTime() int64 {
+	return bva.EndTime
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount vests its coins linearly between its start and
+// end time.
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64 `json:"start_time"` // unix time at which the coins start vesting
+}
+
+// NewContinuousVestingAccount creates an account vesting all of its coins
+// between startTime and endTime.
+func NewContinuousVestingAccount(addr sdk.AccAddress, coins sdk.Coins, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     BaseAccount{Address: addr, Coins: coins},
+			OriginalVesting: coins,
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	now := blockTime.Unix()
+	if now <= cva.StartTime {
+		return nil
+	}
+	if now >= cva.EndTime {
+		return cva.OriginalVesting
+	}
+
+	var vestedCoins sdk.Coins
+	elapsed, duration := sdk.NewInt(now-cva.StartTime), sdk.NewInt(cva.EndTime-cva.StartTime)
+	for _, coin := range cva.OriginalVesting {
+		amount := coin.Amount.Mul(elapsed).Div(duration)
+		if amount.Sign() > 0 {
+			vestedCoins = vestedCoins.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, amount)})
+		}
+	}
+	return vestedCoins
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return cva.OriginalVesting.Minus(cva.GetVestedCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amount)
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount vests all of its coins at once at its end time.
+type DelayedVestingAccount struct {
+	BaseVestingAccount
+}
+
+// NewDelayedVestingAccount creates an account vesting all of its coins at
+// endTime.
+func NewDelayedVestingAccount(addr sdk.AccAddress, coins sdk.Coins, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     BaseAccount{Address: addr, Coins: coins},
+			OriginalVesting: coins,
+			EndTime:         endTime,
+		},
+	}
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if blockTime.Unix() >= dva.EndTime {
+		return dva.OriginalVesting
+	}
+	return nil
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return dva.OriginalVesting.Minus(dva.GetVestedCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	dva.trackDelegation(dva.GetVestingCoins(blockTime), amount)
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	vestingCoins = sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)}
+)
+
+func TestContinuousVestingAccountVesting(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	acc := NewContinuousVestingAccount(addr, vestingCoins, 100, 200)
+
+	// nothing is vested before the start time
+	require.True(t, acc.GetVestedCoins(time.Unix(100, 0)).IsZero())
+	require.True(t, vestingCoins.IsEqual(acc.GetVestingCoins(time.Unix(100, 0))))
+	require.True(t, acc.SpendableCoins(time.Unix(100, 0)).IsZero())
+
+	// coins vest linearly
+	vested := sdk.Coins{sdk.NewInt64Coin("fee", 250), sdk.NewInt64Coin("steak", 25)}
+	require.True(t, vested.IsEqual(acc.GetVestedCoins(time.Unix(125, 0))))
+	require.True(t, vested.IsEqual(acc.SpendableCoins(time.Unix(125, 0))))
+
+	// everything is vested after the end time
+	require.True(t, vestingCoins.IsEqual(acc.GetVestedCoins(time.Unix(300, 0))))
+	require.True(t, acc.GetVestingCoins(time.Unix(300, 0)).IsZero())
+	require.True(t, vestingCoins.IsEqual(acc.SpendableCoins(time.Unix(300, 0))))
+}
+
+func TestDelayedVestingAccountVesting(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	acc := NewDelayedVestingAccount(addr, vestingCoins, 200)
+
+	// nothing is vested before the end time
+	require.True(t, acc.GetVestedCoins(time.Unix(199, 0)).IsZero())
+	require.True(t, acc.SpendableCoins(time.Unix(199, 0)).IsZero())
+
+	// everything is vested at the end time
+	require.True(t, vestingCoins.IsEqual(acc.GetVestedCoins(time.Unix(200, 0))))
+	require.True(t, vestingCoins.IsEqual(acc.SpendableCoins(time.Unix(200, 0))))
+}
+
+func TestVestingAccountTrackDelegation(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	steak := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("steak", amount)} }
+
+	// half of the coins are vested
+	acc := NewContinuousVestingAccount(addr, steak(100), 100, 200)
+	now := time.Unix(150, 0)
+
+	// the vesting coins are delegated first
+	acc.TrackDelegation(now, steak(30))
+	require.True(t, steak(30).IsEqual(acc.DelegatedVesting))
+	require.True(t, acc.DelegatedFree.IsZero())
+	acc.TrackDelegation(now, steak(30))
+	require.True(t, steak(50).IsEqual(acc.DelegatedVesting))
+	require.True(t, steak(10).IsEqual(acc.DelegatedFree))
+
+	// the free coins are undelegated first
+	acc.TrackUndelegation(steak(20))
+	require.True(t, steak(40).IsEqual(acc.DelegatedVesting))
+	require.True(t, acc.DelegatedFree.IsZero())
+
+	// the delegated vesting coins unlock the vested ones
+	acc.Coins = steak(60)
+	require.True(t, steak(50).IsEqual(acc.SpendableCoins(now)))
+
+	// undelegating more than delegated clears the delegation
+	acc.TrackUndelegation(steak(100))
+	require.True(t, acc.DelegatedVesting.IsZero())
+	require.True(t, acc.DelegatedFree.IsZero())
+}
@@ -0,0 +1,104 @@
+package authz
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// Authorization authorizes a grantee to execute the messages of MsgType on
+// behalf of the granter, until Expiration if it is set. If SpendLimit is set,
+// the messages can spend at most SpendLimit of the granter's coins.
+type Authorization struct {
+	MsgType    string    `json:"msg_type"`
+	Expiration time.Time `json:"expiration"`
+	SpendLimit sdk.Coins `json:"spend_limit"`
+}
+
+// NewAuthorization returns an authorization to execute msgType messages,
+// which never expires if expiration is the zero time and is not limited in
+// spending if spendLimit is empty.
+func NewAuthorization(msgType string, expiration time.Time, spendLimit sdk.Coins) Authorization {
+	return Authorization{
+		MsgType:    msgType,
+		Expiration: expiration,
+		SpendLimit: spendLimit,
+	}
+}
+
+// MsgTypeName returns the name authorizations use for the type of msg, made
+// of its route and Go type name, e.g. "gov/MsgVote" or "stake/MsgDelegate".
+func MsgTypeName(msg sdk.Msg) string {
+	return fmt.Sprintf("%s/%s", msg.Type(), reflect.Indirect(reflect.ValueOf(msg)).Type().Name())
+}
+
+// the types of the messages whose spending can be limited
+var spendableMsgTypes = map[string]bool{
+	MsgTypeName(bank.MsgSend{}):          true,
+	MsgTypeName(stake.MsgDelegate{}):     true,
+	MsgTypeName(gov.MsgSubmitProposal{}): true,
+	MsgTypeName(gov.MsgDeposit{}):        true,
+}
+
+// spentCoins returns the coins of granter spent by msg
+func spentCoins(granter sdk.AccAddress, msg sdk.Msg) sdk.Coins {
+	switch msg := msg.(type) {
+	case bank.MsgSend:
+		var spent sdk.Coins
+		for _, in := range msg.Inputs {
+			if in.Address.Equals(granter) {
+				spent = spent.Plus(in.Coins)
+			}
+		}
+		return spent
+	case stake.MsgDelegate:
+		return sdk.Coins{msg.Delegation}
+	case gov.MsgSubmitProposal:
+		return msg.InitialDeposit
+	case gov.MsgDeposit:
+		return msg.Amount
+	default:
+		return nil
+	}
+}
+
+// Accept checks whether granter authorized msg at blockTime. It returns the
+// authorization updated for what msg spends, and whether it is used up or
+// expired and must be removed.
+func (a Authorization) Accept(granter sdk.AccAddress, msg sdk.Msg, blockTime time.Time) (updated Authorization, remove bool, err sdk.Error) {
+	if !a.Expiration.IsZero() && !blockTime.Before(a.Expiration) {
+		return a, true, ErrAuthorizationExpired(DefaultCodespace)
+	}
+
+	if a.SpendLimit.IsZero() {
+		return a, false, nil
+	}
+
+	spent := spentCoins(granter, msg)
+	left := a.SpendLimit.Minus(spent)
+	if !left.IsNotNegative() {
+		return a, false, ErrSpendLimitExceeded(DefaultCodespace,
+			fmt.Sprintf("%v exceeds the spend limit %v", spent, a.SpendLimit))
+	}
+	a.SpendLimit = left
+	return a, left.IsZero(), nil
+}
+
+// ValidateBasic performs a stateless validity check of the authorization
+func (a Authorization) ValidateBasic() sdk.Error {
+	if len(a.MsgType) == 0 {
+		return ErrInvalidGrant(DefaultCodespace, "missing message type")
+	}
+	if !a.SpendLimit.IsValid() {
+		return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("invalid spend limit %v", a.SpendLimit))
+	}
+	if !a.SpendLimit.IsZero() && !spendableMsgTypes[a.MsgType] {
+		return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("spending of %s messages cannot be limited", a.MsgType))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// GetCmdQueryAuthorization implements the query authorization command.
+func GetCmdQueryAuthorization(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authorization [granter] [grantee] [msg-type]",
+		Short: "Query the authorization of an account to execute messages of a type",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			params := authz.QueryAuthorizationParams{
+				Granter: granter,
+				Grantee: grantee,
+				MsgType: args[2],
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, authz.QueryAuthorization), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryGrants implements the query grants command.
+func GetCmdQueryGrants(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants [granter] [grantee]",
+		Short: "Query all the authorizations a granter granted to an account",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			params := authz.QueryGrantsParams{
+				Granter: granter,
+				Grantee: grantee,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, authz.QueryGrants), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
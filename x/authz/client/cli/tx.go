@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+const (
+	flagSpendLimit = "spend-limit"
+	flagExpiration = "expiration"
+)
+
+// GetCmdGrant implements the grant authorization command.
+func GetCmdGrant(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [msg-type]",
+		Short: "Authorize an account to execute messages on behalf of the sender",
+		Long: `Authorize the grantee to execute the messages of the given type, such as
+gov/MsgVote or stake/MsgDelegate, on behalf of the sender, until --expiration
+if it is set. The coins the messages can spend are limited to --spend-limit if
+it is set, which is supported by bank/MsgSend, stake/MsgDelegate,
+gov/MsgSubmitProposal and gov/MsgDeposit.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			spendLimit, err := sdk.ParseCoins(viper.GetString(flagSpendLimit))
+			if err != nil {
+				return err
+			}
+
+			var expiration time.Time
+			if expirationStr := viper.GetString(flagExpiration); expirationStr != "" {
+				expiration, err = time.Parse(time.RFC3339, expirationStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			authorization := authz.NewAuthorization(args[1], expiration.UTC(), spendLimit)
+			msg := authz.NewMsgGrant(granter, grantee, authorization)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagSpendLimit, "", "Maximum coins the messages can spend in total")
+	cmd.Flags().String(flagExpiration, "", "Time the authorization expires at, in RFC3339 format")
+
+	return cmd
+}
+
+// GetCmdRevoke implements the revoke authorization command.
+func GetCmdRevoke(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [grantee] [msg-type]",
+		Short: "Revoke the authorization of an account to execute messages of a type",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevoke(granter, grantee, args[1])
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdExec implements the exec command.
+func GetCmdExec(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [file]",
+		Short: "Execute messages on behalf of the accounts which authorized the sender",
+		Long: `Execute the messages of the transaction generated with --generate-only in the
+given file on behalf of their signers, which must have authorized the sender to
+execute them with 'grant'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			grantee, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var stdTx auth.StdTx
+			err = cdc.UnmarshalJSON(bz, &stdTx)
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgExec(grantee, stdTx.GetMsgs())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,42 @@
+//nolint
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default authz codespace
+	DefaultCodespace sdk.CodespaceType = 9
+
+	CodeInvalidGrant         CodeType = 101
+	CodeNoAuthorization      CodeType = 102
+	CodeAuthorizationExpired CodeType = 103
+	CodeSpendLimitExceeded   CodeType = 104
+	CodeInvalidExec          CodeType = 105
+)
+
+func ErrInvalidGrant(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGrant, msg)
+}
+
+func ErrNoAuthorization(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAuthorization, fmt.Sprintf("%s has not authorized %s to execute %s messages", granter, grantee, msgType))
+}
+
+func ErrAuthorizationExpired(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationExpired, "authorization expired")
+}
+
+func ErrSpendLimitExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeSpendLimitExceeded, msg)
+}
+
+func ErrInvalidExec(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidExec, msg)
+}
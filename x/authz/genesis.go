@@ -0,0 +1,32 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all the authorizations at genesis
+type GenesisState struct {
+	Grants []Grant `json:"grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the genesis authorizations
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Grants {
+		k.Grant(ctx, grant.Granter, grant.Grantee, grant.Authorization)
+	}
+}
+
+// WriteGenesis - output all the authorizations
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []Grant
+	k.IterateAllGrants(ctx, func(grant Grant) (stop bool) {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{grants}
+}
@@ -0,0 +1,65 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "authz" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrant:
+			return handleMsgGrant(ctx, k, msg)
+		case MsgRevoke:
+			return handleMsgRevoke(ctx, k, msg)
+		case MsgExec:
+			return handleMsgExec(ctx, k, msg)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in authz module").Result()
+		}
+	}
+}
+
+func handleMsgGrant(ctx sdk.Context, k Keeper, msg MsgGrant) sdk.Result {
+	k.Grant(ctx, msg.Granter, msg.Grantee, msg.Authorization)
+
+	tags := sdk.NewTags(
+		"action", []byte("grant"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+		"msg-type", []byte(msg.Authorization.MsgType),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevoke(ctx sdk.Context, k Keeper, msg MsgRevoke) sdk.Result {
+	err := k.Revoke(ctx, msg.Granter, msg.Grantee, msg.MsgType)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"action", []byte("revoke"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+		"msg-type", []byte(msg.MsgType),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgExec(ctx sdk.Context, k Keeper, msg MsgExec) sdk.Result {
+	res := k.DispatchActions(ctx, msg.Grantee, msg.Msgs)
+	if !res.IsOK() {
+		return res
+	}
+
+	res.Tags = append(res.Tags, sdk.NewTags(
+		"action", []byte("exec"),
+		"grantee", []byte(msg.Grantee.String()),
+	)...)
+	return res
+}
@@ -0,0 +1,161 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Router routes the messages executed on behalf of granters to their
+// handlers, it is implemented by the baseapp router.
+type Router interface {
+	Route(path string) (h sdk.Handler)
+}
+
+// Grant is the authorization granter granted to grantee
+type Grant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+}
+
+// Keeper of the authz store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+	router   Router
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an authz keeper, which dispatches the messages executed
+// on behalf of granters with router
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, router Router, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		router:    router,
+		codespace: codespace,
+	}
+}
+
+// return the codespace
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+// Grant authorizes grantee to execute the messages of the authorization type
+// on behalf of granter, replacing any authorization of that type granted
+// before
+func (k Keeper) Grant(ctx sdk.Context, granter, grantee sdk.AccAddress, authorization Authorization) {
+	store := ctx.KVStore(k.storeKey)
+	grant := Grant{granter, grantee, authorization}
+	store.Set(GetAuthorizationKey(granter, grantee, authorization.MsgType), k.cdc.MustMarshalBinary(grant))
+}
+
+// Revoke removes the authorization of msgType messages granter granted to
+// grantee
+func (k Keeper) Revoke(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetAuthorizationKey(granter, grantee, msgType)
+	if !store.Has(key) {
+		return ErrNoAuthorization(k.codespace, granter, grantee, msgType)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetAuthorization returns the authorization of msgType messages granter
+// granted to grantee
+func (k Keeper) GetAuthorization(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) (authorization Authorization, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetAuthorizationKey(granter, grantee, msgType))
+	if bz == nil {
+		return authorization, false
+	}
+	var grant Grant
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant.Authorization, true
+}
+
+// GetGrants returns the authorizations granter granted to grantee
+func (k Keeper) GetGrants(ctx sdk.Context, granter, grantee sdk.AccAddress) (grants []Grant) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetAuthorizationsKey(granter, grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// IterateAllGrants iterates over all the authorizations, stopping when the
+// handler returns true
+func (k Keeper) IterateAllGrants(ctx sdk.Context, handler func(grant Grant) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, AuthorizationKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		if handler(grant) {
+			break
+		}
+	}
+}
+
+// useAuthorization charges msg to the authorization granter granted to
+// grantee, removing the authorization once it is used up or expired
+func (k Keeper) useAuthorization(ctx sdk.Context, granter, grantee sdk.AccAddress, msg sdk.Msg) sdk.Error {
+	msgType := MsgTypeName(msg)
+	authorization, found := k.GetAuthorization(ctx, granter, grantee, msgType)
+	if !found {
+		return ErrNoAuthorization(k.codespace, granter, grantee, msgType)
+	}
+
+	updated, remove, err := authorization.Accept(granter, msg, ctx.BlockHeader().Time)
+	if remove {
+		k.Revoke(ctx, granter, grantee, msgType) // nolint: errcheck
+	} else if err == nil {
+		k.Grant(ctx, granter, grantee, updated)
+	}
+	return err
+}
+
+// DispatchActions executes msgs on behalf of their signers, each of which
+// must be grantee or have authorized grantee to execute the message. The
+// messages are routed as if they had been signed by their signers.
+func (k Keeper) DispatchActions(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) sdk.Result {
+	var data []byte
+	var tags sdk.Tags
+	for _, msg := range msgs {
+		for _, signer := range msg.GetSigners() {
+			if signer.Equals(grantee) {
+				continue
+			}
+			err := k.useAuthorization(ctx, signer, grantee, msg)
+			if err != nil {
+				return err.Result()
+			}
+		}
+
+		handler := k.router.Route(msg.Type())
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msg.Type()).Result()
+		}
+
+		res := handler(ctx, msg)
+		if !res.IsOK() {
+			return res
+		}
+		data = append(data, res.Data...)
+		tags = append(tags, res.Tags...)
+	}
+
+	return sdk.Result{
+		Data: data,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,159 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+var (
+	addr1 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr2 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr3 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	sendType = MsgTypeName(bank.MsgSend{})
+)
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyAuthz := sdk.NewKVStoreKey("authz")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAuthz, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	RegisterWire(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0).UTC()}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, auth.ProtoBaseAccount)
+	bankKeeper := bank.NewKeeper(accountMapper)
+
+	router := baseapp.NewRouter()
+	keeper := NewKeeper(cdc, keyAuthz, router, DefaultCodespace)
+	router.
+		AddRoute("bank", bank.NewHandler(bankKeeper)).
+		AddRoute("authz", NewHandler(keeper))
+	return ctx, bankKeeper, keeper
+}
+
+func newSend(from, to sdk.AccAddress, amount int64) bank.MsgSend {
+	coins := sdk.Coins{sdk.NewInt64Coin("atom", amount)}
+	return bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+}
+
+func TestMsgTypeName(t *testing.T) {
+	require.Equal(t, "bank/MsgSend", MsgTypeName(bank.MsgSend{}))
+	require.Equal(t, "gov/MsgVote", MsgTypeName(gov.MsgVote{}))
+	require.Equal(t, "authz/MsgExec", MsgTypeName(MsgExec{}))
+}
+
+func TestGrantRevoke(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	authorization := NewAuthorization(sendType, time.Time{}, nil)
+
+	_, found := keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.False(t, found)
+	require.NotNil(t, keeper.Revoke(ctx, addr1, addr2, sendType))
+
+	keeper.Grant(ctx, addr1, addr2, authorization)
+	keeper.Grant(ctx, addr1, addr2, NewAuthorization("gov/MsgVote", time.Time{}, nil))
+	keeper.Grant(ctx, addr1, addr3, authorization)
+	got, found := keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.True(t, found)
+	require.Equal(t, authorization, got)
+	_, found = keeper.GetAuthorization(ctx, addr2, addr1, sendType)
+	require.False(t, found)
+
+	require.Len(t, keeper.GetGrants(ctx, addr1, addr2), 2)
+	require.Len(t, WriteGenesis(ctx, keeper).Grants, 3)
+
+	require.Nil(t, keeper.Revoke(ctx, addr1, addr2, sendType))
+	_, found = keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.False(t, found)
+	require.Len(t, keeper.GetGrants(ctx, addr1, addr2), 1)
+}
+
+func TestExec(t *testing.T) {
+	ctx, bankKeeper, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	bankKeeper.SetCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("atom", 100)})
+
+	// addr2 cannot send the coins of addr1 without an authorization
+	exec := NewMsgExec(addr2, []sdk.Msg{newSend(addr1, addr3, 10)})
+	res := handler(ctx, exec)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	grant := NewMsgGrant(addr1, addr2, NewAuthorization(sendType, expiration, sdk.Coins{sdk.NewInt64Coin("atom", 30)}))
+	require.Nil(t, grant.ValidateBasic())
+	require.True(t, handler(ctx, grant).IsOK())
+
+	// the grantee sends the coins of the granter within the spend limit
+	require.True(t, handler(ctx, exec).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 90)}, bankKeeper.GetCoins(ctx, addr1))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 10)}, bankKeeper.GetCoins(ctx, addr3))
+	authorization, _ := keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("atom", 20)}, authorization.SpendLimit)
+
+	// the spend limit cannot be exceeded
+	res = handler(ctx, NewMsgExec(addr2, []sdk.Msg{newSend(addr1, addr3, 21)}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSpendLimitExceeded), res.Code)
+
+	// the authorization is removed once used up
+	require.True(t, handler(ctx, NewMsgExec(addr2, []sdk.Msg{newSend(addr1, addr3, 20)})).IsOK())
+	_, found := keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.False(t, found)
+
+	// the grantee can execute its own messages
+	bankKeeper.SetCoins(ctx, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 5)})
+	require.True(t, handler(ctx, NewMsgExec(addr2, []sdk.Msg{newSend(addr2, addr3, 5)})).IsOK())
+
+	// expired authorizations cannot be used
+	keeper.Grant(ctx, addr1, addr2, NewAuthorization(sendType, expiration, nil))
+	ctx = ctx.WithBlockHeader(abci.Header{Time: expiration})
+	res = handler(ctx, exec)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAuthorizationExpired), res.Code)
+	_, found = keeper.GetAuthorization(ctx, addr1, addr2, sendType)
+	require.False(t, found)
+
+	// revoked authorizations cannot be used
+	keeper.Grant(ctx, addr1, addr2, NewAuthorization(sendType, time.Time{}, nil))
+	require.True(t, handler(ctx, NewMsgRevoke(addr1, addr2, sendType)).IsOK())
+	res = handler(ctx, exec)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+}
+
+func TestMsgExecValidateBasic(t *testing.T) {
+	send := newSend(addr1, addr3, 10)
+	require.Nil(t, NewMsgExec(addr2, []sdk.Msg{send}).ValidateBasic())
+	require.NotNil(t, NewMsgExec(addr2, nil).ValidateBasic())
+	require.NotNil(t, NewMsgExec(nil, []sdk.Msg{send}).ValidateBasic())
+	require.NotNil(t, NewMsgExec(addr2, []sdk.Msg{newSend(addr1, addr3, 0)}).ValidateBasic())
+	nested := NewMsgExec(addr2, []sdk.Msg{NewMsgExec(addr2, []sdk.Msg{send})})
+	require.NotNil(t, nested.ValidateBasic())
+
+	// spending can only be limited for known message types
+	authorization := NewAuthorization("gov/MsgVote", time.Time{}, sdk.Coins{sdk.NewInt64Coin("atom", 1)})
+	require.NotNil(t, NewMsgGrant(addr1, addr2, authorization).ValidateBasic())
+	authorization.SpendLimit = nil
+	require.Nil(t, NewMsgGrant(addr1, addr2, authorization).ValidateBasic())
+	require.NotNil(t, NewMsgGrant(addr1, addr1, authorization).ValidateBasic())
+}
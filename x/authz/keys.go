@@ -0,0 +1,20 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// key prefix bytes
+var (
+	AuthorizationKeyPrefix = []byte{0x00} // prefix for the authorizations, by granter then grantee
+)
+
+// key for the authorization granter granted to grantee for msgType messages
+func GetAuthorizationKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(GetAuthorizationsKey(granter, grantee), []byte(msgType)...)
+}
+
+// prefix of the keys of the authorizations granter granted to grantee
+func GetAuthorizationsKey(granter, grantee sdk.AccAddress) []byte {
+	return append(append(AuthorizationKeyPrefix, granter.Bytes()...), grantee.Bytes()...)
+}
@@ -0,0 +1,174 @@
+package authz
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "authz"
+
+// verify interface at compile time
+var _, _, _ sdk.Msg = MsgGrant{}, MsgRevoke{}, MsgExec{}
+
+//______________________________________________________________________
+
+// MsgGrant authorizes Grantee to execute messages on behalf of Granter,
+// replacing any authorization of the same message type granted before
+type MsgGrant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+}
+
+func NewMsgGrant(granter, grantee sdk.AccAddress, authorization Authorization) MsgGrant {
+	return MsgGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+	}
+}
+
+//nolint
+func (msg MsgGrant) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgGrant) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if msg.Granter.Equals(msg.Grantee) {
+		return ErrInvalidGrant(DefaultCodespace, "cannot grant an authorization to oneself")
+	}
+	return msg.Authorization.ValidateBasic()
+}
+
+// Implements Msg.
+func (msg MsgGrant) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgRevoke removes the authorization of MsgType messages Granter granted to
+// Grantee
+type MsgRevoke struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+	MsgType string         `json:"msg_type"`
+}
+
+func NewMsgRevoke(granter, grantee sdk.AccAddress, msgType string) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+//nolint
+func (msg MsgRevoke) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgRevoke) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if len(msg.MsgType) == 0 {
+		return ErrInvalidGrant(DefaultCodespace, "missing message type")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRevoke) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgExec executes Msgs on behalf of their signers, which must have
+// authorized Grantee to execute them
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs"`
+}
+
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+//nolint
+func (msg MsgExec) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrInvalidExec(DefaultCodespace, "no messages to execute")
+	}
+	for _, m := range msg.Msgs {
+		if _, ok := m.(MsgExec); ok {
+			return ErrInvalidExec(DefaultCodespace, "cannot execute nested MsgExec")
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	// the inner messages are signed over their own sign bytes, as in StdSignDoc
+	var msgsBytes []json.RawMessage
+	for _, m := range msg.Msgs {
+		msgsBytes = append(msgsBytes, json.RawMessage(m.GetSignBytes()))
+	}
+	b, err := msgCdc.MarshalJSON(struct {
+		Grantee sdk.AccAddress    `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{
+		Grantee: msg.Grantee,
+		Msgs:    msgsBytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
@@ -0,0 +1,79 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the authz Querier
+const (
+	QueryAuthorization = "authorization"
+	QueryGrants        = "grants"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAuthorization:
+			return queryAuthorization(ctx, path[1:], req, keeper)
+		case QueryGrants:
+			return queryGrants(ctx, path[1:], req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown authz query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/authz/authorization'
+type QueryAuthorizationParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+	MsgType string
+}
+
+func queryAuthorization(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryAuthorizationParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	authorization, found := keeper.GetAuthorization(ctx, params.Granter, params.Grantee, params.MsgType)
+	if !found {
+		return []byte{}, ErrNoAuthorization(DefaultCodespace, params.Granter, params.Grantee, params.MsgType)
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, authorization)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/authz/grants'
+type QueryGrantsParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+func queryGrants(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryGrantsParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	grants := keeper.GetGrants(ctx, params.Granter, params.Grantee)
+	if grants == nil {
+		grants = []Grant{}
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, grants)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
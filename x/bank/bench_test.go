@@ -23,9 +23,32 @@ func getBenchmarkMockApp() (*mock.App, error) {
 	return mapp, err
 }
 
+// getBenchmarkMockAppWithCache initializes the mock application with an
+// inter-block cache of the given size for the account store.
+func getBenchmarkMockAppWithCache(cacheSize int) (*mock.App, error) {
+	mapp := mock.NewApp()
+
+	RegisterWire(mapp.Cdc)
+	coinKeeper := NewKeeper(mapp.AccountMapper)
+	mapp.Router().AddRoute("bank", NewHandler(coinKeeper))
+
+	mapp.MountStoresIAVL(mapp.KeyMain, mapp.KeyAccount)
+	mapp.SetInterBlockCache(cacheSize, mapp.KeyAccount)
+	err := mapp.LoadLatestVersion(mapp.KeyMain)
+	return mapp, err
+}
+
 func BenchmarkOneBankSendTxPerBlock(b *testing.B) {
 	benchmarkApp, _ := getBenchmarkMockApp()
+	benchmarkOneBankSendTxPerBlock(b, benchmarkApp)
+}
+
+func BenchmarkOneBankSendTxPerBlockWithInterBlockCache(b *testing.B) {
+	benchmarkApp, _ := getBenchmarkMockAppWithCache(1000)
+	benchmarkOneBankSendTxPerBlock(b, benchmarkApp)
+}
 
+func benchmarkOneBankSendTxPerBlock(b *testing.B, benchmarkApp *mock.App) {
 	// Add an account at genesis
 	acc := &auth.BaseAccount{
 		Address: addr1,
@@ -50,6 +50,18 @@ func (keeper Keeper) AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coin
 	return addCoins(ctx, keeper.am, addr, amt)
 }
 
+// DelegateCoins subtracts amt from the coins at the addr to be delegated.
+// Unlike SubtractCoins, the vesting coins of a vesting account can be
+// delegated.
+func (keeper Keeper) DelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	return delegateCoins(ctx, keeper.am, addr, amt)
+}
+
+// UndelegateCoins adds amt to the coins at the addr once they are undelegated.
+func (keeper Keeper) UndelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	return undelegateCoins(ctx, keeper.am, addr, amt)
+}
+
 // SendCoins moves coins from one account to another
 func (keeper Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
@@ -140,6 +152,19 @@ func setCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt s
 	return nil
 }
 
+// coins at the addr which are not locked by a vesting schedule
+func getSpendableCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) sdk.Coins {
+	ctx.GasMeter().ConsumeGas(costGetCoins, "getSpendableCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coins{}
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		return vacc.SpendableCoins(ctx.BlockHeader().Time)
+	}
+	return acc.GetCoins()
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func hasCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) bool {
 	ctx.GasMeter().ConsumeGas(costHasCoins, "hasCoins")
@@ -149,11 +174,11 @@ func hasCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt s
 // SubtractCoins subtracts amt from the coins at the addr.
 func subtractCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
-	oldCoins := getCoins(ctx, am, addr)
-	newCoins := oldCoins.Minus(amt)
-	if !newCoins.IsNotNegative() {
-		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+	spendableCoins := getSpendableCoins(ctx, am, addr)
+	if !spendableCoins.Minus(amt).IsNotNegative() {
+		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", spendableCoins, amt))
 	}
+	newCoins := getCoins(ctx, am, addr).Minus(amt)
 	err := setCoins(ctx, am, addr, newCoins)
 	tags := sdk.NewTags("sender", []byte(addr.String()))
 	return newCoins, tags, err
@@ -172,6 +197,54 @@ func addCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt s
 	return newCoins, tags, err
 }
 
+// DelegateCoins subtracts amt from the coins at the addr, tracking the
+// delegation of vesting accounts
+func delegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costSubtractCoins, "delegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(addr.String())
+	}
+	oldCoins := acc.GetCoins()
+	newCoins := oldCoins.Minus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+	}
+	return sdk.NewTags("sender", []byte(addr.String())), setAccountCoins(ctx, am, acc, newCoins)
+}
+
+// UndelegateCoins adds amt to the coins at the addr, tracking the
+// undelegation of vesting accounts
+func undelegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costAddCoins, "undelegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = am.NewAccountWithAddress(ctx, addr)
+	}
+	newCoins := acc.GetCoins().Plus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), amt))
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackUndelegation(amt)
+	}
+	return sdk.NewTags("recipient", []byte(addr.String())), setAccountCoins(ctx, am, acc, newCoins)
+}
+
+func setAccountCoins(ctx sdk.Context, am auth.AccountMapper, acc auth.Account, amt sdk.Coins) sdk.Error {
+	ctx.GasMeter().ConsumeGas(costSetCoins, "setCoins")
+	err := acc.SetCoins(amt)
+	if err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return nil
+}
+
 // SendCoins moves coins from one account to another
 // NOTE: Make sure to revert state changes from tx on error
 func sendCoins(ctx sdk.Context, am auth.AccountMapper, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
@@ -2,6 +2,7 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -207,3 +208,57 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("barcoin", 5)}))
 }
+
+func TestVestingAccountKeeper(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(150, 0)}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	coinKeeper := NewKeeper(accountMapper)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	// half of the coins are vested halfway through the vesting period
+	vacc := auth.NewContinuousVestingAccount(addr, sdk.Coins{sdk.NewInt64Coin("steak", 100)}, 100, 200)
+	accountMapper.SetAccount(ctx, vacc)
+
+	// the vesting coins cannot be sent
+	_, err := coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 51)})
+	require.NotNil(t, err)
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("steak", 51)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 90)}))
+
+	// the vesting coins can be delegated, vesting coins are delegated first
+	_, err = coinKeeper.DelegateCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 30)}))
+	vacc = accountMapper.GetAccount(ctx, addr).(*auth.ContinuousVestingAccount)
+	require.True(t, vacc.DelegatedVesting.IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 50)}))
+	require.True(t, vacc.DelegatedFree.IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 10)}))
+
+	// the remaining coins are all vested
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 30)})
+	require.Nil(t, err)
+
+	// undelegated coins stay locked until they vest
+	_, err = coinKeeper.UndelegateCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 60)}))
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 11)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	require.Nil(t, err)
+
+	// everything can be spent once vested
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(200, 0)})
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 50)})
+	require.Nil(t, err)
+}
@@ -0,0 +1,92 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// allocate the fees collected during the previous block to the previous
+// proposer, the validators which were part of the last commit and the
+// community pool
+func (k Keeper) AllocateTokens(ctx sdk.Context, sumPrecommitPower, totalPower int64,
+	proposer sdk.ConsAddress, votes []abci.SigningValidator) {
+
+	logger := ctx.Logger().With("module", "x/distribution")
+
+	// fetch and clear the collected fees for distribution, since this is
+	// called in BeginBlock, collected fees will be from the previous block
+	// (and distributed to the previous proposer)
+	feesCollected := sdk.NewDecCoins(k.feeCollectionKeeper.GetCollectedFees(ctx))
+	k.feeCollectionKeeper.ClearCollectedFees(ctx)
+
+	// without any voting power there is nobody to reward, everything goes
+	// to the community pool
+	feePool := k.GetFeePool(ctx)
+	if totalPower == 0 {
+		feePool.CommunityPool = feePool.CommunityPool.Plus(feesCollected)
+		k.SetFeePool(ctx, feePool)
+		return
+	}
+
+	// calculate fraction votes
+	fractionVotes := sdk.NewDec(sumPrecommitPower).QuoTruncate(sdk.NewDec(totalPower))
+
+	// calculate proposer reward
+	baseProposerReward := k.BaseProposerReward(ctx)
+	bonusProposerReward := k.BonusProposerReward(ctx)
+	proposerMultiplier := baseProposerReward.Add(bonusProposerReward.MulTruncate(fractionVotes))
+	proposerReward := feesCollected.MulDecTruncate(proposerMultiplier)
+
+	// pay proposer
+	remaining := feesCollected
+	proposerValidator := k.validatorSet.ValidatorByConsAddr(ctx, proposer)
+	if proposerValidator != nil {
+		k.AllocateTokensToValidator(ctx, proposerValidator, proposerReward)
+		remaining = remaining.Minus(proposerReward)
+	} else {
+		// the previous proposer can be unknown if it was removed entirely
+		// within the last block, its reward then goes to the community pool
+		logger.Error(fmt.Sprintf("attempted to allocate proposer rewards to unknown proposer %s", proposer))
+	}
+
+	// calculate fraction allocated to validators
+	communityTax := k.CommunityTax(ctx)
+	voteMultiplier := sdk.OneDec().Sub(proposerMultiplier).Sub(communityTax)
+
+	// allocate tokens proportionally to voting power
+	for _, vote := range votes {
+		validator := k.validatorSet.ValidatorByConsAddr(ctx, sdk.ConsAddress(vote.Validator.Address))
+		if validator == nil {
+			continue
+		}
+
+		powerFraction := sdk.NewDec(vote.Validator.Power).QuoTruncate(sdk.NewDec(totalPower))
+		reward := feesCollected.MulDecTruncate(voteMultiplier).MulDecTruncate(powerFraction)
+		k.AllocateTokensToValidator(ctx, validator, reward)
+		remaining = remaining.Minus(reward)
+	}
+
+	// allocate community funding
+	feePool.CommunityPool = feePool.CommunityPool.Plus(remaining)
+	k.SetFeePool(ctx, feePool)
+}
+
+// allocate tokens to a particular validator, splitting according to commission
+func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val sdk.Validator, tokens sdk.DecCoins) {
+	// split tokens between validator and delegators according to commission
+	commission := tokens.MulDec(val.GetCommission())
+	shared := tokens.Minus(commission)
+
+	// update current commission
+	valAddr := val.GetOperator()
+	currentCommission := k.GetValidatorAccumulatedCommission(ctx, valAddr)
+	currentCommission = currentCommission.Plus(commission)
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, currentCommission)
+
+	// update current rewards
+	currentRewards := k.GetValidatorCurrentRewards(ctx, valAddr)
+	currentRewards.Rewards = currentRewards.Rewards.Plus(shared)
+	k.SetValidatorCurrentRewards(ctx, valAddr, currentRewards)
+}
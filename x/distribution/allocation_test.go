@@ -0,0 +1,95 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestAllocateTokensToValidatorWithCommission(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+
+	// create validator with 50% commission
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+	val := setTestCommission(ctx, sk, addrs[0], sdk.NewDecWithPrec(5, 1))
+
+	// allocate tokens
+	tokens := sdk.DecCoins{sdk.NewDecCoin("steak", 10)}
+	keeper.AllocateTokensToValidator(ctx, val, tokens)
+
+	// check commission
+	expected := sdk.DecCoins{sdk.NewDecCoin("steak", 5)}
+	require.True(t, expected.IsEqual(keeper.GetValidatorAccumulatedCommission(ctx, addrs[0])))
+
+	// check current rewards
+	require.True(t, expected.IsEqual(keeper.GetValidatorCurrentRewards(ctx, addrs[0]).Rewards))
+}
+
+func TestAllocateTokensToManyValidators(t *testing.T) {
+	ctx, _, sk, fck, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+
+	// create validator with 50% commission
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+	setTestCommission(ctx, sk, addrs[0], sdk.NewDecWithPrec(5, 1))
+
+	// create second validator with 0% commission
+	got = stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+
+	abciValA := abci.Validator{Address: pks[0].Address(), Power: 100}
+	abciValB := abci.Validator{Address: pks[1].Address(), Power: 100}
+
+	// assert initial state: zero current rewards, zero community pool
+	require.True(t, keeper.GetValidatorCurrentRewards(ctx, addrs[0]).Rewards.IsZero())
+	require.True(t, keeper.GetValidatorCurrentRewards(ctx, addrs[1]).Rewards.IsZero())
+	require.True(t, keeper.GetFeePool(ctx).CommunityPool.IsZero())
+	require.True(t, keeper.GetValidatorAccumulatedCommission(ctx, addrs[0]).IsZero())
+	require.True(t, keeper.GetValidatorAccumulatedCommission(ctx, addrs[1]).IsZero())
+
+	// allocate tokens as if both had voted and second was proposer
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	fck.AddCollectedFees(ctx, fees)
+	votes := []abci.SigningValidator{
+		{Validator: abciValA, SignedLastBlock: true},
+		{Validator: abciValB, SignedLastBlock: true},
+	}
+	keeper.AllocateTokens(ctx, 200, 200, sdk.ConsAddress(pks[1].Address()), votes)
+
+	// collected fees are cleared
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+
+	// 98 outstanding, 2 to the community pool (community tax)
+	require.True(t, sdk.DecCoins{sdk.NewDecCoin("steak", 2)}.IsEqual(keeper.GetFeePool(ctx).CommunityPool))
+
+	// 50% commission for the first validator, (0.5 * 93%) * 100 / 2 = 23.25
+	require.True(t, sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(2325, 2))}.
+		IsEqual(keeper.GetValidatorAccumulatedCommission(ctx, addrs[0])))
+	// zero commission for the second validator
+	require.True(t, keeper.GetValidatorAccumulatedCommission(ctx, addrs[1]).IsZero())
+
+	// voting share of the first validator less commission = (0.5 * 93%) * 100 / 2 = 23.25
+	require.True(t, sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(2325, 2))}.
+		IsEqual(keeper.GetValidatorCurrentRewards(ctx, addrs[0]).Rewards))
+	// proposer reward plus voting share of the second validator = (5% + 0.5 * 93%) * 100 = 51.5
+	require.True(t, sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(515, 1))}.
+		IsEqual(keeper.GetValidatorCurrentRewards(ctx, addrs[1]).Rewards))
+}
+
+func TestAllocateTokensWithoutPower(t *testing.T) {
+	ctx, _, _, fck, keeper := createTestInput(t)
+
+	// without any voting power all the fees go to the community pool
+	fck.AddCollectedFees(ctx, sdk.Coins{sdk.NewInt64Coin("steak", 100)})
+	keeper.AllocateTokens(ctx, 0, 0, nil, nil)
+
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+	require.True(t, sdk.DecCoins{sdk.NewDecCoin("steak", 100)}.IsEqual(keeper.GetFeePool(ctx).CommunityPool))
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagAddressValidator = "validator"
+)
+
+// GetCmdWithdrawRewards implements the withdraw delegation rewards command.
+func GetCmdWithdrawRewards(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-rewards",
+		Args:  cobra.NoArgs,
+		Short: "withdraw the rewards accrued by a delegation to a validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := sdk.ValAddressFromBech32(viper.GetString(flagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawDelegatorReward(delAddr, valAddr)
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagAddressValidator, "", "bech address of the validator to withdraw the rewards from")
+	return cmd
+}
+
+// GetCmdWithdrawCommission implements the withdraw validator commission command.
+func GetCmdWithdrawCommission(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-commission",
+		Args:  cobra.NoArgs,
+		Short: "withdraw the accumulated commission of the validator operated by the sender",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawValidatorCommission(sdk.ValAddress(valAddr))
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
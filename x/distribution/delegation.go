@@ -0,0 +1,135 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// initialize starting info for a new delegation
+func (k Keeper) initializeDelegation(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) {
+	// period has already been incremented - we want to store the period ended by this delegation action
+	previousPeriod := k.GetValidatorCurrentRewards(ctx, valAddr).Period - 1
+
+	// increment reference count for the period we're going to track
+	k.incrementReferenceCount(ctx, valAddr, previousPeriod)
+
+	delegation := k.validatorSet.Delegation(ctx, delAddr, valAddr)
+	if delegation == nil {
+		panic(fmt.Sprintf("delegation record not found for delegator %v and validator %v\n", delAddr, valAddr))
+	}
+
+	k.SetDelegatorStartingInfo(ctx, valAddr, delAddr, DelegatorStartingInfo{
+		PreviousPeriod: previousPeriod,
+		Shares:         delegation.GetBondShares(),
+		Height:         ctx.BlockHeight(),
+	})
+}
+
+// calculate the rewards accrued by a delegation between two periods
+func (k Keeper) calculateDelegationRewardsBetween(ctx sdk.Context, valAddr sdk.ValAddress,
+	startingPeriod, endingPeriod uint64, shares sdk.Dec) (rewards sdk.DecCoins) {
+
+	// sanity check
+	if startingPeriod > endingPeriod {
+		panic("startingPeriod cannot be greater than endingPeriod")
+	}
+
+	// return shares * (ending - starting)
+	starting := k.GetValidatorHistoricalRewards(ctx, valAddr, startingPeriod).CumulativeRewardRatio
+	ending := k.GetValidatorHistoricalRewards(ctx, valAddr, endingPeriod).CumulativeRewardRatio
+	difference := ending.Minus(starting)
+	if !difference.IsNotNegative() {
+		panic("negative rewards should not be possible")
+	}
+	return difference.MulDec(shares)
+}
+
+// calculate the total rewards accrued by a delegation up to the given ending period
+func (k Keeper) calculateDelegationRewards(ctx sdk.Context, valAddr sdk.ValAddress,
+	delAddr sdk.AccAddress, endingPeriod uint64) sdk.DecCoins {
+
+	startingInfo := k.GetDelegatorStartingInfo(ctx, valAddr, delAddr)
+	return k.calculateDelegationRewardsBetween(ctx, valAddr, startingInfo.PreviousPeriod, endingPeriod, startingInfo.Shares)
+}
+
+// withdraw the rewards of a delegation to the delegator account, the
+// fractional remainder which cannot be paid out is sent to the community pool
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) (sdk.Coins, sdk.Error) {
+
+	// check existence of delegator starting info
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		return nil, ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	// end current period and calculate rewards
+	endingPeriod := k.incrementValidatorPeriod(ctx, valAddr)
+	rewards := k.calculateDelegationRewards(ctx, valAddr, delAddr, endingPeriod)
+
+	// decrement reference count of starting period
+	startingInfo := k.GetDelegatorStartingInfo(ctx, valAddr, delAddr)
+	k.decrementReferenceCount(ctx, valAddr, startingInfo.PreviousPeriod)
+
+	// remove delegator starting info, it is re-initialized once the
+	// delegation modification is complete
+	k.DeleteDelegatorStartingInfo(ctx, valAddr, delAddr)
+
+	// truncate coins, return remainder to community pool
+	coins, remainder := rewards.TruncateDecimal()
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(remainder)
+	k.SetFeePool(ctx, feePool)
+
+	// add coins to user account
+	if !coins.IsZero() {
+		if _, _, err := k.bankKeeper.AddCoins(ctx, delAddr, coins); err != nil {
+			return nil, err
+		}
+	}
+
+	return coins, nil
+}
+
+// withdraw the rewards of a delegation, keeping the delegation tracked afterwards
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Coins, sdk.Error) {
+	if k.validatorSet.Validator(ctx, valAddr) == nil {
+		return nil, ErrNoValidatorDistInfo(k.codespace)
+	}
+	if k.validatorSet.Delegation(ctx, delAddr, valAddr) == nil {
+		return nil, ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	coins, err := k.withdrawDelegationRewards(ctx, valAddr, delAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// reinitialize the delegation
+	k.initializeDelegation(ctx, valAddr, delAddr)
+	return coins, nil
+}
+
+// get the rewards a delegation would receive if it withdrew now, without
+// modifying any state
+func (k Keeper) GetDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.DecCoins, sdk.Error) {
+	validator := k.validatorSet.Validator(ctx, valAddr)
+	if validator == nil {
+		return nil, ErrNoValidatorDistInfo(k.codespace)
+	}
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		return nil, ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	current := k.GetValidatorCurrentRewards(ctx, valAddr)
+	startingInfo := k.GetDelegatorStartingInfo(ctx, valAddr, delAddr)
+
+	// rewards of all the periods which already ended
+	rewards := k.calculateDelegationRewardsBetween(ctx, valAddr, startingInfo.PreviousPeriod, current.Period-1, startingInfo.Shares)
+
+	// plus the share of the rewards accrued during the current period
+	shares := validator.GetDelegatorShares()
+	if !shares.IsZero() {
+		rewards = rewards.Plus(current.Rewards.QuoDec(shares).MulDec(startingInfo.Shares))
+	}
+	return rewards, nil
+}
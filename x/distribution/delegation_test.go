@@ -0,0 +1,108 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestWithdrawDelegationRewards(t *testing.T) {
+	ctx, ck, sk, _, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	valOpr, delAddr := addrs[0], sdk.AccAddress(addrs[1])
+
+	// create validator with zero commission and a self-delegation of 100
+	got := stakeHandler(ctx, newTestMsgCreateValidator(valOpr, pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// allocate some rewards, all of them accrue to the self-delegation
+	val := sk.Validator(ctx, valOpr)
+	keeper.AllocateTokensToValidator(ctx, val, sdk.DecCoins{sdk.NewDecCoin("steak", 10)})
+
+	// second delegation of 100, ending the current period
+	got = stakeHandler(ctx, newTestMsgDelegate(delAddr, valOpr, sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// allocate some more rewards, shared among both delegations
+	val = sk.Validator(ctx, valOpr)
+	keeper.AllocateTokensToValidator(ctx, val, sdk.DecCoins{sdk.NewDecCoin("steak", 20)})
+
+	// query the pending rewards
+	rewards, err := keeper.GetDelegationRewards(ctx, sdk.AccAddress(valOpr), valOpr)
+	require.Nil(t, err)
+	require.True(t, sdk.DecCoins{sdk.NewDecCoin("steak", 20)}.IsEqual(rewards), "got %v", rewards)
+	rewards, err = keeper.GetDelegationRewards(ctx, delAddr, valOpr)
+	require.Nil(t, err)
+	require.True(t, sdk.DecCoins{sdk.NewDecCoin("steak", 10)}.IsEqual(rewards), "got %v", rewards)
+
+	// withdraw the self-delegation rewards: 10 + 20 / 2
+	coins, err := keeper.WithdrawDelegationRewards(ctx, sdk.AccAddress(valOpr), valOpr)
+	require.Nil(t, err)
+	require.True(t, sdk.Coins{sdk.NewInt64Coin("steak", 20)}.IsEqual(coins))
+	require.True(t, ck.GetCoins(ctx, sdk.AccAddress(valOpr)).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 120)}))
+
+	// withdraw the second delegation rewards: 20 / 2
+	coins, err = keeper.WithdrawDelegationRewards(ctx, delAddr, valOpr)
+	require.Nil(t, err)
+	require.True(t, sdk.Coins{sdk.NewInt64Coin("steak", 10)}.IsEqual(coins))
+	require.True(t, ck.GetCoins(ctx, delAddr).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 110)}))
+
+	// nothing left to withdraw
+	coins, err = keeper.WithdrawDelegationRewards(ctx, delAddr, valOpr)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	require.True(t, ck.GetCoins(ctx, delAddr).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 110)}))
+
+	// no rewards for an unknown delegation
+	_, err = keeper.WithdrawDelegationRewards(ctx, sdk.AccAddress(addrs[2]), valOpr)
+	require.NotNil(t, err)
+}
+
+func TestWithdrawRewardsOnDelegationChange(t *testing.T) {
+	ctx, ck, sk, _, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	valOpr := addrs[0]
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(valOpr, pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+
+	val := sk.Validator(ctx, valOpr)
+	keeper.AllocateTokensToValidator(ctx, val, sdk.DecCoins{sdk.NewDecCoin("steak", 10)})
+
+	// delegating more withdraws the pending rewards: 200 - 100 - 50 + 10
+	got = stakeHandler(ctx, newTestMsgDelegate(sdk.AccAddress(valOpr), valOpr, sdk.NewInt(50)))
+	require.True(t, got.IsOK(), "%v", got)
+	require.True(t, ck.GetCoins(ctx, sdk.AccAddress(valOpr)).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 60)}))
+
+	// the delegation tracks its new shares
+	info := keeper.GetDelegatorStartingInfo(ctx, valOpr, sdk.AccAddress(valOpr))
+	require.True(t, sdk.NewDec(150).Equal(info.Shares))
+}
+
+func TestWithdrawValidatorCommission(t *testing.T) {
+	ctx, ck, sk, _, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	valOpr := addrs[0]
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(valOpr, pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK(), "%v", got)
+	val := setTestCommission(ctx, sk, valOpr, sdk.NewDecWithPrec(5, 1))
+
+	// nothing to withdraw yet
+	_, err := keeper.WithdrawValidatorCommission(ctx, valOpr)
+	require.NotNil(t, err)
+
+	// 50% of 15 is 7.5
+	keeper.AllocateTokensToValidator(ctx, val, sdk.DecCoins{sdk.NewDecCoin("steak", 15)})
+
+	// the truncated commission is paid out, the remainder is kept
+	coins, err := keeper.WithdrawValidatorCommission(ctx, valOpr)
+	require.Nil(t, err)
+	require.True(t, sdk.Coins{sdk.NewInt64Coin("steak", 7)}.IsEqual(coins))
+	require.True(t, ck.GetCoins(ctx, sdk.AccAddress(valOpr)).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 107)}))
+	remainder := sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(5, 1))}
+	require.True(t, remainder.IsEqual(keeper.GetValidatorAccumulatedCommission(ctx, valOpr)))
+}
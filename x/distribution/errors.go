@@ -0,0 +1,45 @@
+//nolint
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default distribution codespace
+	DefaultCodespace sdk.CodespaceType = 6
+
+	CodeInvalidInput          CodeType = 103
+	CodeNoDistributionInfo    CodeType = 104
+	CodeNoValidatorCommission CodeType = 105
+	CodeInsufficientPool      CodeType = 106
+)
+
+func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "delegator address is nil")
+}
+
+func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "validator address is nil")
+}
+
+func ErrNoValidatorDistInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoDistributionInfo, "no validator distribution info")
+}
+
+func ErrNoDelegationDistInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoDistributionInfo, "no delegation distribution info")
+}
+
+func ErrNoValidatorCommission(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoValidatorCommission, "no validator commission to withdraw")
+}
+
+func ErrInsufficientCommunityPool(codespace sdk.CodespaceType, amount sdk.Coins, pool sdk.DecCoins) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientPool, fmt.Sprintf("community pool of %v cannot cover %v", pool, amount))
+}
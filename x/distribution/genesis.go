@@ -0,0 +1,151 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stake "github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// historical rewards of a validator for a period, used for genesis import/export
+type ValidatorHistoricalRewardsRecord struct {
+	ValidatorAddr sdk.ValAddress             `json:"validator_addr"`
+	Period        uint64                     `json:"period"`
+	Rewards       ValidatorHistoricalRewards `json:"rewards"`
+}
+
+// current rewards of a validator, used for genesis import/export
+type ValidatorCurrentRewardsRecord struct {
+	ValidatorAddr sdk.ValAddress          `json:"validator_addr"`
+	Rewards       ValidatorCurrentRewards `json:"rewards"`
+}
+
+// accumulated commission of a validator, used for genesis import/export
+type ValidatorAccumulatedCommissionRecord struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Accumulated   sdk.DecCoins   `json:"accumulated"`
+}
+
+// starting info of a delegation, used for genesis import/export
+type DelegatorStartingInfoRecord struct {
+	DelegatorAddr sdk.AccAddress        `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress        `json:"validator_addr"`
+	StartingInfo  DelegatorStartingInfo `json:"starting_info"`
+}
+
+// GenesisState - all distribution state that must be provided at genesis
+type GenesisState struct {
+	FeePool                         FeePool                                `json:"fee_pool"`
+	PreviousProposer                sdk.ConsAddress                        `json:"previous_proposer"`
+	ValidatorHistoricalRewards      []ValidatorHistoricalRewardsRecord     `json:"validator_historical_rewards"`
+	ValidatorCurrentRewards         []ValidatorCurrentRewardsRecord        `json:"validator_current_rewards"`
+	ValidatorAccumulatedCommissions []ValidatorAccumulatedCommissionRecord `json:"validator_accumulated_commissions"`
+	DelegatorStartingInfos          []DelegatorStartingInfoRecord          `json:"delegator_starting_infos"`
+}
+
+func NewGenesisState(feePool FeePool, previousProposer sdk.ConsAddress,
+	historicals []ValidatorHistoricalRewardsRecord, currents []ValidatorCurrentRewardsRecord,
+	commissions []ValidatorAccumulatedCommissionRecord, startingInfos []DelegatorStartingInfoRecord) GenesisState {
+
+	return GenesisState{
+		FeePool:                         feePool,
+		PreviousProposer:                previousProposer,
+		ValidatorHistoricalRewards:      historicals,
+		ValidatorCurrentRewards:         currents,
+		ValidatorAccumulatedCommissions: commissions,
+		DelegatorStartingInfos:          startingInfos,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		FeePool: InitialFeePool(),
+	}
+}
+
+// InitGenesis sets the distribution state. Validators and delegations of the
+// stake genesis which have no distribution records yet (e.g. those created at
+// genesis, bypassing the stake hooks) start accruing rewards from genesis.
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState, stakeData stake.GenesisState) {
+	k.SetFeePool(ctx, data.FeePool)
+	if data.PreviousProposer != nil {
+		k.SetPreviousProposerConsAddr(ctx, data.PreviousProposer)
+	}
+	for _, his := range data.ValidatorHistoricalRewards {
+		k.SetValidatorHistoricalRewards(ctx, his.ValidatorAddr, his.Period, his.Rewards)
+	}
+	for _, cur := range data.ValidatorCurrentRewards {
+		k.SetValidatorCurrentRewards(ctx, cur.ValidatorAddr, cur.Rewards)
+	}
+	for _, acc := range data.ValidatorAccumulatedCommissions {
+		k.SetValidatorAccumulatedCommission(ctx, acc.ValidatorAddr, acc.Accumulated)
+	}
+	for _, del := range data.DelegatorStartingInfos {
+		k.SetDelegatorStartingInfo(ctx, del.ValidatorAddr, del.DelegatorAddr, del.StartingInfo)
+	}
+
+	for _, validator := range stakeData.Validators {
+		if !k.HasValidatorCurrentRewards(ctx, validator.Operator) {
+			k.initializeValidator(ctx, validator.Operator)
+		}
+	}
+	for _, bond := range stakeData.Bonds {
+		if !k.HasDelegatorStartingInfo(ctx, bond.ValidatorAddr, bond.DelegatorAddr) {
+			k.incrementValidatorPeriod(ctx, bond.ValidatorAddr)
+			k.initializeDelegation(ctx, bond.ValidatorAddr, bond.DelegatorAddr)
+		}
+	}
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper.
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	feePool := k.GetFeePool(ctx)
+	previousProposer := k.GetPreviousProposerConsAddr(ctx)
+
+	historicals := make([]ValidatorHistoricalRewardsRecord, 0)
+	k.IterateValidatorHistoricalRewards(ctx,
+		func(valAddr sdk.ValAddress, period uint64, rewards ValidatorHistoricalRewards) (stop bool) {
+			historicals = append(historicals, ValidatorHistoricalRewardsRecord{
+				ValidatorAddr: valAddr,
+				Period:        period,
+				Rewards:       rewards,
+			})
+			return false
+		},
+	)
+
+	currents := make([]ValidatorCurrentRewardsRecord, 0)
+	k.IterateValidatorCurrentRewards(ctx,
+		func(valAddr sdk.ValAddress, rewards ValidatorCurrentRewards) (stop bool) {
+			currents = append(currents, ValidatorCurrentRewardsRecord{
+				ValidatorAddr: valAddr,
+				Rewards:       rewards,
+			})
+			return false
+		},
+	)
+
+	commissions := make([]ValidatorAccumulatedCommissionRecord, 0)
+	k.IterateValidatorAccumulatedCommissions(ctx,
+		func(valAddr sdk.ValAddress, commission sdk.DecCoins) (stop bool) {
+			commissions = append(commissions, ValidatorAccumulatedCommissionRecord{
+				ValidatorAddr: valAddr,
+				Accumulated:   commission,
+			})
+			return false
+		},
+	)
+
+	startingInfos := make([]DelegatorStartingInfoRecord, 0)
+	k.IterateDelegatorStartingInfos(ctx,
+		func(valAddr sdk.ValAddress, delAddr sdk.AccAddress, info DelegatorStartingInfo) (stop bool) {
+			startingInfos = append(startingInfos, DelegatorStartingInfoRecord{
+				DelegatorAddr: delAddr,
+				ValidatorAddr: valAddr,
+				StartingInfo:  info,
+			})
+			return false
+		},
+	)
+
+	return NewGenesisState(feePool, previousProposer, historicals, currents, commissions, startingInfos)
+}
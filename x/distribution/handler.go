@@ -0,0 +1,53 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/tags"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgWithdrawDelegatorReward:
+			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
+		case MsgWithdrawValidatorCommission:
+			return handleMsgWithdrawValidatorCommission(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
+		}
+	}
+}
+
+// Delegators withdraw the rewards accrued by a delegation to their account
+func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg MsgWithdrawDelegatorReward, k Keeper) sdk.Result {
+	_, err := k.WithdrawDelegationRewards(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionWithdrawDelegatorReward,
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.Validator, []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+// Validators withdraw their accumulated commission to the operator account
+func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg MsgWithdrawValidatorCommission, k Keeper) sdk.Result {
+	_, err := k.WithdrawValidatorCommission(ctx, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionWithdrawValidatorCommission,
+		tags.Validator, []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
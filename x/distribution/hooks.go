@@ -0,0 +1,107 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Initialize the distribution records of a newly created validator
+func (k Keeper) onValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) {
+	k.initializeValidator(ctx, valAddr)
+}
+
+// Pay out the remaining commission of a validator which is about to be
+// removed and clean up its distribution records
+func (k Keeper) onValidatorRemoved(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if !k.HasValidatorCurrentRewards(ctx, valAddr) {
+		return
+	}
+
+	// force-withdraw commission, the fractional remainder goes to the community pool
+	feePool := k.GetFeePool(ctx)
+	commission := k.GetValidatorAccumulatedCommission(ctx, valAddr)
+	if !commission.IsZero() {
+		coins, remainder := commission.TruncateDecimal()
+		feePool.CommunityPool = feePool.CommunityPool.Plus(remainder)
+		if !coins.IsZero() {
+			_, _, err := k.bankKeeper.AddCoins(ctx, sdk.AccAddress(valAddr), coins)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	// rewards which were never claimed by any delegator go to the community pool
+	outstanding := k.GetValidatorCurrentRewards(ctx, valAddr).Rewards
+	feePool.CommunityPool = feePool.CommunityPool.Plus(outstanding)
+	k.SetFeePool(ctx, feePool)
+
+	// delete the distribution records of the validator
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, sdk.DecCoins{})
+	k.DeleteAllValidatorHistoricalRewards(ctx, valAddr)
+	k.DeleteValidatorCurrentRewards(ctx, valAddr)
+}
+
+// End the current period of the validator before a new delegation is created
+func (k Keeper) beforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	k.incrementValidatorPeriod(ctx, valAddr)
+}
+
+// Withdraw the rewards of a delegation before its shares are modified
+func (k Keeper) beforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		// nothing to withdraw, only end the current period so the
+		// delegation can be initialized afterwards
+		k.incrementValidatorPeriod(ctx, valAddr)
+		return
+	}
+	if _, err := k.withdrawDelegationRewards(ctx, valAddr, delAddr); err != nil {
+		panic(err)
+	}
+}
+
+// Track the delegation from the period just ended
+func (k Keeper) afterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	k.initializeDelegation(ctx, valAddr, delAddr)
+}
+
+// Wrapper struct for sdk.ValidatorHooks
+type ValidatorHooks struct {
+	k Keeper
+}
+
+// Assert implementation
+var _ sdk.ValidatorHooks = ValidatorHooks{}
+
+// Return a sdk.ValidatorHooks interface over the wrapper struct
+func (k Keeper) ValidatorHooks() sdk.ValidatorHooks {
+	return ValidatorHooks{k}
+}
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) OnValidatorCreated(ctx sdk.Context, address sdk.ValAddress) {
+	v.k.onValidatorCreated(ctx, address)
+}
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) OnValidatorRemoved(ctx sdk.Context, address sdk.ValAddress) {
+	v.k.onValidatorRemoved(ctx, address)
+}
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	v.k.beforeDelegationCreated(ctx, delAddr, valAddr)
+}
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	v.k.beforeDelegationSharesModified(ctx, delAddr, valAddr)
+}
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	v.k.afterDelegationModified(ctx, delAddr, valAddr)
+}
+
+// nolint - unused hooks
+func (v ValidatorHooks) OnValidatorBonded(_ sdk.Context, _ sdk.ConsAddress)         {}
+func (v ValidatorHooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.ConsAddress) {}
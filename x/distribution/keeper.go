@@ -1,91 +1,295 @@
-package stake
-
-//// keeper of the staking store
-//type Keeper struct {
-//storeKey   sdk.StoreKey
-//cdc        *wire.Codec
-//coinKeeper bank.Keeper
-
-//// codespace
-//codespace sdk.CodespaceType
-//}
-
-//func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
-//keeper := Keeper{
-//storeKey:   key,
-//cdc:        cdc,
-//coinKeeper: ck,
-//codespace:  codespace,
-//}
-//return keeper
-//}
-
-////_________________________________________________________________________
-
-//// cummulative power of the non-absent prevotes
-//func (k Keeper) GetTotalPrecommitVotingPower(ctx sdk.Context) sdk.Dec {
-//store := ctx.KVStore(k.storeKey)
-
-//// get absent prevote indexes
-//absents := ctx.AbsentValidators()
-
-//TotalPower := sdk.ZeroDec()
-//i := int32(0)
-//iterator := store.SubspaceIterator(ValidatorsBondedKey)
-//for ; iterator.Valid(); iterator.Next() {
-
-//skip := false
-//for j, absentIndex := range absents {
-//if absentIndex > i {
-//break
-//}
-
-//// if non-voting validator found, skip adding its power
-//if absentIndex == i {
-//absents = append(absents[:j], absents[j+1:]...) // won't need again
-//skip = true
-//break
-//}
-//}
-//if skip {
-//continue
-//}
-
-//bz := iterator.Value()
-//var validator Validator
-//k.cdc.MustUnmarshalBinary(bz, &validator)
-//TotalPower = TotalPower.Add(validator.Power)
-//i++
-//}
-//iterator.Close()
-//return TotalPower
-//}
-
-////_______________________________________________________________________
-
-//// XXX TODO trim functionality
-
-//// retrieve all the power changes which occur after a height
-//func (k Keeper) GetPowerChangesAfterHeight(ctx sdk.Context, earliestHeight int64) (pcs []PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-
-//iterator := store.SubspaceIterator(PowerChangeKey) //smallest to largest
-//for ; iterator.Valid(); iterator.Next() {
-//pcBytes := iterator.Value()
-//var pc PowerChange
-//k.cdc.MustUnmarshalBinary(pcBytes, &pc)
-//if pc.Height < earliestHeight {
-//break
-//}
-//pcs = append(pcs, pc)
-//}
-//iterator.Close()
-//return
-//}
-
-//// set a power change
-//func (k Keeper) setPowerChange(ctx sdk.Context, pc PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-//b := k.cdc.MustMarshalBinary(pc)
-//store.Set(GetPowerChangeKey(pc.Height), b)
-//}
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper of the distribution store
+type Keeper struct {
+	storeKey            sdk.StoreKey
+	cdc                 *wire.Codec
+	params              params.Getter
+	bankKeeper          bank.Keeper
+	validatorSet        sdk.ValidatorSet
+	delegationSet       sdk.DelegationSet
+	feeCollectionKeeper auth.FeeCollectionKeeper
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a distribution keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, params params.Getter, ck bank.Keeper,
+	ds sdk.DelegationSet, fck auth.FeeCollectionKeeper, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:            key,
+		cdc:                 cdc,
+		params:              params,
+		bankKeeper:          ck,
+		validatorSet:        ds.GetValidatorSet(),
+		delegationSet:       ds,
+		feeCollectionKeeper: fck,
+		codespace:           codespace,
+	}
+	return keeper
+}
+
+// return the codespace
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+//______________________________________________________________________
+
+// get the global fee pool
+func (k Keeper) GetFeePool(ctx sdk.Context) (feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(FeePoolKey)
+	if b == nil {
+		panic("Stored fee pool should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &feePool)
+	return
+}
+
+// set the global fee pool
+func (k Keeper) SetFeePool(ctx sdk.Context, feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(feePool)
+	store.Set(FeePoolKey, b)
+}
+
+// send coins from the community pool to an account, used to execute passed
+// CommunityPoolSpend proposals
+func (k Keeper) DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr sdk.AccAddress) sdk.Error {
+	feePool := k.GetFeePool(ctx)
+	newPool := feePool.CommunityPool.Minus(sdk.NewDecCoins(amount))
+	if !newPool.IsNotNegative() {
+		return ErrInsufficientCommunityPool(k.codespace, amount, feePool.CommunityPool)
+	}
+	feePool.CommunityPool = newPool
+
+	_, _, err := k.bankKeeper.AddCoins(ctx, receiveAddr, amount)
+	if err != nil {
+		return err
+	}
+	k.SetFeePool(ctx, feePool)
+	return nil
+}
+
+// get the consensus address of the proposer of the previous block
+func (k Keeper) GetPreviousProposerConsAddr(ctx sdk.Context) (consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(ProposerKey)
+	if b == nil {
+		return nil
+	}
+	k.cdc.MustUnmarshalBinary(b, &consAddr)
+	return
+}
+
+// set the consensus address of the proposer of the previous block
+func (k Keeper) SetPreviousProposerConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(consAddr)
+	store.Set(ProposerKey, b)
+}
+
+//______________________________________________________________________
+
+// get the current rewards of a validator
+func (k Keeper) GetValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) (rewards ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorCurrentRewardsKey(valAddr))
+	if b == nil {
+		panic("Stored validator current rewards should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &rewards)
+	return
+}
+
+// set the current rewards of a validator
+func (k Keeper) SetValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress, rewards ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(rewards)
+	store.Set(GetValidatorCurrentRewardsKey(valAddr), b)
+}
+
+// delete the current rewards of a validator
+func (k Keeper) DeleteValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorCurrentRewardsKey(valAddr))
+}
+
+// check whether distribution records exist for a validator
+func (k Keeper) HasValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetValidatorCurrentRewardsKey(valAddr))
+}
+
+// iterate over the current rewards of all validators
+func (k Keeper) IterateValidatorCurrentRewards(ctx sdk.Context,
+	fn func(valAddr sdk.ValAddress, rewards ValidatorCurrentRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorCurrentRewardsKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var rewards ValidatorCurrentRewards
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &rewards)
+		if fn(sdk.ValAddress(iterator.Key()[1:]), rewards) {
+			break
+		}
+	}
+}
+
+//______________________________________________________________________
+
+// get the historical rewards of a validator at the end of a period
+func (k Keeper) GetValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) (rewards ValidatorHistoricalRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorHistoricalRewardsKey(valAddr, period))
+	if b == nil {
+		panic("Stored validator historical rewards should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &rewards)
+	return
+}
+
+// set the historical rewards of a validator at the end of a period
+func (k Keeper) SetValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period uint64, rewards ValidatorHistoricalRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(rewards)
+	store.Set(GetValidatorHistoricalRewardsKey(valAddr, period), b)
+}
+
+// delete the historical rewards of a validator at the end of a period
+func (k Keeper) DeleteValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorHistoricalRewardsKey(valAddr, period))
+}
+
+// delete all the historical rewards of a validator
+func (k Keeper) DeleteAllValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorHistoricalRewardsPrefix(valAddr))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// iterate over the historical rewards of all validators
+func (k Keeper) IterateValidatorHistoricalRewards(ctx sdk.Context,
+	fn func(valAddr sdk.ValAddress, period uint64, rewards ValidatorHistoricalRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorHistoricalRewardsKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var rewards ValidatorHistoricalRewards
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &rewards)
+		valAddr, period := GetValidatorHistoricalRewardsAddrPeriod(iterator.Key())
+		if fn(valAddr, period, rewards) {
+			break
+		}
+	}
+}
+
+//______________________________________________________________________
+
+// get the accumulated commission of a validator
+func (k Keeper) GetValidatorAccumulatedCommission(ctx sdk.Context, valAddr sdk.ValAddress) (commission sdk.DecCoins) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorAccumulatedCommissionKey(valAddr))
+	if b == nil {
+		return sdk.DecCoins{}
+	}
+	k.cdc.MustUnmarshalBinary(b, &commission)
+	return
+}
+
+// set the accumulated commission of a validator
+func (k Keeper) SetValidatorAccumulatedCommission(ctx sdk.Context, valAddr sdk.ValAddress, commission sdk.DecCoins) {
+	store := ctx.KVStore(k.storeKey)
+	if commission.IsZero() {
+		store.Delete(GetValidatorAccumulatedCommissionKey(valAddr))
+		return
+	}
+	b := k.cdc.MustMarshalBinary(commission)
+	store.Set(GetValidatorAccumulatedCommissionKey(valAddr), b)
+}
+
+// iterate over the accumulated commission of all validators
+func (k Keeper) IterateValidatorAccumulatedCommissions(ctx sdk.Context,
+	fn func(valAddr sdk.ValAddress, commission sdk.DecCoins) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorAccumulatedCommissionKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var commission sdk.DecCoins
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &commission)
+		if fn(sdk.ValAddress(iterator.Key()[1:]), commission) {
+			break
+		}
+	}
+}
+
+//______________________________________________________________________
+
+// get the starting info of a delegation
+func (k Keeper) GetDelegatorStartingInfo(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) (info DelegatorStartingInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegatorStartingInfoKey(valAddr, delAddr))
+	if b == nil {
+		panic("Stored delegator starting info should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &info)
+	return
+}
+
+// set the starting info of a delegation
+func (k Keeper) SetDelegatorStartingInfo(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress, info DelegatorStartingInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(info)
+	store.Set(GetDelegatorStartingInfoKey(valAddr, delAddr), b)
+}
+
+// check whether the starting info of a delegation exists
+func (k Keeper) HasDelegatorStartingInfo(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetDelegatorStartingInfoKey(valAddr, delAddr))
+}
+
+// delete the starting info of a delegation
+func (k Keeper) DeleteDelegatorStartingInfo(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegatorStartingInfoKey(valAddr, delAddr))
+}
+
+// iterate over the starting infos of all delegations
+func (k Keeper) IterateDelegatorStartingInfos(ctx sdk.Context,
+	fn func(valAddr sdk.ValAddress, delAddr sdk.AccAddress, info DelegatorStartingInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegatorStartingInfoKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var info DelegatorStartingInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		valAddr, delAddr := GetDelegatorStartingInfoAddrs(iterator.Key())
+		if fn(valAddr, delAddr, info) {
+			break
+		}
+	}
+}
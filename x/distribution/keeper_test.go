@@ -1,31 +1,32 @@
-package stake
+package distribution
 
-//// test if is a gotValidator from the last update
-//func TestGetTotalPrecommitVotingPower(t *testing.T) {
-//ctx, _, keeper := createTestInput(t, false, 0)
+import (
+	"testing"
 
-//amts := []int64{10000, 1000, 100, 10, 1}
-//var candidatesIn [5]Candidate
-//for i, amt := range amts {
-//candidatesIn[i] = NewCandidate(addrVals[i], pks[i], Description{})
-//candidatesIn[i].BondedShares = sdk.NewDec(amt)
-//candidatesIn[i].DelegatorShares = sdk.NewDec(amt)
-//keeper.setCandidate(ctx, candidatesIn[i])
-//}
+	"github.com/stretchr/testify/require"
 
-//// test that an empty gotValidator set doesn't have any gotValidators
-//gotValidators := keeper.GetValidators(ctx)
-//require.Equal(t, 5, len(gotValidators))
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
-//totPow := keeper.GetTotalPrecommitVotingPower(ctx)
-//exp := sdk.NewDec(11111)
-//require.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
+func TestDistributeFromFeePool(t *testing.T) {
+	ctx, ck, _, _, keeper := createTestInput(t)
+	recipient := sdk.AccAddress(addrs[0])
 
-//// set absent gotValidators to be the 1st and 3rd record sorted by pubKey address
-//ctx = ctx.WithAbsentValidators([]int32{1, 3})
-//totPow = keeper.GetTotalPrecommitVotingPower(ctx)
+	feePool := keeper.GetFeePool(ctx)
+	feePool.CommunityPool = sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(105, 1))}
+	keeper.SetFeePool(ctx, feePool)
 
-//// XXX verify that this order should infact exclude these two records
-//exp = sdk.NewDec(11100)
-//require.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
-//}
+	// the pool cannot cover more than it holds, nor other denoms
+	err := keeper.DistributeFromFeePool(ctx, sdk.Coins{sdk.NewInt64Coin("steak", 11)}, recipient)
+	require.NotNil(t, err)
+	err = keeper.DistributeFromFeePool(ctx, sdk.Coins{sdk.NewInt64Coin("photino", 1)}, recipient)
+	require.NotNil(t, err)
+	require.True(t, ck.GetCoins(ctx, recipient).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 200)}))
+
+	// spending from the pool sends the coins to the recipient
+	err = keeper.DistributeFromFeePool(ctx, sdk.Coins{sdk.NewInt64Coin("steak", 10)}, recipient)
+	require.Nil(t, err)
+	require.True(t, ck.GetCoins(ctx, recipient).IsEqual(sdk.Coins{sdk.NewInt64Coin("steak", 210)}))
+	remainder := sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(5, 1))}
+	require.True(t, remainder.IsEqual(keeper.GetFeePool(ctx).CommunityPool))
+}
@@ -0,0 +1,68 @@
+package distribution
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// key prefix bytes
+var (
+	FeePoolKey                        = []byte{0x00} // key for the global fee pool
+	ProposerKey                       = []byte{0x01} // key for the proposer of the previous block
+	ValidatorCurrentRewardsKey        = []byte{0x02} // prefix for the current rewards of each validator
+	ValidatorHistoricalRewardsKey     = []byte{0x03} // prefix for the historical rewards of each validator and period
+	ValidatorAccumulatedCommissionKey = []byte{0x04} // prefix for the accumulated commission of each validator
+	DelegatorStartingInfoKey          = []byte{0x05} // prefix for the starting info of each delegation
+)
+
+// gets the key for a validator's current rewards
+// VALUE: distribution/ValidatorCurrentRewards
+func GetValidatorCurrentRewardsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorCurrentRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the prefix for all the historical rewards of a validator
+func GetValidatorHistoricalRewardsPrefix(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorHistoricalRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the key for a validator's historical rewards at the end of a period
+// VALUE: distribution/ValidatorHistoricalRewards
+func GetValidatorHistoricalRewardsKey(valAddr sdk.ValAddress, period uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, period)
+	return append(GetValidatorHistoricalRewardsPrefix(valAddr), b...)
+}
+
+// gets the validator address and period from a historical rewards key
+func GetValidatorHistoricalRewardsAddrPeriod(key []byte) (valAddr sdk.ValAddress, period uint64) {
+	if len(key) != 1+sdk.AddrLen+8 {
+		panic("unexpected key length")
+	}
+	valAddr = sdk.ValAddress(key[1 : 1+sdk.AddrLen])
+	period = binary.BigEndian.Uint64(key[1+sdk.AddrLen:])
+	return
+}
+
+// gets the key for a validator's accumulated commission
+// VALUE: sdk.DecCoins
+func GetValidatorAccumulatedCommissionKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorAccumulatedCommissionKey, valAddr.Bytes()...)
+}
+
+// gets the key for the starting info of a delegation
+// VALUE: distribution/DelegatorStartingInfo
+func GetDelegatorStartingInfoKey(valAddr sdk.ValAddress, delAddr sdk.AccAddress) []byte {
+	return append(append(DelegatorStartingInfoKey, valAddr.Bytes()...), delAddr.Bytes()...)
+}
+
+// gets the validator and delegator addresses from a starting info key
+func GetDelegatorStartingInfoAddrs(key []byte) (valAddr sdk.ValAddress, delAddr sdk.AccAddress) {
+	if len(key) != 1+2*sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	valAddr = sdk.ValAddress(key[1 : 1+sdk.AddrLen])
+	delAddr = sdk.AccAddress(key[1+sdk.AddrLen:])
+	return
+}
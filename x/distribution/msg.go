@@ -0,0 +1,92 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var cdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "distr"
+
+// verify interface at compile time
+var _, _ sdk.Msg = &MsgWithdrawDelegatorReward{}, &MsgWithdrawValidatorCommission{}
+
+//______________________________________________________________________
+
+// MsgWithdrawDelegatorReward - struct for withdrawing the rewards of a delegation
+type MsgWithdrawDelegatorReward struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+}
+
+func NewMsgWithdrawDelegatorReward(delAddr sdk.AccAddress, valAddr sdk.ValAddress) MsgWithdrawDelegatorReward {
+	return MsgWithdrawDelegatorReward{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawDelegatorReward) Type() string { return MsgType }
+func (msg MsgWithdrawDelegatorReward) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawDelegatorReward) GetSignBytes() []byte {
+	b, err := cdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgWithdrawDelegatorReward) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgWithdrawValidatorCommission - struct for withdrawing the accumulated
+// commission of a validator
+type MsgWithdrawValidatorCommission struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+}
+
+func NewMsgWithdrawValidatorCommission(valAddr sdk.ValAddress) MsgWithdrawValidatorCommission {
+	return MsgWithdrawValidatorCommission{
+		ValidatorAddr: valAddr,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawValidatorCommission) Type() string { return MsgType }
+func (msg MsgWithdrawValidatorCommission) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawValidatorCommission) GetSignBytes() []byte {
+	b, err := cdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgWithdrawValidatorCommission) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMsgWithdrawDelegatorRewardValidateBasic(t *testing.T) {
+	delAddr, valAddr := sdk.AccAddress("abcd"), sdk.ValAddress("efgh")
+	require.Nil(t, NewMsgWithdrawDelegatorReward(delAddr, valAddr).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawDelegatorReward(nil, valAddr).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawDelegatorReward(delAddr, nil).ValidateBasic())
+}
+
+func TestMsgWithdrawValidatorCommissionValidateBasic(t *testing.T) {
+	require.Nil(t, NewMsgWithdrawValidatorCommission(sdk.ValAddress("abcd")).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawValidatorCommission(nil).ValidateBasic())
+}
+
+func TestMsgWithdrawValidatorCommissionGetSignBytes(t *testing.T) {
+	msg := NewMsgWithdrawValidatorCommission(sdk.ValAddress("abcd"))
+	bytes := msg.GetSignBytes()
+	require.Equal(t, `{"validator_addr":"cosmosval1v93xxeq7xkcrf"}`, string(bytes))
+}
@@ -0,0 +1,74 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// nolint
+const (
+	CommunityTaxKey        = "distribution/CommunityTax"
+	BaseProposerRewardKey  = "distribution/BaseProposerReward"
+	BonusProposerRewardKey = "distribution/BonusProposerReward"
+)
+
+// RegisterParamTypes registers the types of the distribution params with the
+// param store, so they can be changed through governance
+func RegisterParamTypes(k params.Keeper) {
+	getter := k.Getter()
+	for _, key := range []string{CommunityTaxKey, BaseProposerRewardKey, BonusProposerRewardKey} {
+		k.RegisterType(key, sdk.Dec{}, rewardFractionValidator(getter, key))
+	}
+}
+
+// rewardFractionValidator returns the validator of the fraction of the
+// collected rewards under key, which must be between 0 and 1 and, summed with
+// the current other fractions, not exceed 1
+func rewardFractionValidator(getter params.Getter, key string) params.ValidateFn {
+	return func(ctx sdk.Context, value interface{}) error {
+		fraction := value.(sdk.Dec)
+		if fraction.IsNil() || fraction.LT(sdk.ZeroDec()) || fraction.GT(sdk.OneDec()) {
+			return fmt.Errorf("must be between 0 and 1, got %v", fraction)
+		}
+
+		fractions := map[string]sdk.Dec{
+			CommunityTaxKey:        getter.GetDecWithDefault(ctx, CommunityTaxKey, defaultCommunityTax),
+			BaseProposerRewardKey:  getter.GetDecWithDefault(ctx, BaseProposerRewardKey, defaultBaseProposerReward),
+			BonusProposerRewardKey: getter.GetDecWithDefault(ctx, BonusProposerRewardKey, defaultBonusProposerReward),
+		}
+		fractions[key] = fraction
+		total := sdk.ZeroDec()
+		for _, f := range fractions {
+			total = total.Add(f)
+		}
+		if total.GT(sdk.OneDec()) {
+			return fmt.Errorf("community tax and proposer rewards sum to %v, more than 1", total)
+		}
+		return nil
+	}
+}
+
+// CommunityTax - fraction of the collected rewards sent to the community pool, default 2%
+func (k Keeper) CommunityTax(ctx sdk.Context) sdk.Dec {
+	return k.params.GetDecWithDefault(ctx, CommunityTaxKey, defaultCommunityTax)
+}
+
+// BaseProposerReward - fraction of the collected rewards always given to the block proposer, default 1%
+func (k Keeper) BaseProposerReward(ctx sdk.Context) sdk.Dec {
+	return k.params.GetDecWithDefault(ctx, BaseProposerRewardKey, defaultBaseProposerReward)
+}
+
+// BonusProposerReward - maximum additional fraction of the collected rewards
+// given to the block proposer, scaled by the fraction of precommits it
+// included, default 4%
+func (k Keeper) BonusProposerReward(ctx sdk.Context) sdk.Dec {
+	return k.params.GetDecWithDefault(ctx, BonusProposerRewardKey, defaultBonusProposerReward)
+}
+
+var (
+	defaultCommunityTax        = sdk.NewDecWithPrec(2, 2)
+	defaultBaseProposerReward  = sdk.NewDecWithPrec(1, 2)
+	defaultBonusProposerReward = sdk.NewDecWithPrec(4, 2)
+)
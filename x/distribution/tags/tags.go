@@ -0,0 +1,15 @@
+// nolint
+package tags
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	ActionWithdrawDelegatorReward     = []byte("withdraw-delegator-reward")
+	ActionWithdrawValidatorCommission = []byte("withdraw-validator-commission")
+
+	Action    = sdk.TagAction
+	Validator = sdk.TagSrcValidator
+	Delegator = sdk.TagDelegator
+)
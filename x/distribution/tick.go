@@ -0,0 +1,30 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// distribution begin block functionality, allocates the fees collected in the
+// previous block and records the proposer of the current block
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) {
+
+	// determine the total power signing the block
+	var totalPower, sumPrecommitPower int64
+	for _, voteInfo := range req.LastCommitInfo.GetValidators() {
+		totalPower += voteInfo.Validator.Power
+		if voteInfo.SignedLastBlock {
+			sumPrecommitPower += voteInfo.Validator.Power
+		}
+	}
+
+	// there is no previous proposer and nothing collected yet in the first block
+	if ctx.BlockHeight() > 1 {
+		previousProposer := k.GetPreviousProposerConsAddr(ctx)
+		k.AllocateTokens(ctx, sumPrecommitPower, totalPower, previousProposer, req.LastCommitInfo.GetValidators())
+	}
+
+	// record the proposer for when we payout on the next block
+	consAddr := sdk.ConsAddress(req.Header.Proposer.Address)
+	k.SetPreviousProposerConsAddr(ctx, consAddr)
+}
@@ -0,0 +1,125 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// initialize rewards for a new validator
+func (k Keeper) initializeValidator(ctx sdk.Context, valAddr sdk.ValAddress) {
+	// set initial historical rewards (period 0) with a reference count of 1,
+	// the reference being held by the current period
+	k.SetValidatorHistoricalRewards(ctx, valAddr, 0, ValidatorHistoricalRewards{
+		CumulativeRewardRatio: sdk.DecCoins{},
+		ReferenceCount:        1,
+	})
+
+	// set current rewards (starting at period 1)
+	k.SetValidatorCurrentRewards(ctx, valAddr, ValidatorCurrentRewards{
+		Rewards: sdk.DecCoins{},
+		Period:  1,
+	})
+
+	// set accumulated commission
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, sdk.DecCoins{})
+}
+
+// increment the period of a validator, returning the period just ended
+func (k Keeper) incrementValidatorPeriod(ctx sdk.Context, valAddr sdk.ValAddress) uint64 {
+	validator := k.validatorSet.Validator(ctx, valAddr)
+	if validator == nil {
+		panic(fmt.Sprintf("validator record not found for address: %v\n", valAddr))
+	}
+
+	// fetch current rewards
+	rewards := k.GetValidatorCurrentRewards(ctx, valAddr)
+
+	// calculate current ratio
+	var current sdk.DecCoins
+	shares := validator.GetDelegatorShares()
+	if shares.IsZero() {
+		// can't calculate ratio for zero-share validators
+		// ergo we instead add to the community pool
+		feePool := k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Plus(rewards.Rewards)
+		k.SetFeePool(ctx, feePool)
+
+		current = sdk.DecCoins{}
+	} else {
+		// rewards per delegator share accrued during the period
+		current = rewards.Rewards.QuoDec(shares)
+	}
+
+	// fetch historical rewards for last period
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, rewards.Period-1).CumulativeRewardRatio
+
+	// decrement reference count
+	k.decrementReferenceCount(ctx, valAddr, rewards.Period-1)
+
+	// set new historical rewards with reference count of 1
+	k.SetValidatorHistoricalRewards(ctx, valAddr, rewards.Period, ValidatorHistoricalRewards{
+		CumulativeRewardRatio: historical.Plus(current),
+		ReferenceCount:        1,
+	})
+
+	// set current rewards, incrementing period by 1
+	k.SetValidatorCurrentRewards(ctx, valAddr, ValidatorCurrentRewards{
+		Rewards: sdk.DecCoins{},
+		Period:  rewards.Period + 1,
+	})
+
+	return rewards.Period
+}
+
+// increment the reference count for a historical rewards value
+func (k Keeper) incrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount > 2 {
+		panic("reference count should never exceed 2")
+	}
+	historical.ReferenceCount++
+	k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+}
+
+// decrement the reference count for a historical rewards value, and delete if zero references remain
+func (k Keeper) decrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount == 0 {
+		panic("cannot set negative reference count")
+	}
+	historical.ReferenceCount--
+	if historical.ReferenceCount == 0 {
+		k.DeleteValidatorHistoricalRewards(ctx, valAddr, period)
+	} else {
+		k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+	}
+}
+
+// withdraw the accumulated commission of a validator to its operator account
+func (k Keeper) WithdrawValidatorCommission(ctx sdk.Context, valAddr sdk.ValAddress) (sdk.Coins, sdk.Error) {
+	if !k.HasValidatorCurrentRewards(ctx, valAddr) {
+		return nil, ErrNoValidatorDistInfo(k.codespace)
+	}
+
+	// fetch validator accumulated commission
+	commission := k.GetValidatorAccumulatedCommission(ctx, valAddr)
+	if commission.IsZero() {
+		return nil, ErrNoValidatorCommission(k.codespace)
+	}
+
+	coins, remainder := commission.TruncateDecimal()
+
+	// leave remainder to withdraw later
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, remainder)
+
+	// add to validator account
+	if !coins.IsZero() {
+		accAddr := sdk.AccAddress(valAddr)
+		if _, _, err := k.bankKeeper.AddCoins(ctx, accAddr, coins); err != nil {
+			return nil, err
+		}
+	}
+
+	return coins, nil
+}
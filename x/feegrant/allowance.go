@@ -0,0 +1,168 @@
+package feegrant
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllowance is the fee allowance a granter grants to a grantee, which
+// limits the fees the granter pays on behalf of the grantee.
+type FeeAllowance interface {
+	// Accept checks whether the fee can be paid at blockTime. It returns the
+	// allowance updated for the fee, and whether it is used up or expired and
+	// must be removed.
+	Accept(fee sdk.Coins, blockTime time.Time) (updated FeeAllowance, remove bool, err sdk.Error)
+
+	// ValidateBasic performs a stateless validity check of the allowance
+	ValidateBasic() sdk.Error
+}
+
+var _ FeeAllowance = BasicFeeAllowance{}
+var _ FeeAllowance = PeriodicFeeAllowance{}
+
+//______________________________________________________________________
+
+// BasicFeeAllowance allows the grantee to spend fees up to SpendLimit, or
+// without limit if SpendLimit is empty, until Expiration if it is set.
+type BasicFeeAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// NewBasicFeeAllowance returns an allowance of the given spend limit, which
+// never expires if expiration is the zero time.
+func NewBasicFeeAllowance(spendLimit sdk.Coins, expiration time.Time) BasicFeeAllowance {
+	return BasicFeeAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Accept implements FeeAllowance
+func (a BasicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time) (FeeAllowance, bool, sdk.Error) {
+	if a.isExpired(blockTime) {
+		return a, true, ErrFeeLimitExpired(DefaultCodespace)
+	}
+
+	if a.SpendLimit.IsZero() {
+		return a, false, nil
+	}
+
+	left := a.SpendLimit.Minus(fee)
+	if !left.IsNotNegative() {
+		return a, false, ErrFeeLimitExceeded(DefaultCodespace,
+			fmt.Sprintf("fee %v exceeds the spend limit %v", fee, a.SpendLimit))
+	}
+	a.SpendLimit = left
+	return a, left.IsZero(), nil
+}
+
+// ValidateBasic implements FeeAllowance
+func (a BasicFeeAllowance) ValidateBasic() sdk.Error {
+	if !a.SpendLimit.IsValid() {
+		return ErrInvalidAllowance(DefaultCodespace, fmt.Sprintf("invalid spend limit %v", a.SpendLimit))
+	}
+	return nil
+}
+
+func (a BasicFeeAllowance) isExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+//______________________________________________________________________
+
+// PeriodicFeeAllowance allows the grantee to spend fees up to
+// PeriodSpendLimit each Period, within the limits of Basic. PeriodCanSpend is
+// what is left to spend in the current period, which ends at PeriodReset.
+type PeriodicFeeAllowance struct {
+	Basic            BasicFeeAllowance `json:"basic"`
+	Period           time.Duration     `json:"period"`
+	PeriodSpendLimit sdk.Coins         `json:"period_spend_limit"`
+	PeriodCanSpend   sdk.Coins         `json:"period_can_spend"`
+	PeriodReset      time.Time         `json:"period_reset"`
+}
+
+// NewPeriodicFeeAllowance returns an allowance of periodSpendLimit each
+// period, the first period starting at the first use of the allowance.
+func NewPeriodicFeeAllowance(basic BasicFeeAllowance, period time.Duration, periodSpendLimit sdk.Coins) PeriodicFeeAllowance {
+	return PeriodicFeeAllowance{
+		Basic:            basic,
+		Period:           period,
+		PeriodSpendLimit: periodSpendLimit,
+	}
+}
+
+// Accept implements FeeAllowance
+func (a PeriodicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time) (FeeAllowance, bool, sdk.Error) {
+	if a.Basic.isExpired(blockTime) {
+		return a, true, ErrFeeLimitExpired(DefaultCodespace)
+	}
+
+	a.tryResetPeriod(blockTime)
+
+	left := a.PeriodCanSpend.Minus(fee)
+	if !left.IsNotNegative() {
+		return a, false, ErrFeeLimitExceeded(DefaultCodespace,
+			fmt.Sprintf("fee %v exceeds the period spend limit left %v", fee, a.PeriodCanSpend))
+	}
+
+	basic, remove, err := a.Basic.Accept(fee, blockTime)
+	if err != nil {
+		return a, remove, err
+	}
+	a.Basic = basic.(BasicFeeAllowance)
+	a.PeriodCanSpend = left
+	return a, remove, nil
+}
+
+// tryResetPeriod refills what can be spent once the period is over, and
+// starts the next period
+func (a *PeriodicFeeAllowance) tryResetPeriod(blockTime time.Time) {
+	if blockTime.Before(a.PeriodReset) {
+		return
+	}
+
+	a.PeriodCanSpend = a.PeriodSpendLimit
+	// never allow more than what is left of the basic spend limit
+	if !a.Basic.SpendLimit.IsZero() {
+		a.PeriodCanSpend = minCoins(a.PeriodSpendLimit, a.Basic.SpendLimit)
+	}
+
+	// periods follow each other, unless the allowance was left unused for
+	// more than a period
+	a.PeriodReset = a.PeriodReset.Add(a.Period)
+	if blockTime.After(a.PeriodReset) {
+		a.PeriodReset = blockTime.Add(a.Period)
+	}
+}
+
+// ValidateBasic implements FeeAllowance
+func (a PeriodicFeeAllowance) ValidateBasic() sdk.Error {
+	if err := a.Basic.ValidateBasic(); err != nil {
+		return err
+	}
+	if !a.PeriodSpendLimit.IsValid() || a.PeriodSpendLimit.IsZero() {
+		return ErrInvalidAllowance(DefaultCodespace, fmt.Sprintf("invalid period spend limit %v", a.PeriodSpendLimit))
+	}
+	if !a.Basic.SpendLimit.IsZero() && !a.Basic.SpendLimit.IsGTE(a.PeriodSpendLimit) {
+		return ErrInvalidAllowance(DefaultCodespace, "period spend limit exceeds the spend limit")
+	}
+	if a.Period <= 0 {
+		return ErrInvalidAllowance(DefaultCodespace, "period must be positive")
+	}
+	return nil
+}
+
+// minCoins returns the coins of a, each capped by its amount in b
+func minCoins(a, b sdk.Coins) sdk.Coins {
+	var min sdk.Coins
+	for _, coin := range a {
+		amount := sdk.MinInt(coin.Amount, b.AmountOf(coin.Denom))
+		if amount.Sign() > 0 {
+			min = append(min, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return min
+}
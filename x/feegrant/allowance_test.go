@@ -0,0 +1,68 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBasicFeeAllowance(t *testing.T) {
+	now := time.Unix(1000, 0)
+	atom := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("atom", amount)} }
+
+	// an allowance without spend limit nor expiration is never removed
+	unlimited := NewBasicFeeAllowance(nil, time.Time{})
+	require.Nil(t, unlimited.ValidateBasic())
+	updated, remove, err := unlimited.Accept(atom(1000), now)
+	require.Nil(t, err)
+	require.False(t, remove)
+	require.Equal(t, unlimited, updated)
+
+	limited := NewBasicFeeAllowance(atom(10), now.Add(time.Hour))
+	_, _, err = limited.Accept(atom(11), now)
+	require.NotNil(t, err)
+	updated, remove, err = limited.Accept(atom(10), now)
+	require.Nil(t, err)
+	require.True(t, remove)
+	_, remove, err = limited.Accept(atom(1), now.Add(time.Hour))
+	require.NotNil(t, err)
+	require.True(t, remove)
+
+	require.NotNil(t, NewBasicFeeAllowance(sdk.Coins{sdk.NewInt64Coin("atom", 0)}, time.Time{}).ValidateBasic())
+}
+
+func TestPeriodicFeeAllowance(t *testing.T) {
+	now := time.Unix(1000, 0)
+	atom := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("atom", amount)} }
+
+	allowance := NewPeriodicFeeAllowance(NewBasicFeeAllowance(atom(25), time.Time{}), time.Hour, atom(10))
+	require.Nil(t, allowance.ValidateBasic())
+	require.NotNil(t, NewPeriodicFeeAllowance(NewBasicFeeAllowance(atom(5), time.Time{}), time.Hour, atom(10)).ValidateBasic())
+	require.NotNil(t, NewPeriodicFeeAllowance(NewBasicFeeAllowance(nil, time.Time{}), 0, atom(10)).ValidateBasic())
+
+	// the first period starts at the first use
+	updated, remove, err := allowance.Accept(atom(6), now)
+	require.Nil(t, err)
+	require.False(t, remove)
+	periodic := updated.(PeriodicFeeAllowance)
+	require.Equal(t, atom(4), periodic.PeriodCanSpend)
+	require.Equal(t, now.Add(time.Hour), periodic.PeriodReset)
+
+	// the period limit applies until the period is over
+	_, _, err = periodic.Accept(atom(5), now.Add(time.Minute))
+	require.NotNil(t, err)
+	updated, _, err = periodic.Accept(atom(10), now.Add(time.Hour))
+	require.Nil(t, err)
+	periodic = updated.(PeriodicFeeAllowance)
+	require.Equal(t, atom(9), periodic.Basic.SpendLimit)
+	require.Equal(t, now.Add(2*time.Hour), periodic.PeriodReset)
+
+	// a period never refills more than what is left of the spend limit
+	updated, remove, err = periodic.Accept(atom(9), now.Add(5*time.Hour))
+	require.Nil(t, err)
+	require.True(t, remove)
+	require.Equal(t, now.Add(6*time.Hour), updated.(PeriodicFeeAllowance).PeriodReset)
+}
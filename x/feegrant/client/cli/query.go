@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// GetCmdQueryFeeAllowance implements the query fee allowance command.
+func GetCmdQueryFeeAllowance(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowance [grantee]",
+		Short: "Query the fee allowance --granter granted to an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(viper.GetString(flagGranter))
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := feegrant.QueryAllowanceParams{
+				Granter: granter,
+				Grantee: grantee,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, feegrant.QueryAllowance), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGranter, "", "Address of the account which granted the allowance")
+
+	return cmd
+}
+
+// GetCmdQueryFeeAllowances implements the query fee allowances command.
+func GetCmdQueryFeeAllowances(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowances [grantee]",
+		Short: "Query all the fee allowances granted to an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := feegrant.QueryAllowancesParams{
+				Grantee: grantee,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, feegrant.QueryAllowances), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+const (
+	flagSpendLimit  = "spend-limit"
+	flagExpiration  = "expiration"
+	flagPeriod      = "period"
+	flagPeriodLimit = "period-limit"
+	flagGranter     = "granter"
+)
+
+// GetCmdGrantFeeAllowance implements the grant fee allowance command.
+func GetCmdGrantFeeAllowance(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee]",
+		Short: "Grant a fee allowance to an account",
+		Long: `Grant an allowance to pay fees on behalf of the sender to the grantee, replacing
+any allowance granted to it before. The allowance is limited to --spend-limit,
+or unlimited if it is not set, until --expiration if it is set. With --period,
+the allowance refills up to --period-limit every period.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := buildFeeAllowance()
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgGrantFeeAllowance(granter, grantee, allowance)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagSpendLimit, "", "Maximum fees the grantee can spend in total")
+	cmd.Flags().String(flagExpiration, "", "Time the allowance expires at, in RFC3339 format")
+	cmd.Flags().Duration(flagPeriod, 0, "Period after which the allowance refills, e.g. 24h")
+	cmd.Flags().String(flagPeriodLimit, "", "Maximum fees the grantee can spend each period")
+
+	return cmd
+}
+
+// build the fee allowance from the flags
+func buildFeeAllowance() (feegrant.FeeAllowance, error) {
+	spendLimit, err := sdk.ParseCoins(viper.GetString(flagSpendLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	var expiration time.Time
+	if expirationStr := viper.GetString(flagExpiration); expirationStr != "" {
+		expiration, err = time.Parse(time.RFC3339, expirationStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	basic := feegrant.NewBasicFeeAllowance(spendLimit, expiration.UTC())
+	period := viper.GetDuration(flagPeriod)
+	if period == 0 {
+		return basic, nil
+	}
+
+	periodLimit, err := sdk.ParseCoins(viper.GetString(flagPeriodLimit))
+	if err != nil {
+		return nil, err
+	}
+	return feegrant.NewPeriodicFeeAllowance(basic, period, periodLimit), nil
+}
+
+// GetCmdRevokeFeeAllowance implements the revoke fee allowance command.
+func GetCmdRevokeFeeAllowance(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [grantee]",
+		Short: "Revoke the fee allowance granted to an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgRevokeFeeAllowance(granter, grantee)
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
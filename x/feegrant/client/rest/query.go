@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+
+	"github.com/gorilla/mux"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/feegrant/allowance/{granter}/{grantee}",
+		allowanceHandlerFn(cliCtx, "feegrant", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/feegrant/allowances/{grantee}",
+		allowancesHandlerFn(cliCtx, "feegrant", cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the allowance a granter grants to a grantee
+func allowanceHandlerFn(cliCtx context.CLIContext, queryRoute string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		granter, err := sdk.AccAddressFromBech32(vars["granter"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(vars["grantee"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(feegrant.QueryAllowanceParams{Granter: granter, Grantee: grantee})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/"+queryRoute+"/"+feegrant.QueryAllowance, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Write(res)
+	}
+}
+
+// http request handler to query all allowances granted to a grantee
+func allowancesHandlerFn(cliCtx context.CLIContext, queryRoute string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		grantee, err := sdk.AccAddressFromBech32(vars["grantee"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(feegrant.QueryAllowancesParams{Grantee: grantee})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/"+queryRoute+"/"+feegrant.QueryAllowances, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Write(res)
+	}
+}
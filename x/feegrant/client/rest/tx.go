@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+
+	"github.com/gorilla/mux"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
+	r.HandleFunc(
+		"/feegrant/grant",
+		grantRequestHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/feegrant/revoke",
+		revokeRequestHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+}
+
+type baseBody struct {
+	LocalAccountName string `json:"name"`
+	Password         string `json:"password"`
+	ChainID          string `json:"chain_id"`
+	AccountNumber    int64  `json:"account_number"`
+	Sequence         int64  `json:"sequence"`
+	Gas              int64  `json:"gas"`
+	GasAdjustment    string `json:"gas_adjustment"`
+}
+
+// Grant fee allowance TX body
+type GrantBody struct {
+	BaseBody  baseBody              `json:"base_req"`
+	Grantee   string                `json:"grantee"`
+	Allowance feegrant.FeeAllowance `json:"allowance"`
+}
+
+// Revoke fee allowance TX body
+type RevokeBody struct {
+	BaseBody baseBody `json:"base_req"`
+	Grantee  string   `json:"grantee"`
+}
+
+func grantRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m GrantBody
+		if !readBody(w, r, cdc, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.BaseBody.LocalAccountName)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(m.Grantee)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := feegrant.NewMsgGrantFeeAllowance(sdk.AccAddress(info.GetPubKey().Address()), grantee, m.Allowance)
+		if err := msg.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		signAndBroadcast(w, r, cdc, cliCtx, m.BaseBody, msg)
+	}
+}
+
+func revokeRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m RevokeBody
+		if !readBody(w, r, cdc, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.BaseBody.LocalAccountName)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(m.Grantee)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := feegrant.NewMsgRevokeFeeAllowance(sdk.AccAddress(info.GetPubKey().Address()), grantee)
+		if err := msg.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		signAndBroadcast(w, r, cdc, cliCtx, m.BaseBody, msg)
+	}
+}
+
+// readBody decodes the request body with amino, so that the allowance
+// interface can be decoded
+func readBody(w http.ResponseWriter, r *http.Request, cdc *wire.Codec, m interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	err = cdc.UnmarshalJSON(body, m)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func signAndBroadcast(w http.ResponseWriter, r *http.Request, cdc *wire.Codec, cliCtx context.CLIContext, m baseBody, msg sdk.Msg) {
+	txCtx := authctx.TxContext{
+		Codec:         cdc,
+		ChainID:       m.ChainID,
+		AccountNumber: m.AccountNumber,
+		Sequence:      m.Sequence,
+		Gas:           m.Gas,
+	}
+
+	adjustment, ok := utils.ParseFloat64OrReturnBadRequest(w, m.GasAdjustment, client.DefaultGasAdjustment)
+	if !ok {
+		return
+	}
+	cliCtx = cliCtx.WithGasAdjustment(adjustment)
+
+	if utils.HasDryRunArg(r) || m.Gas == 0 {
+		newCtx, err := utils.EnrichCtxWithGas(txCtx, cliCtx, m.LocalAccountName, []sdk.Msg{msg})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if utils.HasDryRunArg(r) {
+			utils.WriteSimulationResponse(w, newCtx.Gas)
+			return
+		}
+		txCtx = newCtx
+	}
+
+	if utils.HasGenerateOnlyArg(r) {
+		utils.WriteGenerateStdTxResponse(w, txCtx, []sdk.Msg{msg})
+		return
+	}
+
+	txBytes, err := txCtx.BuildAndSign(m.LocalAccountName, m.Password, []sdk.Msg{msg})
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	res, err := cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	output, err := wire.MarshalJSONIndent(cdc, res)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Write(output)
+}
@@ -0,0 +1,37 @@
+//nolint
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default fee grant codespace
+	DefaultCodespace sdk.CodespaceType = 8
+
+	CodeInvalidAllowance CodeType = 101
+	CodeNoAllowance      CodeType = 102
+	CodeFeeLimitExceeded CodeType = 103
+	CodeFeeLimitExpired  CodeType = 104
+)
+
+func ErrInvalidAllowance(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAllowance, msg)
+}
+
+func ErrNoAllowance(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAllowance, fmt.Sprintf("%s has not granted a fee allowance to %s", granter, grantee))
+}
+
+func ErrFeeLimitExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeLimitExceeded, msg)
+}
+
+func ErrFeeLimitExpired(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeLimitExpired, "fee allowance expired")
+}
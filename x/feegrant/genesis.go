@@ -0,0 +1,32 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all the fee allowances at genesis
+type GenesisState struct {
+	Grants []FeeAllowanceGrant `json:"grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the genesis fee allowances
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Grants {
+		k.GrantFeeAllowance(ctx, grant.Granter, grant.Grantee, grant.Allowance)
+	}
+}
+
+// WriteGenesis - output all the fee allowances
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []FeeAllowanceGrant
+	k.IterateAllFeeAllowances(ctx, func(grant FeeAllowanceGrant) (stop bool) {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{grants}
+}
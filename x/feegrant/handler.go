@@ -0,0 +1,48 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "feegrant" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantFeeAllowance:
+			return handleMsgGrantFeeAllowance(ctx, k, msg)
+		case MsgRevokeFeeAllowance:
+			return handleMsgRevokeFeeAllowance(ctx, k, msg)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in feegrant module").Result()
+		}
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k Keeper, msg MsgGrantFeeAllowance) sdk.Result {
+	k.GrantFeeAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance)
+
+	tags := sdk.NewTags(
+		"action", []byte("grantFeeAllowance"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k Keeper, msg MsgRevokeFeeAllowance) sdk.Result {
+	err := k.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"action", []byte("revokeFeeAllowance"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
@@ -0,0 +1,117 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// FeeAllowanceGrant is the fee allowance granter granted to grantee
+type FeeAllowanceGrant struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// Keeper of the fee grant store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+var _ auth.FeeGrantKeeper = Keeper{}
+
+// NewKeeper creates a fee grant keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+// return the codespace
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+// GrantFeeAllowance grants the allowance to grantee, replacing any allowance
+// granter granted to it before
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress, allowance FeeAllowance) {
+	store := ctx.KVStore(k.storeKey)
+	grant := FeeAllowanceGrant{granter, grantee, allowance}
+	store.Set(GetFeeAllowanceKey(granter, grantee), k.cdc.MustMarshalBinary(grant))
+}
+
+// RevokeFeeAllowance removes the allowance granter granted to grantee
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetFeeAllowanceKey(granter, grantee)
+	if !store.Has(key) {
+		return ErrNoAllowance(k.codespace, granter, grantee)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetFeeAllowance returns the allowance granter granted to grantee, nil if
+// there is none
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) FeeAllowance {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return nil
+	}
+	var grant FeeAllowanceGrant
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant.Allowance
+}
+
+// GetFeeGrants returns the fee allowances granted to grantee
+func (k Keeper) GetFeeGrants(ctx sdk.Context, grantee sdk.AccAddress) (grants []FeeAllowanceGrant) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetFeeAllowancesKey(grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// IterateAllFeeAllowances iterates over all the fee allowances, stopping when
+// the handler returns true
+func (k Keeper) IterateAllFeeAllowances(ctx sdk.Context, handler func(grant FeeAllowanceGrant) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, FeeAllowanceKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		if handler(grant) {
+			break
+		}
+	}
+}
+
+// UseGrantedFees charges fee to the allowance granter granted to grantee,
+// removing the allowance once it is used up or expired. Implements
+// auth.FeeGrantKeeper.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	allowance := k.GetFeeAllowance(ctx, granter, grantee)
+	if allowance == nil {
+		return ErrNoAllowance(k.codespace, granter, grantee)
+	}
+
+	updated, remove, err := allowance.Accept(fee, ctx.BlockHeader().Time)
+	if remove {
+		k.RevokeFeeAllowance(ctx, granter, grantee) // nolint: errcheck
+	} else if err == nil {
+		k.GrantFeeAllowance(ctx, granter, grantee, updated)
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var (
+	addr1 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr2 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr3 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyFeeGrant := sdk.NewKVStoreKey("feegrant")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyFeeGrant, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0).UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, keyFeeGrant, DefaultCodespace)
+}
+
+func TestGrantRevokeFeeAllowance(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	allowance := NewBasicFeeAllowance(sdk.Coins{sdk.NewInt64Coin("atom", 10)}, expiration)
+
+	require.Nil(t, keeper.GetFeeAllowance(ctx, addr1, addr2))
+	require.NotNil(t, keeper.RevokeFeeAllowance(ctx, addr1, addr2))
+
+	keeper.GrantFeeAllowance(ctx, addr1, addr2, allowance)
+	keeper.GrantFeeAllowance(ctx, addr3, addr2, allowance)
+	keeper.GrantFeeAllowance(ctx, addr2, addr1, allowance)
+	require.Equal(t, allowance, keeper.GetFeeAllowance(ctx, addr1, addr2))
+	require.Nil(t, keeper.GetFeeAllowance(ctx, addr2, addr3))
+
+	// the allowances are listed by grantee
+	grants := keeper.GetFeeGrants(ctx, addr2)
+	require.Len(t, grants, 2)
+	for _, grant := range grants {
+		require.Equal(t, addr2, grant.Grantee)
+	}
+	require.Len(t, WriteGenesis(ctx, keeper).Grants, 3)
+
+	require.Nil(t, keeper.RevokeFeeAllowance(ctx, addr1, addr2))
+	require.Nil(t, keeper.GetFeeAllowance(ctx, addr1, addr2))
+	require.Len(t, keeper.GetFeeGrants(ctx, addr2), 1)
+}
+
+func TestUseGrantedFees(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	allowance := NewBasicFeeAllowance(sdk.Coins{sdk.NewInt64Coin("atom", 10)}, expiration)
+
+	require.NotNil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 1)}))
+	keeper.GrantFeeAllowance(ctx, addr1, addr2, allowance)
+
+	// fees above the allowance are rejected, the allowance is unchanged
+	require.NotNil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 11)}))
+	require.NotNil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 1)}))
+	require.Equal(t, allowance, keeper.GetFeeAllowance(ctx, addr1, addr2))
+
+	require.Nil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 4)}))
+	expected := NewBasicFeeAllowance(sdk.Coins{sdk.NewInt64Coin("atom", 6)}, expiration)
+	require.Equal(t, expected, keeper.GetFeeAllowance(ctx, addr1, addr2))
+
+	// the allowance is removed once used up
+	require.Nil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 6)}))
+	require.Nil(t, keeper.GetFeeAllowance(ctx, addr1, addr2))
+
+	// or once expired
+	keeper.GrantFeeAllowance(ctx, addr1, addr2, allowance)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: expiration})
+	require.NotNil(t, keeper.UseGrantedFees(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 1)}))
+	require.Nil(t, keeper.GetFeeAllowance(ctx, addr1, addr2))
+}
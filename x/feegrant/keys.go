@@ -0,0 +1,20 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// key prefix bytes
+var (
+	FeeAllowanceKeyPrefix = []byte{0x00} // prefix for the fee allowances, by grantee then granter
+)
+
+// key for the fee allowance granter granted to grantee
+func GetFeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(GetFeeAllowancesKey(grantee), granter.Bytes()...)
+}
+
+// prefix of the keys of the fee allowances granted to grantee
+func GetFeeAllowancesKey(grantee sdk.AccAddress) []byte {
+	return append(FeeAllowanceKeyPrefix, grantee.Bytes()...)
+}
@@ -0,0 +1,106 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "feegrant"
+
+// verify interface at compile time
+var _, _ sdk.Msg = MsgGrantFeeAllowance{}, MsgRevokeFeeAllowance{}
+
+//______________________________________________________________________
+
+// MsgGrantFeeAllowance grants a fee allowance from Granter to Grantee,
+// replacing any allowance granted before
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+func NewMsgGrantFeeAllowance(granter, grantee sdk.AccAddress, allowance FeeAllowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+//nolint
+func (msg MsgGrantFeeAllowance) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if msg.Granter.Equals(msg.Grantee) {
+		return ErrInvalidAllowance(DefaultCodespace, "cannot grant a fee allowance to oneself")
+	}
+	if msg.Allowance == nil {
+		return ErrInvalidAllowance(DefaultCodespace, "missing fee allowance")
+	}
+	return msg.Allowance.ValidateBasic()
+}
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgRevokeFeeAllowance removes the fee allowance Granter granted to Grantee
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+//nolint
+func (msg MsgRevokeFeeAllowance) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
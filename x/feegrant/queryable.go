@@ -0,0 +1,77 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the feegrant Querier
+const (
+	QueryAllowance  = "allowance"
+	QueryAllowances = "allowances"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAllowance:
+			return queryAllowance(ctx, path[1:], req, keeper)
+		case QueryAllowances:
+			return queryAllowances(ctx, path[1:], req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown feegrant query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/feegrant/allowance'
+type QueryAllowanceParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+func queryAllowance(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryAllowanceParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	allowance := keeper.GetFeeAllowance(ctx, params.Granter, params.Grantee)
+	if allowance == nil {
+		return []byte{}, ErrNoAllowance(DefaultCodespace, params.Granter, params.Grantee)
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, allowance)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/feegrant/allowances'
+type QueryAllowancesParams struct {
+	Grantee sdk.AccAddress
+}
+
+func queryAllowances(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryAllowancesParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	grants := keeper.GetFeeGrants(ctx, params.Grantee)
+	if grants == nil {
+		grants = []FeeAllowanceGrant{}
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, grants)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
@@ -11,9 +11,11 @@ import (
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"encoding/json"
 	"io/ioutil"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -32,13 +34,22 @@ const (
 	flagStatus            = "status"
 	flagLatestProposalIDs = "latest"
 	flagProposal          = "proposal"
+	flagUpgradeName       = "upgrade-name"
+	flagUpgradeHeight     = "upgrade-height"
+	flagRecipient         = "recipient"
+	flagAmount            = "amount"
 )
 
 type proposal struct {
-	Title       string
-	Description string
-	Type        string
-	Deposit     string
+	Title         string
+	Description   string
+	Type          string
+	Deposit       string
+	ParamChanges  []gov.ParamChange `json:"param_changes"`
+	UpgradeName   string            `json:"upgrade_name"`
+	UpgradeHeight int64             `json:"upgrade_height"`
+	Recipient     string            `json:"recipient"`
+	Amount        string            `json:"amount"`
 }
 
 var proposalFlags = []string{
@@ -46,6 +57,10 @@ var proposalFlags = []string{
 	flagDescription,
 	flagProposalType,
 	flagDeposit,
+	flagUpgradeName,
+	flagUpgradeHeight,
+	flagRecipient,
+	flagAmount,
 }
 
 // GetCmdSubmitProposal implements submitting a proposal transaction command.
@@ -70,6 +85,28 @@ where proposal.json contains:
 is equivalent to
 
 $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="1000test"
+
+ParameterChange proposals can only be submitted through a proposal JSON file,
+listing the params to change with their new JSON encoded value:
+
+{
+  "title": "Shorter voting period",
+  "description": "Reduce the voting period to 100 blocks",
+  "type": "ParameterChange",
+  "deposit": "1000test",
+  "param_changes": [
+    {"key": "gov/votingprocedure", "value": "{\"voting_period\":\"100\"}"}
+  ]
+}
+
+SoftwareUpgrade proposals name the upgrade and the height at which the chain
+halts unless the running binary contains the upgrade handler:
+
+$ gaiacli gov submit-proposal --title="Upgrade" --description="Upgrade to v1" --type="SoftwareUpgrade" --deposit="1000test" --upgrade-name="v1" --upgrade-height=100000
+
+CommunityPoolSpend proposals send an amount of the community pool to a recipient:
+
+$ gaiacli gov submit-proposal --title="Grant" --description="Fund the docs" --type="CommunityPoolSpend" --deposit="1000test" --recipient=<account address> --amount="500test"
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -99,6 +136,21 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 			}
 
 			msg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount)
+			msg.ParamChanges = proposal.ParamChanges
+			if proposalType == gov.ProposalTypeSoftwareUpgrade {
+				plan := upgrade.NewPlan(proposal.UpgradeName, proposal.UpgradeHeight)
+				msg.UpgradePlan = &plan
+			}
+			if proposalType == gov.ProposalTypeCommunityPoolSpend {
+				msg.Recipient, err = sdk.AccAddressFromBech32(proposal.Recipient)
+				if err != nil {
+					return err
+				}
+				msg.Amount, err = sdk.ParseCoins(proposal.Amount)
+				if err != nil {
+					return err
+				}
+			}
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -119,6 +171,10 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 	cmd.Flags().String(flagDescription, "", "description of proposal")
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(flagUpgradeName, "", "name of the upgrade of a SoftwareUpgrade proposal")
+	cmd.Flags().String(flagUpgradeHeight, "", "height of the upgrade of a SoftwareUpgrade proposal")
+	cmd.Flags().String(flagRecipient, "", "recipient of the funds of a CommunityPoolSpend proposal")
+	cmd.Flags().String(flagAmount, "", "amount spent from the community pool by a CommunityPoolSpend proposal")
 	cmd.Flags().String(flagProposal, "", "proposal file path (if this path is given, other proposal flags are ignored)")
 
 	return cmd
@@ -133,6 +189,16 @@ func parseSubmitProposalFlags() (*proposal, error) {
 		proposal.Description = viper.GetString(flagDescription)
 		proposal.Type = viper.GetString(flagProposalType)
 		proposal.Deposit = viper.GetString(flagDeposit)
+		proposal.UpgradeName = viper.GetString(flagUpgradeName)
+		proposal.Recipient = viper.GetString(flagRecipient)
+		proposal.Amount = viper.GetString(flagAmount)
+		if heightStr := viper.GetString(flagUpgradeHeight); heightStr != "" {
+			height, err := strconv.ParseInt(heightStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid upgrade height %s: %v", heightStr, err)
+			}
+			proposal.UpgradeHeight = height
+		}
 		return proposal, nil
 	}
 
@@ -539,3 +605,24 @@ func GetCmdQueryTally(queryRoute string, cdc *wire.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryUpgradePlan implements the command to query the scheduled software upgrade.
+func GetCmdQueryUpgradePlan(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-upgrade-plan",
+		Short: "get the software upgrade scheduled by a passed proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/upgrade-plan", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -47,6 +47,8 @@ type postProposalReq struct {
 	ProposalType   gov.ProposalKind `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       sdk.AccAddress   `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins        `json:"initial_deposit"` // Coins to add to the proposal's deposit
+
+	ParamChanges []gov.ParamChange `json:"param_changes"` // Param changes of a ParameterChange proposal
 }
 
 type depositReq struct {
@@ -75,6 +77,7 @@ func postProposalHandlerFn(cdc *wire.Codec, cliCtx context.CLIContext) http.Hand
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, req.ProposalType, req.Proposer, req.InitialDeposit)
+		msg.ParamChanges = req.ParamChanges
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
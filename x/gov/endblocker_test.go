@@ -2,11 +2,14 @@ package gov
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -222,3 +225,155 @@ func TestSlashing(t *testing.T) {
 	require.True(t, val1End.LT(val1Initial))
 	require.True(t, val2End.LT(val2Initial))
 }
+
+func TestParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, 1)
+	valAddrs[0] = sdk.ValAddress(addrs[0])
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{25})
+
+	// the changes which can't be applied are rejected on submission: values
+	// which don't match the registered param type, unknown params and values
+	// out of the range of the param. The decimals are JSON encoded as their
+	// value scaled by 10^18.
+	invalidChangeCode := ErrInvalidParamChange(DefaultCodespace, "").Result().Code
+	for _, tc := range []struct {
+		change ParamChange
+		reason string
+	}{
+		{ParamChange{Key: ParamStoreKeyVotingProcedure, Value: `{"voting_period":"abc"}`},
+			"Invalid value for param gov/votingprocedure"},
+		{ParamChange{Key: "unknown/param", Value: `"1"`},
+			"No type registered for param unknown/param"},
+		{ParamChange{Key: ParamStoreKeyVotingProcedure, Value: `{"voting_period":"0"}`},
+			"voting period must be positive"},
+		{ParamChange{Key: ParamStoreKeyTallyingProcedure, Value: `{"threshold":"1500000000000000000","veto":"334000000000000000","governance_penalty":"10000000000000000"}`},
+			"threshold must be between 0 and 1"},
+		{ParamChange{Key: ParamStoreKeyTallyingProcedure, Value: `{"threshold":"500000000000000000","veto":"-100000000000000000","governance_penalty":"10000000000000000"}`},
+			"veto must be between 0 and 1"},
+		{ParamChange{Key: stake.ParamStoreKeyParams, Value: `{"inflation_rate_change":"130000000000000000","inflation_max":"70000000000000000","inflation_min":"200000000000000000","goal_bonded":"670000000000000000","unbonding_time":"1","max_validators":100,"bond_denom":"steak"}`},
+			"above maximum inflation"},
+		{ParamChange{Key: stake.ParamStoreKeyParams, Value: `{"inflation_rate_change":"130000000000000000","inflation_max":"200000000000000000","inflation_min":"70000000000000000","goal_bonded":"670000000000000000","unbonding_time":"1","max_validators":100,"bond_denom":"atom"}`},
+			"bond denomination can't be changed"},
+	} {
+		res := govHandler(ctx, NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{tc.change}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 15)}))
+		require.Equal(t, invalidChangeCode, res.Code, tc.change.Value)
+		require.Contains(t, res.Log, tc.reason, tc.change.Value)
+	}
+
+	change := ParamChange{Key: ParamStoreKeyVotingProcedure, Value: `{"voting_period":"100"}`}
+	stakeChange := ParamChange{Key: stake.ParamStoreKeyParams, Value: `{"inflation_rate_change":"130000000000000000","inflation_max":"200000000000000000","inflation_min":"70000000000000000","goal_bonded":"670000000000000000","unbonding_time":"1","max_validators":100,"bond_denom":"steak"}`}
+	res := govHandler(ctx, NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{change, stakeChange}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 15)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	// the change is only applied once the proposal passes
+	votingPeriod := keeper.GetVotingProcedure(ctx).VotingPeriod
+	require.NotEqual(t, int64(100), votingPeriod)
+
+	ctx = ctx.WithBlockHeight(votingPeriod)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, int64(100), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Equal(t, time.Duration(1), sk.GetParams(ctx).UnbondingTime)
+}
+
+func TestSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, 1)
+	valAddrs[0] = sdk.ValAddress(addrs[0])
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{25})
+
+	plan := upgrade.NewPlan("v1", 1000)
+	res := govHandler(ctx, NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 15)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	// the upgrade is only scheduled once the proposal passes
+	_, found := keeper.uk.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	ctx = ctx.WithBlockHeight(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	scheduled, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, scheduled)
+}
+
+// community pool holding a fixed amount of coins
+type testCommunityPool struct {
+	ck   bank.Keeper
+	pool *sdk.Coins
+}
+
+func (cp testCommunityPool) DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr sdk.AccAddress) sdk.Error {
+	if !cp.pool.IsGTE(amount) {
+		return sdk.ErrInsufficientCoins("community pool too small")
+	}
+	*cp.pool = cp.pool.Minus(amount)
+	_, _, err := cp.ck.AddCoins(ctx, receiveAddr, amount)
+	return err
+}
+
+func TestCommunityPoolSpendProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	pool := sdk.Coins{sdk.NewInt64Coin("steak", 10)}
+	keeper = keeper.WithCommunityPoolKeeper(testCommunityPool{keeper.ck, &pool})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, 1)
+	valAddrs[0] = sdk.ValAddress(addrs[0])
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{25})
+
+	// the first spend is covered by the pool, the second one is not
+	recipient := addrs[1]
+	var proposalIDs []int64
+	for i, amount := range []int64{8, 5} {
+		msg := NewMsgSubmitCommunityPoolSpendProposal("Test", "test", recipient, sdk.Coins{sdk.NewInt64Coin("steak", amount)}, addrs[i+2], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+		res := govHandler(ctx, msg)
+		require.True(t, res.IsOK())
+		var proposalID int64
+		keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+		proposalIDs = append(proposalIDs, proposalID)
+
+		res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+		require.True(t, res.IsOK())
+	}
+	balance := keeper.ck.GetCoins(ctx, recipient)
+
+	ctx = ctx.WithBlockHeight(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	EndBlocker(ctx, keeper)
+
+	// both proposals passed, but only the first one could be executed
+	for _, proposalID := range proposalIDs {
+		require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	}
+	require.True(t, balance.Plus(sdk.Coins{sdk.NewInt64Coin("steak", 8)}).IsEqual(keeper.ck.GetCoins(ctx, recipient)))
+	require.True(t, sdk.Coins{sdk.NewInt64Coin("steak", 2)}.IsEqual(pool))
+}
@@ -21,6 +21,8 @@ const (
 	CodeInvalidVote             sdk.CodeType = 9
 	CodeInvalidGenesis          sdk.CodeType = 10
 	CodeInvalidProposalStatus   sdk.CodeType = 11
+	CodeInvalidParamChange      sdk.CodeType = 12
+	CodeInvalidPoolSpend        sdk.CodeType = 13
 )
 
 //----------------------------------------
@@ -65,3 +67,11 @@ func ErrInvalidVote(codespace sdk.CodespaceType, voteOption VoteOption) sdk.Erro
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
+
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, msg)
+}
+
+func ErrInvalidPoolSpend(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPoolSpend, msg)
+}
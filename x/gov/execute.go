@@ -0,0 +1,37 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// executeProposal applies the content of a passed proposal. The changes of a
+// proposal are applied atomically, if any of them fails none are written.
+func (keeper Keeper) executeProposal(ctx sdk.Context, proposal Proposal) sdk.Error {
+	cacheCtx, write := ctx.CacheContext()
+
+	switch proposal := proposal.(type) {
+	case *ParameterChangeProposal:
+		for _, change := range proposal.Changes {
+			if err := keeper.ps.SetJSON(cacheCtx, change.Key, []byte(change.Value)); err != nil {
+				return ErrInvalidParamChange(keeper.codespace, err.Error())
+			}
+		}
+	case *SoftwareUpgradeProposal:
+		if keeper.uk == nil {
+			return sdk.ErrInternal("no upgrade keeper set to schedule the upgrade")
+		}
+		if err := keeper.uk.ScheduleUpgrade(cacheCtx, proposal.Plan); err != nil {
+			return err
+		}
+	case *CommunityPoolSpendProposal:
+		if keeper.cpk == nil {
+			return sdk.ErrInternal("no community pool keeper set to spend the community pool")
+		}
+		if err := keeper.cpk.DistributeFromFeePool(cacheCtx, proposal.Amount, proposal.Recipient); err != nil {
+			return err
+		}
+	}
+
+	write()
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov/tags"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // Handle all "gov" type messages.
@@ -26,7 +27,29 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		// reject upfront the changes which can't be applied to the current
+		// params, applying them in order to a discarded context as the
+		// validity of a change may depend on the previous ones
+		cacheCtx, _ := ctx.CacheContext()
+		for _, change := range msg.ParamChanges {
+			if err := keeper.ps.SetJSON(cacheCtx, change.Key, []byte(change.Value)); err != nil {
+				return ErrInvalidParamChange(keeper.codespace, err.Error()).Result()
+			}
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.ParamChanges)
+	case ProposalTypeSoftwareUpgrade:
+		if msg.UpgradePlan.Height <= ctx.BlockHeight() {
+			return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, "upgrade cannot be scheduled in the past").Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, *msg.UpgradePlan)
+	case ProposalTypeCommunityPoolSpend:
+		proposal = keeper.NewCommunityPoolSpendProposal(ctx, msg.Title, msg.Description, msg.Recipient, msg.Amount)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -135,6 +158,11 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusPassed)
 			action = tags.ActionProposalPassed
+
+			if err := keeper.executeProposal(ctx, activeProposal); err != nil {
+				logger.Info(fmt.Sprintf("Proposal %d - \"%s\" - passed but could not be executed: %s",
+					activeProposal.GetProposalID(), activeProposal.GetTitle(), err.Error()))
+			}
 		} else {
 			keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusRejected)
@@ -1,10 +1,12 @@
 package gov
 
 import (
+	"github.com/cosmos/cosmos-sdk/store/collections"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // nolint
@@ -25,12 +27,22 @@ type Keeper struct {
 	// The ValidatorSet to get information about validators
 	vs sdk.ValidatorSet
 
+	// The UpgradeKeeper to schedule the upgrades of passed proposals
+	uk UpgradeKeeper
+
+	// The CommunityPoolKeeper to spend the community pool of passed proposals
+	cpk CommunityPoolKeeper
+
 	// The reference to the DelegationSet to get information about delegators
 	ds sdk.DelegationSet
 
 	// The (unexposed) keys used to access the stores from the Context.
 	storeKey sdk.StoreKey
 
+	// The deposits and votes, by proposalID and depositer or voter address
+	deposits collections.Map
+	votes    collections.Map
+
 	// The wire codec for binary encoding/decoding.
 	cdc *wire.Codec
 
@@ -42,6 +54,8 @@ type Keeper struct {
 func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ps params.Setter, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
 		storeKey:  key,
+		deposits:  collections.NewMap(key, PrefixDeposits, proposalAddressKeys, collections.AminoValue(cdc, Deposit{})),
+		votes:     collections.NewMap(key, PrefixVotes, proposalAddressKeys, collections.AminoValue(cdc, Vote{})),
 		ps:        ps,
 		ck:        ck,
 		ds:        ds,
@@ -51,6 +65,35 @@ func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ps params.Setter, ck bank.Keep
 	}
 }
 
+// UpgradeKeeper schedules the software upgrades of passed SoftwareUpgrade proposals
+type UpgradeKeeper interface {
+	ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) sdk.Error
+	GetUpgradePlan(ctx sdk.Context) (plan upgrade.Plan, found bool)
+}
+
+// Set the upgrade keeper, required to execute SoftwareUpgrade proposals
+func (keeper Keeper) WithUpgradeKeeper(uk UpgradeKeeper) Keeper {
+	if keeper.uk != nil {
+		panic("cannot set upgrade keeper twice")
+	}
+	keeper.uk = uk
+	return keeper
+}
+
+// CommunityPoolKeeper spends the community pool for passed CommunityPoolSpend proposals
+type CommunityPoolKeeper interface {
+	DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr sdk.AccAddress) sdk.Error
+}
+
+// Set the community pool keeper, required to execute CommunityPoolSpend proposals
+func (keeper Keeper) WithCommunityPoolKeeper(cpk CommunityPoolKeeper) Keeper {
+	if keeper.cpk != nil {
+		panic("cannot set community pool keeper twice")
+	}
+	keeper.cpk = cpk
+	return keeper
+}
+
 // Returns the go-wire codec.
 func (keeper Keeper) WireCodec() *wire.Codec {
 	return keeper.cdc
@@ -65,7 +108,62 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	if err != nil {
 		return nil
 	}
-	var proposal Proposal = &TextProposal{
+	textProposal := newTextProposal(ctx, proposalID, title, description, proposalType)
+	var proposal Proposal = &textProposal
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a new ParameterChangeProposal
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: newTextProposal(ctx, proposalID, title, description, ProposalTypeParameterChange),
+		Changes:      changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a new SoftwareUpgradeProposal
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: newTextProposal(ctx, proposalID, title, description, ProposalTypeSoftwareUpgrade),
+		Plan:         plan,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a new CommunityPoolSpendProposal
+func (keeper Keeper) NewCommunityPoolSpendProposal(ctx sdk.Context, title string, description string, recipient sdk.AccAddress, amount sdk.Coins) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &CommunityPoolSpendProposal{
+		TextProposal: newTextProposal(ctx, proposalID, title, description, ProposalTypeCommunityPoolSpend),
+		Recipient:    recipient,
+		Amount:       amount,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// new proposal in its deposit period
+func newTextProposal(ctx sdk.Context, proposalID int64, title string, description string, proposalType ProposalKind) TextProposal {
+	return TextProposal{
 		ProposalID:       proposalID,
 		Title:            title,
 		Description:      description,
@@ -76,9 +174,6 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 		SubmitBlock:      ctx.BlockHeight(),
 		VotingStartBlock: -1, // TODO: Make Time
 	}
-	keeper.SetProposal(ctx, proposal)
-	keeper.InactiveProposalQueuePush(ctx, proposal)
-	return proposal
 }
 
 // Get Proposal from store by ProposalID
@@ -271,31 +366,24 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 
 // Gets the vote of a specific voter on a specific proposal
 func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) (Vote, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyVote(proposalID, voterAddr))
-	if bz == nil {
+	vote, found := keeper.votes.Get(ctx, keyProposalAddress(proposalID, voterAddr))
+	if !found {
 		return Vote{}, false
 	}
-	var vote Vote
-	keeper.cdc.MustUnmarshalBinary(bz, &vote)
-	return vote, true
+	return vote.(Vote), true
 }
 
 func (keeper Keeper) setVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, vote Vote) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(vote)
-	store.Set(KeyVote(proposalID, voterAddr), bz)
+	keeper.votes.Set(ctx, keyProposalAddress(proposalID, voterAddr), vote)
 }
 
-// Gets all the votes on a specific proposal
-func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) sdk.Iterator {
-	store := ctx.KVStore(keeper.storeKey)
-	return sdk.KVStorePrefixIterator(store, KeyVotesSubspace(proposalID))
+// Gets all the votes on a specific proposal, the values of the iterator are Votes
+func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) collections.Iterator {
+	return keeper.votes.Iterate(ctx, collections.Range{Prefix: proposalID})
 }
 
 func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) {
-	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyVote(proposalID, voterAddr))
+	keeper.votes.Remove(ctx, keyProposalAddress(proposalID, voterAddr))
 }
 
 // =====================================================
@@ -303,20 +391,15 @@ func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk
 
 // Gets the deposit of a specific depositer on a specific proposal
 func (keeper Keeper) GetDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.AccAddress) (Deposit, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyDeposit(proposalID, depositerAddr))
-	if bz == nil {
+	deposit, found := keeper.deposits.Get(ctx, keyProposalAddress(proposalID, depositerAddr))
+	if !found {
 		return Deposit{}, false
 	}
-	var deposit Deposit
-	keeper.cdc.MustUnmarshalBinary(bz, &deposit)
-	return deposit, true
+	return deposit.(Deposit), true
 }
 
 func (keeper Keeper) setDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.AccAddress, deposit Deposit) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(deposit)
-	store.Set(KeyDeposit(proposalID, depositerAddr), bz)
+	keeper.deposits.Set(ctx, keyProposalAddress(proposalID, depositerAddr), deposit)
 }
 
 // Adds or updates a deposit of a specific depositer on a specific proposal
@@ -364,42 +447,28 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	return nil, activatedVotingPeriod
 }
 
-// Gets all the deposits on a specific proposal
-func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator {
-	store := ctx.KVStore(keeper.storeKey)
-	return sdk.KVStorePrefixIterator(store, KeyDepositsSubspace(proposalID))
+// Gets all the deposits on a specific proposal, the values of the iterator are Deposits
+func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) collections.Iterator {
+	return keeper.deposits.Iterate(ctx, collections.Range{Prefix: proposalID})
 }
 
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
-
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		deposit := &Deposit{}
-		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
-
+	keeper.deposits.Walk(ctx, collections.Range{Prefix: proposalID}, func(_, value interface{}) bool {
+		deposit := value.(Deposit)
 		_, _, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
 		if err != nil {
 			panic("should not happen")
 		}
+		return false
+	})
 
-		store.Delete(depositsIterator.Key())
-	}
-
-	depositsIterator.Close()
+	keeper.DeleteDeposits(ctx, proposalID)
 }
 
 // Deletes all the deposits on a specific proposal without refunding them
 func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
-
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		store.Delete(depositsIterator.Key())
-	}
-
-	depositsIterator.Close()
+	keeper.deposits.Clear(ctx, collections.Range{Prefix: proposalID})
 }
 
 // =====================================================
@@ -1,8 +1,12 @@
 package gov
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"strconv"
 
+	"github.com/cosmos/cosmos-sdk/store/collections"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -15,27 +19,77 @@ var (
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
 )
 
+// Prefixes of the deposits and votes collections, keyed by proposalID and
+// address
+var (
+	PrefixDeposits = []byte("deposits:")
+	PrefixVotes    = []byte("votes:")
+)
+
 // Key for getting a specific proposal from the store
 func KeyProposal(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("proposals:%d", proposalID))
 }
 
-// Key for getting a specific deposit from the store
-func KeyDeposit(proposalID int64, depositerAddr sdk.AccAddress) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositerAddr))
+// Key of a deposit or vote in its collection
+func keyProposalAddress(proposalID int64, addr sdk.AccAddress) collections.Pair {
+	return collections.Pair{K1: proposalID, K2: addr}
+}
+
+// The deposits and votes keep the keys of the previous versions,
+// deposits:<proposalID>:<address> and votes:<proposalID>:<address> with the
+// proposalID in decimal and the address in hex, so that the stores of the
+// existing chains are read without a migration. The keys don't sort like the
+// proposalIDs, so the collections are only iterated by proposal.
+var proposalAddressKeys = collections.PairKeys(legacyProposalIDKey{}, legacyAddressKey{})
+
+// legacyProposalIDKey encodes the proposalIDs in decimal, followed by a colon
+// when not terminal.
+type legacyProposalIDKey struct{}
+
+func (legacyProposalIDKey) Encode(key interface{}) []byte {
+	return []byte(strconv.FormatInt(key.(int64), 10))
+}
+
+func (legacyProposalIDKey) Decode(bz []byte) (interface{}, error) {
+	return strconv.ParseInt(string(bz), 10, 64)
+}
+
+func (c legacyProposalIDKey) EncodeNonTerminal(key interface{}) []byte {
+	return append(c.Encode(key), ':')
+}
+
+func (c legacyProposalIDKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	n := bytes.IndexByte(bz, ':')
+	if n < 0 {
+		return nil, 0, fmt.Errorf("unterminated proposalID key")
+	}
+	key, err := c.Decode(bz[:n])
+	return key, n + 1, err
+}
+
+// legacyAddressKey encodes the addresses in upper case hex, followed by a
+// colon when not terminal.
+type legacyAddressKey struct{}
+
+func (legacyAddressKey) Encode(key interface{}) []byte {
+	return []byte(fmt.Sprintf("%X", []byte(key.(sdk.AccAddress))))
 }
 
-// Key for getting a specific vote from the store
-func KeyVote(proposalID int64, voterAddr sdk.AccAddress) []byte {
-	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
+func (legacyAddressKey) Decode(bz []byte) (interface{}, error) {
+	addr, err := hex.DecodeString(string(bz))
+	return sdk.AccAddress(addr), err
 }
 
-// Key for getting all deposits on a proposal from the store
-func KeyDepositsSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
+func (c legacyAddressKey) EncodeNonTerminal(key interface{}) []byte {
+	return append(c.Encode(key), ':')
 }
 
-// Key for getting all votes on a proposal from the store
-func KeyVotesSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("votes:%d:", proposalID))
+func (c legacyAddressKey) DecodeNonTerminal(bz []byte) (interface{}, int, error) {
+	n := bytes.IndexByte(bz, ':')
+	if n < 0 {
+		return nil, 0, fmt.Errorf("unterminated address key")
+	}
+	key, err := c.Decode(bz[:n])
+	return key, n + 1, err
 }
@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -113,6 +114,9 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, fourSteak.Plus(fiveSteak).Plus(fourSteak), keeper.GetProposal(ctx, proposalID).GetTotalDeposit())
 	require.Equal(t, addr1Initial.Minus(fourSteak), keeper.ck.GetCoins(ctx, addrs[1]))
 
+	// the deposits keep the keys of the previous versions
+	require.True(t, ctx.KVStore(keeper.storeKey).Has([]byte(fmt.Sprintf("deposits:%d:%d", proposalID, addrs[1]))))
+
 	// Check that proposal moved to voting period
 	require.Equal(t, ctx.BlockHeight(), keeper.GetProposal(ctx, proposalID).GetVotingStartBlock())
 	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
@@ -121,11 +125,11 @@ func TestDeposits(t *testing.T) {
 	// Test deposit iterator
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 	require.True(t, depositsIterator.Valid())
-	keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+	deposit = depositsIterator.Value().(Deposit)
 	require.Equal(t, addrs[0], deposit.Depositer)
 	require.Equal(t, fourSteak.Plus(fiveSteak), deposit.Amount)
 	depositsIterator.Next()
-	keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+	deposit = depositsIterator.Value().(Deposit)
 	require.Equal(t, addrs[1], deposit.Depositer)
 	require.Equal(t, fourSteak, deposit.Amount)
 	depositsIterator.Next()
@@ -180,17 +184,20 @@ func TestVotes(t *testing.T) {
 	require.Equal(t, proposalID, vote.ProposalID)
 	require.Equal(t, OptionNoWithVeto, vote.Option)
 
+	// the votes keep the keys of the previous versions
+	require.True(t, ctx.KVStore(keeper.storeKey).Has([]byte(fmt.Sprintf("votes:%d:%d", proposalID, addrs[1]))))
+
 	// Test vote iterator
 	votesIterator := keeper.GetVotes(ctx, proposalID)
 	require.True(t, votesIterator.Valid())
-	keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+	vote = votesIterator.Value().(Vote)
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
 	require.Equal(t, OptionYes, vote.Option)
 	votesIterator.Next()
 	require.True(t, votesIterator.Valid())
-	keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+	vote = votesIterator.Value().(Vote)
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[1], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // name to idetify transaction types
@@ -17,6 +18,11 @@ type MsgSubmitProposal struct {
 	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
+
+	ParamChanges []ParamChange  `json:"param_changes,omitempty"` //  Param changes of a ParameterChange proposal
+	UpgradePlan  *upgrade.Plan  `json:"upgrade_plan,omitempty"`  //  Upgrade plan of a SoftwareUpgrade proposal
+	Recipient    sdk.AccAddress `json:"recipient,omitempty"`     //  Recipient of a CommunityPoolSpend proposal
+	Amount       sdk.Coins      `json:"amount,omitempty"`        //  Amount spent by a CommunityPoolSpend proposal
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -29,6 +35,17 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParameterChangeProposal(title string, description string, changes []ParamChange, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   changes,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 
@@ -52,6 +69,91 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if err := validateParamChanges(msg.ProposalType, msg.ParamChanges); err != nil {
+		return err
+	}
+	if err := validateUpgradePlan(msg.ProposalType, msg.UpgradePlan); err != nil {
+		return err
+	}
+	return validateCommunityPoolSpend(msg.ProposalType, msg.Recipient, msg.Amount)
+}
+
+func NewMsgSubmitSoftwareUpgradeProposal(title string, description string, plan upgrade.Plan, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		UpgradePlan:    &plan,
+	}
+}
+
+// an upgrade plan is required by, and only allowed for, software upgrade proposals
+func validateUpgradePlan(proposalType ProposalKind, plan *upgrade.Plan) sdk.Error {
+	if proposalType != ProposalTypeSoftwareUpgrade {
+		if plan != nil {
+			return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, fmt.Sprintf("%s proposals cannot schedule upgrades", proposalType))
+		}
+		return nil
+	}
+	if plan == nil {
+		return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, "SoftwareUpgrade proposals must include an upgrade plan")
+	}
+	return plan.ValidateBasic()
+}
+
+func NewMsgSubmitCommunityPoolSpendProposal(title string, description string, recipient sdk.AccAddress, amount sdk.Coins, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeCommunityPoolSpend,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		Recipient:      recipient,
+		Amount:         amount,
+	}
+}
+
+// a recipient and a positive amount are required by, and only allowed for,
+// community pool spend proposals
+func validateCommunityPoolSpend(proposalType ProposalKind, recipient sdk.AccAddress, amount sdk.Coins) sdk.Error {
+	if proposalType != ProposalTypeCommunityPoolSpend {
+		if len(recipient) != 0 || len(amount) != 0 {
+			return ErrInvalidPoolSpend(DefaultCodespace, fmt.Sprintf("%s proposals cannot spend the community pool", proposalType))
+		}
+		return nil
+	}
+	if len(recipient) == 0 {
+		return sdk.ErrInvalidAddress(recipient.String())
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return sdk.ErrInvalidCoins(amount.String())
+	}
+	return nil
+}
+
+// param changes are required by, and only allowed for, parameter change proposals
+func validateParamChanges(proposalType ProposalKind, changes []ParamChange) sdk.Error {
+	if proposalType != ProposalTypeParameterChange {
+		if len(changes) != 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("%s proposals cannot change params", proposalType))
+		}
+		return nil
+	}
+	if len(changes) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "ParameterChange proposals must change at least one param")
+	}
+	keys := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		if len(change.Key) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "param key cannot be empty")
+		}
+		if keys[change.Key] {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("param %s changed more than once", change.Key))
+		}
+		keys[change.Key] = true
+	}
 	return nil
 }
 
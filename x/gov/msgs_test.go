@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +31,9 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeCommunityPoolSpend, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.AccAddress{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +51,93 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for the param changes of MsgSubmitProposal
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	change := ParamChange{Key: "gov/votingprocedure", Value: `{"voting_period":"100"}`}
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{change}, true},
+		{nil, false},
+		{[]ParamChange{{Key: "", Value: `"1"`}}, false},
+		{[]ParamChange{change, change}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot change params
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = []ParamChange{change}
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for the upgrade plan of MsgSubmitProposal
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		plan       upgrade.Plan
+		expectPass bool
+	}{
+		{upgrade.NewPlan("v1", 100), true},
+		{upgrade.NewPlan("", 100), false},
+		{upgrade.NewPlan("v1", 0), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", tc.plan, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot schedule upgrades
+	plan := upgrade.NewPlan("v1", 100)
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.UpgradePlan = &plan
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for the spend of MsgSubmitProposal
+func TestMsgSubmitCommunityPoolSpendProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(2, sdk.Coins{})
+	tests := []struct {
+		recipient  sdk.AccAddress
+		amount     sdk.Coins
+		expectPass bool
+	}{
+		{addrs[1], coinsPos, true},
+		{addrs[1], coinsMulti, true},
+		{sdk.AccAddress{}, coinsPos, false},
+		{addrs[1], coinsZero, false},
+		{addrs[1], coinsNeg, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitCommunityPoolSpendProposal("Test Proposal", "the purpose of this proposal is to test", tc.recipient, tc.amount, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot spend the community pool
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.Recipient, msg.Amount = addrs[1], coinsPos
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
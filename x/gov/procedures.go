@@ -1,7 +1,10 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // Procedure around Deposits for governance
@@ -21,3 +24,48 @@ type TallyingProcedure struct {
 type VotingProcedure struct {
 	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period.
 }
+
+// RegisterParamTypes registers the types of the governance procedures with the
+// param store, so they can be changed through governance
+func RegisterParamTypes(k params.Keeper) {
+	k.RegisterType(ParamStoreKeyDepositProcedure, DepositProcedure{}, validateDepositProcedure)
+	k.RegisterType(ParamStoreKeyVotingProcedure, VotingProcedure{}, validateVotingProcedure)
+	k.RegisterType(ParamStoreKeyTallyingProcedure, TallyingProcedure{}, validateTallyingProcedure)
+}
+
+func validateDepositProcedure(_ sdk.Context, value interface{}) error {
+	procedure := value.(DepositProcedure)
+	if !procedure.MinDeposit.IsValid() || !procedure.MinDeposit.IsNotNegative() {
+		return fmt.Errorf("invalid minimum deposit %v", procedure.MinDeposit)
+	}
+	if procedure.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("maximum deposit period must be positive, got %d", procedure.MaxDepositPeriod)
+	}
+	return nil
+}
+
+func validateVotingProcedure(_ sdk.Context, value interface{}) error {
+	procedure := value.(VotingProcedure)
+	if procedure.VotingPeriod <= 0 {
+		return fmt.Errorf("voting period must be positive, got %d", procedure.VotingPeriod)
+	}
+	return nil
+}
+
+func validateTallyingProcedure(_ sdk.Context, value interface{}) error {
+	procedure := value.(TallyingProcedure)
+	for _, f := range []struct {
+		name     string
+		value    sdk.Dec
+		positive bool
+	}{
+		{"threshold", procedure.Threshold, true},
+		{"veto", procedure.Veto, true},
+		{"governance penalty", procedure.GovernancePenalty, false},
+	} {
+		if f.value.IsNil() || f.value.LT(sdk.ZeroDec()) || f.value.GT(sdk.OneDec()) || (f.positive && f.value.IsZero()) {
+			return fmt.Errorf("%s must be between 0 and 1, got %v", f.name, f.value)
+		}
+	}
+	return nil
+}
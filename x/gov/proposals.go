@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 //-----------------------------------------------------------
@@ -98,6 +99,56 @@ func (tp *TextProposal) SetVotingStartBlock(votingStartBlock int64) {
 	tp.VotingStartBlock = votingStartBlock
 }
 
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// ParamChange sets the param stored under Key to the JSON encoded Value
+type ParamChange struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s: %s", pc.Key, pc.Value)
+}
+
+// ParameterChangeProposal is a proposal which, once passed, applies a list of
+// changes to the global param store
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Param changes applied together when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+//-----------------------------------------------------------
+// Software Upgrade Proposals
+
+// SoftwareUpgradeProposal is a proposal which, once passed, schedules a
+// software upgrade at the height of its plan
+type SoftwareUpgradeProposal struct {
+	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Upgrade scheduled when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
+
+//-----------------------------------------------------------
+// Community Pool Spend Proposals
+
+// CommunityPoolSpendProposal is a proposal which, once passed, sends an amount
+// of the community pool to a recipient
+type CommunityPoolSpendProposal struct {
+	TextProposal
+	Recipient sdk.AccAddress `json:"recipient"` //  Address receiving the funds
+	Amount    sdk.Coins      `json:"amount"`    //  Amount spent from the community pool
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*CommunityPoolSpendProposal)(nil)
+
 //-----------------------------------------------------------
 // ProposalQueue
 type ProposalQueue []int64
@@ -110,10 +161,11 @@ type ProposalKind byte
 
 //nolint
 const (
-	ProposalTypeNil             ProposalKind = 0x00
-	ProposalTypeText            ProposalKind = 0x01
-	ProposalTypeParameterChange ProposalKind = 0x02
-	ProposalTypeSoftwareUpgrade ProposalKind = 0x03
+	ProposalTypeNil                ProposalKind = 0x00
+	ProposalTypeText               ProposalKind = 0x01
+	ProposalTypeParameterChange    ProposalKind = 0x02
+	ProposalTypeSoftwareUpgrade    ProposalKind = 0x03
+	ProposalTypeCommunityPoolSpend ProposalKind = 0x04
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -125,6 +177,8 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeParameterChange, nil
 	case "SoftwareUpgrade":
 		return ProposalTypeSoftwareUpgrade, nil
+	case "CommunityPoolSpend":
+		return ProposalTypeCommunityPoolSpend, nil
 	default:
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
@@ -134,7 +188,8 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 func validProposalType(pt ProposalKind) bool {
 	if pt == ProposalTypeText ||
 		pt == ProposalTypeParameterChange ||
-		pt == ProposalTypeSoftwareUpgrade {
+		pt == ProposalTypeSoftwareUpgrade ||
+		pt == ProposalTypeCommunityPoolSpend {
 		return true
 	}
 	return false
@@ -181,6 +236,8 @@ func (pt ProposalKind) String() string {
 		return "ParameterChange"
 	case ProposalTypeSoftwareUpgrade:
 		return "SoftwareUpgrade"
+	case ProposalTypeCommunityPoolSpend:
+		return "CommunityPoolSpend"
 	default:
 		return ""
 	}
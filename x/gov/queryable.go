@@ -25,6 +25,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryProposals(ctx, path[1:], req, keeper)
 		case "tally":
 			return queryTally(ctx, path[1:], req, keeper)
+		case "upgrade-plan":
+			return queryUpgradePlan(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -112,10 +114,9 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	var deposits []Deposit
 	depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		deposit := Deposit{}
-		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
-		deposits = append(deposits, deposit)
+		deposits = append(deposits, depositsIterator.Value().(Deposit))
 	}
+	depositsIterator.Close()
 
 	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, deposits)
 	if err2 != nil {
@@ -140,10 +141,9 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	var votes []Vote
 	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
 	for ; votesIterator.Valid(); votesIterator.Next() {
-		vote := Vote{}
-		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
-		votes = append(votes, vote)
+		votes = append(votes, votesIterator.Value().(Vote))
 	}
+	votesIterator.Close()
 
 	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, votes)
 	if err2 != nil {
@@ -211,3 +211,20 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	}
 	return bz, nil
 }
+
+func queryUpgradePlan(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	if keeper.uk == nil {
+		return []byte{}, sdk.ErrUnknownRequest("software upgrades are not supported")
+	}
+
+	plan, found := keeper.uk.GetUpgradePlan(ctx)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest("no upgrade scheduled")
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(keeper.cdc, plan)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
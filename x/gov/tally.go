@@ -38,8 +38,7 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tall
 	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
 	defer votesIterator.Close()
 	for ; votesIterator.Valid(); votesIterator.Next() {
-		vote := &Vote{}
-		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), vote)
+		vote := votesIterator.Value().(Vote)
 
 		// if validator, just record it in the map
 		// if delegator tally voting power
@@ -13,6 +13,9 @@ func RegisterWire(cdc *wire.Codec) {
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&TextProposal{}, "gov/TextProposal", nil)
+	cdc.RegisterConcrete(&ParameterChangeProposal{}, "gov/ParameterChangeProposal", nil)
+	cdc.RegisterConcrete(&SoftwareUpgradeProposal{}, "gov/SoftwareUpgradeProposal", nil)
+	cdc.RegisterConcrete(&CommunityPoolSpendProposal{}, "gov/CommunityPoolSpendProposal", nil)
 }
 
 var msgCdc = wire.NewCodec()
@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,7 +23,10 @@ func getMockApp(t *testing.T) *mock.App {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, mapp.RegisterCodespace(DefaultCodespace))
 	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, coinKeeper))
+	transferApp := NewTransferApp(ibcMapper, coinKeeper)
+	mapp.Router().
+		AddRoute("ibc", NewHandler(ibcMapper, NewRouter().AddRoute(TransferPort, transferApp))).
+		AddRoute("ibctransfer", NewTransferHandler(transferApp))
 
 	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyIBC}))
 	return mapp
@@ -52,20 +56,10 @@ func TestIBCMsgs(t *testing.T) {
 	res1 := mapp.AccountMapper.GetAccount(ctxCheck, addr1)
 	require.Equal(t, acc, res1)
 
-	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
-		SrcChain:  sourceChain,
-		DestChain: destChain,
-	}
-
-	transferMsg := IBCTransferMsg{
-		IBCPacket: packet,
-	}
+	transferMsg := NewIBCTransferMsg(addr1, addr1, coins, sourceChain, destChain, 100, time.Time{})
 
 	receiveMsg := IBCReceiveMsg{
-		IBCPacket: packet,
+		IBCPacket: transferMsg.Packet(),
 		Relayer:   addr1,
 		Sequence:  0,
 	}
@@ -73,7 +67,7 @@ func TestIBCMsgs(t *testing.T) {
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, true, priv1)
 	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, coins)
+	// a receive without a proof is rejected
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	wire "github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagHeight = "height"
+)
+
+// IBCCreateClientCmd implements the command creating the light client of a
+// source chain from its header at a given height.
+func IBCCreateClientCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-client",
+		Short: "Create the light client of a source chain, whose current validator set must be the one trusted by governance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			fromChainNode := viper.GetString(FlagFromChainNode)
+			height := viper.GetInt64(flagHeight)
+			if height == 0 {
+				height, err = latestHeight(fromChainNode)
+				if err != nil {
+					return err
+				}
+			}
+
+			header, validators, err := signedHeader(fromChainNode, height)
+			if err != nil {
+				return err
+			}
+
+			msg := ibc.IBCCreateClientMsg{
+				Header:     header,
+				Validators: validators,
+				Signer:     from,
+			}
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for the source chain")
+	cmd.Flags().Int64(flagHeight, 0, "Height of the source chain header to start from, defaults to the latest")
+
+	return cmd
+}
+
+func latestHeight(node string) (int64, error) {
+	client, err := context.NewCLIContext().WithNodeURI(node).GetNode()
+	if err != nil {
+		return 0, err
+	}
+
+	status, err := client.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// signedHeader returns the header of the chain at height, along with the
+// commit and the validator set that signed it
+func signedHeader(node string, height int64) (tmtypes.SignedHeader, *tmtypes.ValidatorSet, error) {
+	client, err := context.NewCLIContext().WithNodeURI(node).GetNode()
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+
+	commit, err := client.Commit(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+
+	validators, err := client.Validators(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+	return commit.SignedHeader, tmtypes.NewValidatorSet(validators.Validators), nil
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/hex"
 	"os"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -18,9 +19,11 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo            = "to"
+	flagAmount        = "amount"
+	flagChain         = "chain"
+	flagTimeoutHeight = "timeout-height"
+	flagTimeoutTime   = "timeout-time"
 )
 
 // IBCTransferCmd implements the IBC transfer command.
@@ -54,6 +57,8 @@ func IBCTransferCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeoutHeight, 0, "Destination chain height from which the transfer times out and is refunded")
+	cmd.Flags().String(flagTimeoutTime, "", "Destination chain time (RFC3339) from which the transfer times out and is refunded")
 
 	return cmd
 }
@@ -72,12 +77,16 @@ func buildMsg(from sdk.AccAddress) (sdk.Msg, error) {
 	}
 	to := sdk.AccAddress(bz)
 
-	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
-
-	msg := ibc.IBCTransferMsg{
-		IBCPacket: packet,
+	var timeoutTime time.Time
+	if t := viper.GetString(flagTimeoutTime); t != "" {
+		timeoutTime, err = time.Parse(time.RFC3339, t)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	msg := ibc.NewIBCTransferMsg(from, to, coins, viper.GetString(client.FlagChainID),
+		viper.GetString(flagChain), viper.GetInt64(flagTimeoutHeight), timeoutTime.UTC())
+
 	return msg, nil
 }
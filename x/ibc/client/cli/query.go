@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+// GetCmdQueryDenomTrace implements the command resolving a voucher denom to
+// the chains it was transferred through.
+func GetCmdQueryDenomTrace(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "denom-trace [denom]",
+		Short: "Query the chains a voucher denom was transferred through and its base denom",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := ibc.QueryDenomTraceParams{
+				Denom: args[0],
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, ibc.QueryDenomTrace), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
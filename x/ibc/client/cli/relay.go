@@ -1,21 +1,23 @@
 package cli
 
 import (
+	"net/http"
 	"os"
-	"time"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
-	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/keys"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
-	"github.com/cosmos/cosmos-sdk/x/auth"
-	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
-	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
-	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/ibc/client/relayer"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -25,46 +27,79 @@ const (
 	FlagFromChainNode = "from-chain-node"
 	FlagToChainID     = "to-chain-id"
 	FlagToChainNode   = "to-chain-node"
+	FlagConfig        = "config"
 )
 
-type relayCommander struct {
-	cdc       *wire.Codec
-	address   sdk.AccAddress
-	decoder   auth.AccountDecoder
-	mainStore string
-	ibcStore  string
-	accStore  string
-
-	logger log.Logger
-}
-
 // IBCRelayCmd implements the IBC relay command.
 func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
-	cmdr := relayCommander{
-		cdc:       cdc,
-		decoder:   authcmd.GetAccountDecoder(cdc),
-		ibcStore:  "ibc",
-		mainStore: "main",
-		accStore:  "acc",
-
-		logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
-	}
-
 	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
+		Use:   "relay",
+		Short: "Relay IBC packets, acknowledgements and timeouts between chains",
+		Long: `Relay IBC packets, acknowledgements and timeouts along the paths of the
+--config file, or from --from-chain-id to --to-chain-id. The relayer persists
+its progress under the home directory and serves prometheus metrics on the
+listen_addr of the config.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := relayConfig()
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := keys.ReadPassphraseFromStdin(config.Key)
+			if err != nil {
+				return err
+			}
+
+			var chains []relayer.Chain
+			for _, chainConfig := range config.Chains {
+				chain, err := relayer.NewRPCChain(cdc, chainConfig, config.Key, passphrase)
+				if err != nil {
+					return err
+				}
+				chains = append(chains, chain)
+			}
+
+			dir := filepath.Join(viper.GetString(cli.HomeFlag), "relayer")
+			db, err := dbm.NewGoLevelDB("relayer", dir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			metrics := relayer.NopMetrics()
+			if config.ListenAddr != "" {
+				metrics = relayer.PrometheusMetrics()
+				go func() {
+					err := http.ListenAndServe(config.ListenAddr, promhttp.Handler())
+					if err != nil {
+						panic(err)
+					}
+				}()
+			}
+
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "ibc-relayer")
+			r := relayer.NewRelayer(cdc, config, chains, db, metrics, logger)
+
+			stop := make(chan struct{})
+			go func() {
+				sigs := make(chan os.Signal, 1)
+				signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+				<-sigs
+				close(stop)
+			}()
+
+			r.Run(stop)
+			return nil
+		},
 	}
 
+	cmd.Flags().String(FlagConfig, "", "TOML file of the chains and paths to relay")
 	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
 	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
 	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for this chain")
 
-	cmd.MarkFlagRequired(FlagFromChainID)
-	cmd.MarkFlagRequired(FlagFromChainNode)
-	cmd.MarkFlagRequired(FlagToChainID)
-	cmd.MarkFlagRequired(FlagToChainNode)
-
+	viper.BindPFlag(FlagConfig, cmd.Flags().Lookup(FlagConfig))
 	viper.BindPFlag(FlagFromChainID, cmd.Flags().Lookup(FlagFromChainID))
 	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
 	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
@@ -73,138 +108,33 @@ func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
-// nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
-	fromChainID := viper.GetString(FlagFromChainID)
-	fromChainNode := viper.GetString(FlagFromChainNode)
-	toChainID := viper.GetString(FlagToChainID)
-	toChainNode := viper.GetString(FlagToChainNode)
-
-	address, err := context.NewCLIContext().GetFromAddress()
-	if err != nil {
-		panic(err)
-	}
-
-	c.address = address
-
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
-}
-
-// This is nolinted as someone is in the process of refactoring this to remove the goto
-// nolint: gocyclo
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID, toChainNode string) {
-	cliCtx := context.NewCLIContext()
-
-	passphrase, err := keys.ReadPassphraseFromStdin(cliCtx.FromAddressName)
-	if err != nil {
-		panic(err)
-	}
-
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
-
-OUTER:
-	for {
-		time.Sleep(5 * time.Second)
-
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
+// relayConfig reads the relayer config file, or builds the config of a single
+// path from the flags
+func relayConfig() (relayer.Config, error) {
+	var config relayer.Config
+	if file := viper.GetString(FlagConfig); file != "" {
+		var err error
+		config, err = relayer.LoadConfig(file)
 		if err != nil {
-			panic(err)
+			return config, err
 		}
-
-		var processed int64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinary(processedbz, &processed); err != nil {
-			panic(err)
+	} else {
+		fromChainID := viper.GetString(FlagFromChainID)
+		toChainID := viper.GetString(FlagToChainID)
+
+		config = relayer.DefaultConfig()
+		config.Chains = []relayer.ChainConfig{
+			{ChainID: fromChainID, Node: viper.GetString(FlagFromChainNode)},
+			{ChainID: toChainID, Node: viper.GetString(FlagToChainNode)},
 		}
-
-		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
-		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
+		config.Paths = []relayer.PathConfig{{Src: fromChainID, Dest: toChainID}}
+		if err := config.ValidateBasic(); err != nil {
+			return config, err
 		}
-
-		var egressLength int64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinary(egressLengthbz, &egressLength); err != nil {
-			panic(err)
-		}
-
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
-		}
-
-		seq := c.getSequence(toChainNode)
-
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
-			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
-
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
-
-			seq++
-
-			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
-
-			c.logger.Info("Relayed IBC packet", "number", i)
-		}
-	}
-}
-
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCLIContext().WithNodeURI(node).QueryStore(key, storeName)
-}
-
-func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
-	_, err := context.NewCLIContext().WithNodeURI(node).BroadcastTx(tx)
-	return err
-}
-
-func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
-	if err != nil {
-		panic(err)
 	}
 
-	if nil != res {
-		account, err := c.decoder(res)
-		if err != nil {
-			panic(err)
-		}
-
-		return account.GetSequence()
+	if config.Key == "" {
+		config.Key = viper.GetString(client.FlagFrom)
 	}
-
-	return 0
-}
-
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
-		panic(err)
-	}
-
-	msg := ibc.IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  sequence,
-	}
-
-	txCtx := authctx.NewTxContextFromCLI().WithSequence(sequence).WithCodec(c.cdc)
-	cliCtx := context.NewCLIContext()
-
-	res, err := txCtx.BuildAndSign(cliCtx.FromAddressName, passphrase, []sdk.Msg{msg})
-	if err != nil {
-		panic(err)
-	}
-
-	return res
+	return config, nil
 }
@@ -0,0 +1,176 @@
+package relayer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+)
+
+// Chain is the connection of the relayer to a chain
+type Chain interface {
+	ChainID() string
+
+	// Address of the account of the relayer on the chain
+	Address() sdk.AccAddress
+
+	// LatestHeight returns the height of the latest block of the chain
+	LatestHeight() (int64, error)
+
+	// SignedHeader returns the header at height along with the commit and the
+	// validator set that signed it
+	SignedHeader(height int64) (tmtypes.SignedHeader, *tmtypes.ValidatorSet, error)
+
+	// QueryStore returns the value at key in the store at height, along with
+	// the proof of it against the app hash of the header at height+1
+	QueryStore(key []byte, storeName string, height int64) (value []byte, proof []byte, err error)
+
+	// QuerySubspace returns the pairs of the store under the subspace at the
+	// latest height
+	QuerySubspace(subspace []byte, storeName string) ([]sdk.KVPair, error)
+
+	// SendMsgs signs the msgs in a single tx as the relayer and broadcasts it,
+	// returning once the tx is committed
+	SendMsgs(msgs []sdk.Msg) error
+}
+
+// rpcChain connects to a chain through the RPC of one of its nodes
+type rpcChain struct {
+	cdc        *wire.Codec
+	chainID    string
+	cliCtx     context.CLIContext
+	keyName    string
+	passphrase string
+	address    sdk.AccAddress
+	gasPerMsg  int64
+
+	// txs are signed and broadcast one at a time to get the sequences right
+	mtx sync.Mutex
+}
+
+var _ Chain = (*rpcChain)(nil)
+
+// NewRPCChain connects to a chain through the RPC of a node, signing txs with
+// the key of the keybase named keyName.
+func NewRPCChain(cdc *wire.Codec, config ChainConfig, keyName, passphrase string) (Chain, error) {
+	info, err := keys.GetKeyInfo(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPerMsg := config.GasPerMsg
+	if gasPerMsg == 0 {
+		gasPerMsg = defaultGasPerMsg
+	}
+
+	return &rpcChain{
+		cdc:     cdc,
+		chainID: config.ChainID,
+		cliCtx: context.NewCLIContext().
+			WithCodec(cdc).
+			WithNodeURI(config.Node).
+			WithTrustNode(true).
+			WithAccountDecoder(authcmd.GetAccountDecoder(cdc)),
+		keyName:    keyName,
+		passphrase: passphrase,
+		address:    sdk.AccAddress(info.GetPubKey().Address()),
+		gasPerMsg:  gasPerMsg,
+	}, nil
+}
+
+func (c *rpcChain) ChainID() string {
+	return c.chainID
+}
+
+func (c *rpcChain) Address() sdk.AccAddress {
+	return c.address
+}
+
+func (c *rpcChain) LatestHeight() (int64, error) {
+	node, err := c.cliCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func (c *rpcChain) SignedHeader(height int64) (tmtypes.SignedHeader, *tmtypes.ValidatorSet, error) {
+	node, err := c.cliCtx.GetNode()
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+
+	commit, err := node.Commit(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+
+	validators, err := node.Validators(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, err
+	}
+	return commit.SignedHeader, tmtypes.NewValidatorSet(validators.Validators), nil
+}
+
+func (c *rpcChain) QueryStore(key []byte, storeName string, height int64) (value []byte, proof []byte, err error) {
+	node, err := c.cliCtx.GetNode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/store/%s/key", storeName)
+	result, err := node.ABCIQueryWithOptions(path, key, rpcclient.ABCIQueryOptions{Height: height})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, nil, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, nil
+}
+
+func (c *rpcChain) QuerySubspace(subspace []byte, storeName string) ([]sdk.KVPair, error) {
+	return c.cliCtx.QuerySubspace(subspace, storeName)
+}
+
+func (c *rpcChain) SendMsgs(msgs []sdk.Msg) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	account, err := c.cliCtx.GetAccount(c.address)
+	if err != nil {
+		return err
+	}
+
+	txCtx := authctx.TxContext{
+		Codec:         c.cdc,
+		ChainID:       c.chainID,
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      account.GetSequence(),
+		Gas:           c.gasPerMsg * int64(len(msgs)),
+	}
+
+	txBytes, err := txCtx.BuildAndSign(c.keyName, c.passphrase, msgs)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.cliCtx.BroadcastTx(txBytes)
+	return err
+}
@@ -0,0 +1,113 @@
+package relayer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultBatchSize  = 10
+	defaultInterval   = 5 * time.Second
+	defaultMaxBackoff = 5 * time.Minute
+	defaultGasPerMsg  = 200000
+)
+
+// Config defines the chains the relayer connects to and the paths it relays
+// packets along
+type Config struct {
+	// Name of the key of the relayer, which must have an account on every chain
+	Key string `mapstructure:"key"`
+
+	// Address to serve the prometheus metrics on, disabled if empty
+	ListenAddr string `mapstructure:"listen_addr"`
+
+	// Maximum number of packets relayed in a single tx
+	BatchSize int `mapstructure:"batch_size"`
+
+	// Time between two relaying rounds of a path
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Maximum time to wait before retrying a failing path, the wait doubles
+	// from Interval on every consecutive failure
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	Chains []ChainConfig `mapstructure:"chains"`
+	Paths  []PathConfig  `mapstructure:"paths"`
+}
+
+// ChainConfig defines how to connect to a chain
+type ChainConfig struct {
+	ChainID string `mapstructure:"chain_id"`
+	Node    string `mapstructure:"node"`
+
+	// Gas of the txs of the relayer per msg they contain
+	GasPerMsg int64 `mapstructure:"gas_per_msg"`
+}
+
+// PathConfig defines a path packets are relayed along, from the egress queue
+// of the source chain to the destination chain
+type PathConfig struct {
+	Src  string `mapstructure:"src"`
+	Dest string `mapstructure:"dest"`
+}
+
+// DefaultConfig returns the default relayer configuration, without any chain
+func DefaultConfig() Config {
+	return Config{
+		BatchSize:  defaultBatchSize,
+		Interval:   defaultInterval,
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+// LoadConfig reads the relayer configuration from a TOML file
+func LoadConfig(file string) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, err
+	}
+
+	config := DefaultConfig()
+	if err := v.Unmarshal(&config); err != nil {
+		return Config{}, err
+	}
+	return config, config.ValidateBasic()
+}
+
+// ValidateBasic checks the configuration is complete and every path is
+// between two configured chains
+func (config Config) ValidateBasic() error {
+	if config.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	chains := make(map[string]bool)
+	for _, chain := range config.Chains {
+		if chain.ChainID == "" || chain.Node == "" {
+			return fmt.Errorf("chains must have a chain_id and a node")
+		}
+		if chains[chain.ChainID] {
+			return fmt.Errorf("chain %s is configured twice", chain.ChainID)
+		}
+		chains[chain.ChainID] = true
+	}
+
+	if len(config.Paths) == 0 {
+		return fmt.Errorf("no path to relay")
+	}
+	for _, path := range config.Paths {
+		if !chains[path.Src] || !chains[path.Dest] {
+			return fmt.Errorf("path from %s to %s is not between configured chains", path.Src, path.Dest)
+		}
+		if path.Src == path.Dest {
+			return fmt.Errorf("path from %s to itself", path.Src)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package relayer
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains the metrics exposed by the relayer, labeled with the
+// source and destination chains of the path.
+type Metrics struct {
+	// Index of the next egress packet to relay
+	NextIndex metrics.Gauge
+	// Number of packets relayed to the destination chain
+	PacketsRelayed metrics.Counter
+	// Number of acknowledgements and timeouts relayed back to the source chain
+	PacketsResolved metrics.Counter
+	// Number of failed relaying rounds
+	Errors metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics built by the Prometheus client library.
+func PrometheusMetrics() *Metrics {
+	labels := []string{"src", "dest"}
+	return &Metrics{
+		NextIndex: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "ibc_relayer",
+			Name:      "next_index",
+			Help:      "Index of the next egress packet to relay.",
+		}, labels),
+		PacketsRelayed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "ibc_relayer",
+			Name:      "packets_relayed",
+			Help:      "Number of packets relayed to the destination chain.",
+		}, labels),
+		PacketsResolved: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "ibc_relayer",
+			Name:      "packets_resolved",
+			Help:      "Number of acknowledgements and timeouts relayed back to the source chain.",
+		}, labels),
+		Errors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "ibc_relayer",
+			Name:      "errors",
+			Help:      "Number of failed relaying rounds.",
+		}, labels),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		NextIndex:       discard.NewGauge(),
+		PacketsRelayed:  discard.NewCounter(),
+		PacketsResolved: discard.NewCounter(),
+		Errors:          discard.NewCounter(),
+	}
+}
@@ -0,0 +1,316 @@
+package relayer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+const ibcStoreName = "ibc"
+
+// Relayer relays the packets of the egress queues of chains to their
+// destination chains, proving them against the light clients of the source
+// chains, and relays the acknowledgements and timeouts of the packets back to
+// the source chains. Each path is relayed independently, retrying with
+// backoff on errors.
+type Relayer struct {
+	cdc     *wire.Codec
+	config  Config
+	chains  map[string]Chain
+	state   state
+	metrics *Metrics
+	logger  log.Logger
+}
+
+// NewRelayer returns a relayer along the paths of the config between the
+// chains, persisting its progress in db.
+func NewRelayer(cdc *wire.Codec, config Config, chains []Chain, db dbm.DB, metrics *Metrics, logger log.Logger) *Relayer {
+	chainsByID := make(map[string]Chain, len(chains))
+	for _, chain := range chains {
+		chainsByID[chain.ChainID()] = chain
+	}
+
+	return &Relayer{
+		cdc:     cdc,
+		config:  config,
+		chains:  chainsByID,
+		state:   state{cdc: cdc, db: db},
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// Run relays every path until stop is closed.
+func (r *Relayer) Run(stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	for _, path := range r.config.Paths {
+		wg.Add(1)
+		go func(path PathConfig) {
+			defer wg.Done()
+			r.runPath(path, stop)
+		}(path)
+	}
+	wg.Wait()
+}
+
+// runPath relays the path every interval, doubling the wait up to the max
+// backoff as long as relaying fails.
+func (r *Relayer) runPath(path PathConfig, stop <-chan struct{}) {
+	logger := r.logger.With("src", path.Src, "dest", path.Dest)
+	wait := r.config.Interval
+
+	for {
+		err := r.RelayPath(path)
+		if err == nil {
+			wait = r.config.Interval
+		} else {
+			r.metrics.Errors.With("src", path.Src, "dest", path.Dest).Add(1)
+			logger.Error("error relaying path, retrying", "err", err, "wait", wait)
+
+			wait *= 2
+			if wait > r.config.MaxBackoff {
+				wait = r.config.MaxBackoff
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RelayPath runs a single relaying round along the path: it resolves the
+// pending packets of the source chain which are acknowledged or timed out,
+// then relays the new egress packets of the source chain in batches.
+func (r *Relayer) RelayPath(path PathConfig) error {
+	src, dest := r.chains[path.Src], r.chains[path.Dest]
+	if src == nil || dest == nil {
+		return fmt.Errorf("path from %s to %s is not between connected chains", path.Src, path.Dest)
+	}
+
+	err := r.resolvePackets(path, src, dest)
+	if err != nil {
+		return err
+	}
+	return r.relayPackets(path, src, dest)
+}
+
+// relayPackets relays the egress packets of src from the next index on.
+func (r *Relayer) relayPackets(path PathConfig, src, dest Chain) error {
+	// packets are proven against the app hash of the latest header, which
+	// commits the state of the previous block
+	proofHeight, err := src.LatestHeight()
+	if err != nil || proofHeight < 2 {
+		return err
+	}
+	queryHeight := proofHeight - 1
+
+	bz, _, err := src.QueryStore(ibc.EgressLengthKey(dest.ChainID()), ibcStoreName, queryHeight)
+	if err != nil {
+		return err
+	}
+	var egressLength int64
+	if bz != nil {
+		if err = r.cdc.UnmarshalBinary(bz, &egressLength); err != nil {
+			return err
+		}
+	}
+
+	// the ingress sequence of the destination chain tells which packets it
+	// actually processed, e.g. if another relayer serves the path as well or
+	// if relayed packets weren't committed, so the next index is taken from
+	// it rather than from the local state
+	bz, _, err = dest.QueryStore(ibc.IngressSequenceKey(src.ChainID()), ibcStoreName, 0)
+	if err != nil {
+		return err
+	}
+	var next int64
+	if bz != nil {
+		if err = r.cdc.UnmarshalBinary(bz, &next); err != nil {
+			return err
+		}
+	}
+	if recorded := r.state.NextIndex(path); recorded != next {
+		r.logger.Info("Reconciling the next IBC packet index with the destination chain",
+			"src", path.Src, "dest", path.Dest, "recorded", recorded, "next", next)
+		r.metrics.NextIndex.With("src", path.Src, "dest", path.Dest).Set(float64(next))
+		r.state.SetNextIndex(path, next)
+	}
+
+	for next < egressLength {
+		end := next + int64(r.config.BatchSize)
+		if end > egressLength {
+			end = egressLength
+		}
+
+		var msgs []sdk.Msg
+		for i := next; i < end; i++ {
+			bz, proof, err := src.QueryStore(ibc.EgressKey(dest.ChainID(), i), ibcStoreName, queryHeight)
+			if err != nil {
+				return err
+			}
+			var packet ibc.IBCPacket
+			if err = r.cdc.UnmarshalBinary(bz, &packet); err != nil {
+				return err
+			}
+
+			msgs = append(msgs, ibc.IBCReceiveMsg{
+				IBCPacket:   packet,
+				Relayer:     dest.Address(),
+				Sequence:    i,
+				ProofHeight: proofHeight,
+				Proof:       proof,
+			})
+		}
+
+		err = r.sendWithClient(dest, src, proofHeight, msgs)
+		if err != nil {
+			return err
+		}
+
+		r.logger.Info("Relayed IBC packets", "src", path.Src, "dest", path.Dest, "from", next, "to", end-1)
+		r.metrics.PacketsRelayed.With("src", path.Src, "dest", path.Dest).Add(float64(end - next))
+		r.metrics.NextIndex.With("src", path.Src, "dest", path.Dest).Set(float64(end))
+		r.state.SetNextIndex(path, end)
+		next = end
+	}
+	return nil
+}
+
+// resolvePackets relays the acknowledgements of the pending packets of src
+// back to it, and proves the timeouts of the pending packets dest will not
+// process anymore, which refunds them.
+func (r *Relayer) resolvePackets(path PathConfig, src, dest Chain) error {
+	pending, err := src.QuerySubspace(ibc.PendingPacketsKey(dest.ChainID()), ibcStoreName)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	proofHeight, err := dest.LatestHeight()
+	if err != nil || proofHeight < 2 {
+		return err
+	}
+	queryHeight := proofHeight - 1
+
+	header, _, err := dest.SignedHeader(proofHeight)
+	if err != nil {
+		return err
+	}
+
+	bz, ingressProof, err := dest.QueryStore(ibc.IngressSequenceKey(src.ChainID()), ibcStoreName, queryHeight)
+	if err != nil {
+		return err
+	}
+	var ingress int64
+	if bz != nil {
+		if err = r.cdc.UnmarshalBinary(bz, &ingress); err != nil {
+			return err
+		}
+	}
+
+	var msgs []sdk.Msg
+	for _, kv := range pending {
+		var seq int64
+		if err = r.cdc.UnmarshalBinary(kv.Value, &seq); err != nil {
+			return err
+		}
+
+		if seq < ingress {
+			bz, proof, err := dest.QueryStore(ibc.AcknowledgementKey(src.ChainID(), seq), ibcStoreName, queryHeight)
+			if err != nil {
+				return err
+			}
+			var ack ibc.IBCAcknowledgement
+			if err = r.cdc.UnmarshalBinary(bz, &ack); err != nil {
+				return err
+			}
+
+			msgs = append(msgs, ibc.IBCAcknowledgementMsg{
+				DestChain:       dest.ChainID(),
+				Sequence:        seq,
+				Acknowledgement: ack,
+				Relayer:         src.Address(),
+				ProofHeight:     proofHeight,
+				Proof:           proof,
+			})
+			continue
+		}
+
+		bz, _, err := src.QueryStore(ibc.EgressKey(dest.ChainID(), seq), ibcStoreName, 0)
+		if err != nil {
+			return err
+		}
+		var packet ibc.IBCPacket
+		if err = r.cdc.UnmarshalBinary(bz, &packet); err != nil {
+			return err
+		}
+
+		if packet.TimedOut(header.Header.Height, header.Header.Time) {
+			msgs = append(msgs, ibc.IBCTimeoutMsg{
+				DestChain:       dest.ChainID(),
+				Sequence:        seq,
+				IngressSequence: ingress,
+				Relayer:         src.Address(),
+				ProofHeight:     proofHeight,
+				Proof:           ingressProof,
+			})
+		}
+	}
+
+	for len(msgs) > 0 {
+		n := len(msgs)
+		if n > r.config.BatchSize {
+			n = r.config.BatchSize
+		}
+
+		err = r.sendWithClient(src, dest, proofHeight, msgs[:n])
+		if err != nil {
+			return err
+		}
+
+		r.logger.Info("Resolved IBC packets", "src", path.Src, "dest", path.Dest, "number", n)
+		r.metrics.PacketsResolved.With("src", path.Src, "dest", path.Dest).Add(float64(n))
+		msgs = msgs[n:]
+	}
+	return nil
+}
+
+// sendWithClient sends the msgs to chain, preceded by the msg creating or
+// updating the light client of counterparty up to height if needed.
+func (r *Relayer) sendWithClient(chain, counterparty Chain, height int64, msgs []sdk.Msg) error {
+	bz, _, err := chain.QueryStore(ibc.ClientLatestHeightKey(counterparty.ChainID()), ibcStoreName, 0)
+	if err != nil {
+		return err
+	}
+
+	var latest int64
+	if bz != nil {
+		if err = r.cdc.UnmarshalBinary(bz, &latest); err != nil {
+			return err
+		}
+	}
+
+	if latest < height {
+		header, validators, err := counterparty.SignedHeader(height)
+		if err != nil {
+			return err
+		}
+
+		var msg sdk.Msg = ibc.IBCUpdateClientMsg{Header: header, Validators: validators, Signer: chain.Address()}
+		if bz == nil {
+			msg = ibc.IBCCreateClientMsg{Header: header, Validators: validators, Signer: chain.Address()}
+		}
+		msgs = append([]sdk.Msg{msg}, msgs...)
+	}
+
+	return chain.SendMsgs(msgs)
+}
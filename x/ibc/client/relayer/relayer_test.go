@@ -0,0 +1,343 @@
+package relayer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	gaia "github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// appChain runs a gaia app in process, producing a block for every tx
+type appChain struct {
+	t          *testing.T
+	cdc        *wire.Codec
+	app        *gaia.GaiaApp
+	chainID    string
+	validators []ed25519.PrivKeyEd25519
+	relayer    crypto.PrivKey
+	appHashes  map[int64][]byte
+	height     int64
+}
+
+var _ Chain = (*appChain)(nil)
+
+// newAppChain starts the chain of the validators, trusting the light client
+// of the counterparty chain at genesis
+func newAppChain(t *testing.T, chainID string, validators []ed25519.PrivKeyEd25519, counterparty ibc.TrustedClient,
+	relayer crypto.PrivKey, accs ...*auth.BaseAccount) *appChain {
+
+	c := &appChain{
+		t:          t,
+		cdc:        gaia.MakeCodec(),
+		app:        gaia.NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil),
+		chainID:    chainID,
+		validators: validators,
+		relayer:    relayer,
+		appHashes:  make(map[int64][]byte),
+	}
+
+	accs = append(accs, &auth.BaseAccount{Address: c.Address()})
+	genaccs := make([]gaia.GenesisAccount, len(accs))
+	for i, acc := range accs {
+		genaccs[i] = gaia.NewGenesisAccount(acc)
+	}
+	genesisState := gaia.GenesisState{
+		Accounts:  genaccs,
+		StakeData: stake.DefaultGenesisState(),
+		IBCData:   ibc.GenesisState{TrustedClients: []ibc.TrustedClient{counterparty}},
+	}
+	stateBytes, err := wire.MarshalJSONIndent(c.cdc, genesisState)
+	require.Nil(t, err)
+
+	c.app.InitChain(abci.RequestInitChain{ChainId: chainID, AppStateBytes: stateBytes})
+	c.block()
+	return c
+}
+
+func blockTime(height int64) time.Time {
+	return time.Unix(1000+height*5, 0).UTC()
+}
+
+// block produces the next block with the txs, returning their results
+func (c *appChain) block(txs ...auth.StdTx) []sdk.Result {
+	height := c.height + 1
+	header := abci.Header{ChainID: c.chainID, Height: height, Time: blockTime(height)}
+	c.app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	results := make([]sdk.Result, len(txs))
+	for i, tx := range txs {
+		results[i] = c.app.Deliver(tx)
+	}
+
+	c.app.EndBlock(abci.RequestEndBlock{Height: height})
+	c.app.Commit()
+	c.appHashes[height] = c.app.LastCommitID().Hash
+	c.height = height
+	return results
+}
+
+// deliver signs the msgs in a tx and delivers it in a new block
+func (c *appChain) deliver(priv crypto.PrivKey, msgs ...sdk.Msg) sdk.Result {
+	account := c.account(sdk.AccAddress(priv.PubKey().Address()))
+	fee := auth.NewStdFee(10000000)
+	signBytes := auth.StdSignBytes(c.chainID, account.GetAccountNumber(), account.GetSequence(), fee, msgs, "")
+	sig, err := priv.Sign(signBytes)
+	require.Nil(c.t, err)
+
+	tx := auth.NewStdTx(msgs, fee, []auth.StdSignature{{
+		PubKey:        priv.PubKey(),
+		Signature:     sig,
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      account.GetSequence(),
+	}}, "")
+	return c.block(tx)[0]
+}
+
+// account queries the account at the last block, the default height of the
+// queries being the one before
+func (c *appChain) account(addr sdk.AccAddress) auth.Account {
+	bz, _, err := c.QueryStore(auth.AddressStoreKey(addr), "acc", c.height)
+	require.Nil(c.t, err)
+	require.NotNil(c.t, bz, "no account %s", addr)
+
+	var account auth.Account
+	require.Nil(c.t, c.cdc.UnmarshalBinaryBare(bz, &account))
+	return account
+}
+
+func (c *appChain) coins(addr sdk.AccAddress) sdk.Coins {
+	return c.account(addr).GetCoins()
+}
+
+func (c *appChain) ChainID() string {
+	return c.chainID
+}
+
+func (c *appChain) Address() sdk.AccAddress {
+	return sdk.AccAddress(c.relayer.PubKey().Address())
+}
+
+func (c *appChain) LatestHeight() (int64, error) {
+	return c.height, nil
+}
+
+// SignedHeader signs the header at height, which commits the app hash of the
+// previous block
+func validatorSet(privs []ed25519.PrivKeyEd25519) *tmtypes.ValidatorSet {
+	validators := make([]*tmtypes.Validator, len(privs))
+	for i, priv := range privs {
+		validators[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	return tmtypes.NewValidatorSet(validators)
+}
+
+func (c *appChain) SignedHeader(height int64) (tmtypes.SignedHeader, *tmtypes.ValidatorSet, error) {
+	appHash, ok := c.appHashes[height-1]
+	if !ok && height != 1 {
+		return tmtypes.SignedHeader{}, nil, fmt.Errorf("no block at height %d", height-1)
+	}
+
+	valSet := validatorSet(c.validators)
+
+	header := &tmtypes.Header{
+		ChainID:        c.chainID,
+		Height:         height,
+		Time:           blockTime(height),
+		ValidatorsHash: valSet.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, valSet.Size())
+	for _, priv := range c.validators {
+		idx, _ := valSet.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: priv.PubKey().Address(),
+			ValidatorIndex:   idx,
+			Height:           height,
+			Timestamp:        header.Time,
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		sig, err := priv.Sign(vote.SignBytes(c.chainID))
+		if err != nil {
+			return tmtypes.SignedHeader{}, nil, err
+		}
+		vote.Signature = sig
+		precommits[idx] = vote
+	}
+
+	commit := &tmtypes.Commit{BlockID: blockID, Precommits: precommits}
+	return tmtypes.SignedHeader{Header: header, Commit: commit}, valSet, nil
+}
+
+func (c *appChain) QueryStore(key []byte, storeName string, height int64) (value []byte, proof []byte, err error) {
+	res := c.app.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", storeName),
+		Data:   key,
+		Height: height,
+		Prove:  true,
+	})
+	if !res.IsOK() {
+		return nil, nil, fmt.Errorf("query failed: (%d) %s", res.Code, res.Log)
+	}
+	return res.Value, res.Proof, nil
+}
+
+func (c *appChain) QuerySubspace(subspace []byte, storeName string) (pairs []sdk.KVPair, err error) {
+	res := c.app.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/subspace", storeName),
+		Data:   subspace,
+		Height: c.height,
+	})
+	if !res.IsOK() {
+		return nil, fmt.Errorf("query failed: (%d) %s", res.Code, res.Log)
+	}
+	err = c.cdc.UnmarshalBinary(res.Value, &pairs)
+	return pairs, err
+}
+
+func (c *appChain) SendMsgs(msgs []sdk.Msg) error {
+	res := c.deliver(c.relayer, msgs...)
+	if !res.IsOK() {
+		return fmt.Errorf("deliverTx failed: (%d) %s", res.Code, res.Log)
+	}
+	return nil
+}
+
+func TestRelayer(t *testing.T) {
+	relayerKey := ed25519.GenPrivKey()
+	aliceKey, bobKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	alice := sdk.AccAddress(aliceKey.PubKey().Address())
+	bob := sdk.AccAddress(bobKey.PubKey().Address())
+	atoms := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("atom", amount)} }
+	vouchers := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("chain-a/atom", amount)} }
+
+	validatorsA := []ed25519.PrivKeyEd25519{ed25519.GenPrivKey(), ed25519.GenPrivKey()}
+	validatorsB := []ed25519.PrivKeyEd25519{ed25519.GenPrivKey(), ed25519.GenPrivKey()}
+	chainA := newAppChain(t, "chain-a", validatorsA, ibc.TrustedClient{ChainID: "chain-b", ValidatorsHash: validatorSet(validatorsB).Hash()},
+		relayerKey, &auth.BaseAccount{Address: alice, Coins: atoms(100)})
+	chainB := newAppChain(t, "chain-b", validatorsB, ibc.TrustedClient{ChainID: "chain-a", ValidatorsHash: validatorSet(validatorsA).Hash()},
+		relayerKey, &auth.BaseAccount{Address: bob})
+
+	config := DefaultConfig()
+	config.BatchSize = 2
+	config.Paths = []PathConfig{{Src: "chain-a", Dest: "chain-b"}, {Src: "chain-b", Dest: "chain-a"}}
+	pathAB, pathBA := config.Paths[0], config.Paths[1]
+
+	db := dbm.NewMemDB()
+	r := NewRelayer(chainA.cdc, config, []Chain{chainA, chainB}, db, NopMetrics(), log.NewNopLogger())
+
+	// nothing to relay yet
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Nil(t, r.RelayPath(pathBA))
+
+	transfer := func(amount, timeoutHeight int64) ibc.IBCTransferMsg {
+		return ibc.NewIBCTransferMsg(alice, bob, atoms(amount), "chain-a", "chain-b", timeoutHeight, time.Time{})
+	}
+
+	// three packets are relayed in two batches
+	res := chainA.deliver(aliceKey, transfer(10, 100), transfer(20, 100), transfer(30, 100))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, atoms(40), chainA.coins(alice))
+
+	// the packets are proven once the next block commits them
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Equal(t, int64(0), r.state.NextIndex(pathAB))
+	chainA.block()
+
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Equal(t, vouchers(60), chainB.coins(bob))
+	require.Equal(t, int64(3), r.state.NextIndex(pathAB))
+
+	// the acknowledgements are relayed back, resolving the pending packets
+	pending, err := chainA.QuerySubspace(ibc.PendingPacketsKey("chain-b"), "ibc")
+	require.Nil(t, err)
+	require.Len(t, pending, 3)
+	chainB.block()
+	require.Nil(t, r.RelayPath(pathAB))
+	pending, err = chainA.QuerySubspace(ibc.PendingPacketsKey("chain-b"), "ibc")
+	require.Nil(t, err)
+	require.Len(t, pending, 0)
+	require.Equal(t, atoms(40), chainA.coins(alice))
+
+	// a packet not relayed before its timeout is refunded
+	res = chainA.deliver(aliceKey, transfer(5, chainB.height+2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, atoms(35), chainA.coins(alice))
+	chainA.block()
+	chainB.block()
+	chainB.block()
+
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Equal(t, atoms(40), chainA.coins(alice))
+	require.Equal(t, vouchers(60), chainB.coins(bob))
+	require.Equal(t, int64(4), r.state.NextIndex(pathAB))
+
+	// a restarted relayer picks up where it left off
+	r = NewRelayer(chainA.cdc, config, []Chain{chainA, chainB}, db, NopMetrics(), log.NewNopLogger())
+	require.Equal(t, int64(4), r.state.NextIndex(pathAB))
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Equal(t, int64(4), r.state.NextIndex(pathAB))
+
+	// packets are relayed the other way around as well, sending the vouchers back
+	res = chainB.deliver(bobKey, ibc.NewIBCTransferMsg(bob, alice, vouchers(15), "chain-b", "chain-a", 100, time.Time{}))
+	require.True(t, res.IsOK(), res.Log)
+	chainB.block()
+	require.Nil(t, r.RelayPath(pathBA))
+	require.Equal(t, atoms(55), chainA.coins(alice))
+	require.Equal(t, vouchers(45), chainB.coins(bob))
+	require.Equal(t, int64(1), r.state.NextIndex(pathBA))
+
+	// a recorded index ahead of the destination chain is reconciled with it
+	r.state.SetNextIndex(pathAB, 10)
+	res = chainA.deliver(aliceKey, transfer(5, 100))
+	require.True(t, res.IsOK(), res.Log)
+	chainA.block()
+	require.Nil(t, r.RelayPath(pathAB))
+	require.Equal(t, vouchers(50), chainB.coins(bob))
+	require.Equal(t, int64(5), r.state.NextIndex(pathAB))
+
+	// relaying fails on an unknown chain
+	require.NotNil(t, r.RelayPath(PathConfig{Src: "chain-a", Dest: "chain-c"}))
+}
+
+func TestConfigValidateBasic(t *testing.T) {
+	chains := []ChainConfig{{ChainID: "chain-a", Node: "tcp://localhost:26657"}, {ChainID: "chain-b", Node: "tcp://localhost:36657"}}
+
+	config := DefaultConfig()
+	config.Chains = chains
+	require.NotNil(t, config.ValidateBasic())
+
+	config.Paths = []PathConfig{{Src: "chain-a", Dest: "chain-b"}}
+	require.Nil(t, config.ValidateBasic())
+
+	config.Paths = []PathConfig{{Src: "chain-a", Dest: "chain-c"}}
+	require.NotNil(t, config.ValidateBasic())
+
+	config.Paths = []PathConfig{{Src: "chain-a", Dest: "chain-a"}}
+	require.NotNil(t, config.ValidateBasic())
+
+	config.Paths = []PathConfig{{Src: "chain-a", Dest: "chain-b"}}
+	config.Chains = append(chains, chains[0])
+	require.NotNil(t, config.ValidateBasic())
+
+	config.Chains = chains
+	config.BatchSize = 0
+	require.NotNil(t, config.ValidateBasic())
+}
@@ -0,0 +1,40 @@
+package relayer
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// state persists the progress of the relayer on each path, so that it picks
+// up where it left off after a restart
+type state struct {
+	cdc *wire.Codec
+	db  dbm.DB
+}
+
+// Stores the index of the next egress packet to relay along a path under
+// "next/src_chain_id/dest_chain_id".
+func nextIndexKey(path PathConfig) []byte {
+	return []byte(fmt.Sprintf("next/%s/%s", path.Src, path.Dest))
+}
+
+// NextIndex returns the index of the next egress packet of the source chain
+// of the path to relay.
+func (s state) NextIndex(path PathConfig) int64 {
+	bz := s.db.Get(nextIndexKey(path))
+	if bz == nil {
+		return 0
+	}
+	var index int64
+	s.cdc.MustUnmarshalBinary(bz, &index)
+	return index
+}
+
+// SetNextIndex records that the egress packets of the source chain of the
+// path before index are relayed.
+func (s state) SetNextIndex(path PathConfig, index int64) {
+	s.db.SetSync(nextIndexKey(path), s.cdc.MustMarshalBinary(index))
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -31,6 +32,8 @@ type transferBody struct {
 	Sequence         int64     `json:"sequence"`
 	Gas              int64     `json:"gas"`
 	GasAdjustment    string    `json:"gas_adjustment"`
+	TimeoutHeight    int64     `json:"timeout_height"`
+	TimeoutTime      time.Time `json:"timeout_time"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -68,8 +71,8 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.C
 		}
 
 		// build message
-		packet := ibc.NewIBCPacket(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID)
-		msg := ibc.IBCTransferMsg{packet}
+		msg := ibc.NewIBCTransferMsg(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID,
+			m.TimeoutHeight, m.TimeoutTime)
 
 		txCtx := authctx.TxContext{
 			Codec:         cdc,
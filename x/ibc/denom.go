@@ -0,0 +1,87 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DenomTrace is the path a voucher denom was transferred along, from the
+// chain the vouchers were last received from back to the chain of the base
+// denom.
+type DenomTrace struct {
+	Path      []string `json:"path"`
+	BaseDenom string   `json:"base_denom"`
+}
+
+// ParseDenomTrace splits a denom into the chains its vouchers came through
+// and its base denom. Native denoms have an empty path.
+func ParseDenomTrace(denom string) DenomTrace {
+	parts := strings.Split(denom, "/")
+	return DenomTrace{
+		Path:      parts[:len(parts)-1],
+		BaseDenom: parts[len(parts)-1],
+	}
+}
+
+// IsVoucher returns true if the denom was received from another chain.
+func (trace DenomTrace) IsVoucher() bool {
+	return len(trace.Path) > 0
+}
+
+// Denom returns the denom of the vouchers on the chain they were last
+// received on.
+func (trace DenomTrace) Denom() string {
+	if !trace.IsVoucher() {
+		return trace.BaseDenom
+	}
+	return strings.Join(trace.Path, "/") + "/" + trace.BaseDenom
+}
+
+func (trace DenomTrace) String() string {
+	if !trace.IsVoucher() {
+		return fmt.Sprintf("%s (native)", trace.BaseDenom)
+	}
+	return fmt.Sprintf("%s (from %s)", trace.BaseDenom, strings.Join(trace.Path, " <- "))
+}
+
+// VoucherDenom returns the denom of the vouchers minted for denom received
+// from srcChain.
+func VoucherDenom(srcChain, denom string) string {
+	return srcChain + "/" + denom
+}
+
+// returnsTo returns true if the denom is a voucher of chainID, which is burned
+// when sent back to it.
+func returnsTo(denom, chainID string) bool {
+	trace := ParseDenomTrace(denom)
+	return trace.IsVoucher() && trace.Path[0] == chainID
+}
+
+// escrowedCoins returns the coins sent to destChain the source chain escrows,
+// which are released once they come back. The other coins are vouchers sent
+// back to destChain, which are burned.
+func escrowedCoins(coins sdk.Coins, destChain string) (escrowed sdk.Coins) {
+	for _, coin := range coins {
+		if !returnsTo(coin.Denom, destChain) {
+			escrowed = append(escrowed, coin)
+		}
+	}
+	return escrowed
+}
+
+// receivedCoins splits the coins received from srcChain into the coins
+// destChain releases from its escrow, stripped of the voucher prefix, and the
+// vouchers it mints.
+func receivedCoins(coins sdk.Coins, srcChain, destChain string) (released sdk.Coins, vouchers sdk.Coins) {
+	for _, coin := range coins {
+		if returnsTo(coin.Denom, destChain) {
+			denom := strings.TrimPrefix(coin.Denom, destChain+"/")
+			released = append(released, sdk.NewCoin(denom, coin.Amount))
+		} else {
+			vouchers = append(vouchers, sdk.NewCoin(VoucherDenom(srcChain, coin.Denom), coin.Amount))
+		}
+	}
+	return released.Sort(), vouchers.Sort()
+}
@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,6 +13,20 @@ const (
 	// IBC errors reserve 200 - 299.
 	CodeInvalidSequence sdk.CodeType = 200
 	CodeIdenticalChains sdk.CodeType = 201
+	CodeInvalidProof    sdk.CodeType = 202
+	CodeInvalidHeader   sdk.CodeType = 203
+	CodeClientExists    sdk.CodeType = 204
+	CodeNoClient        sdk.CodeType = 205
+	CodeWrongChain      sdk.CodeType = 206
+	CodeInvalidTimeout  sdk.CodeType = 207
+	CodeNoPendingPacket sdk.CodeType = 208
+	CodeNotTimedOut     sdk.CodeType = 209
+	CodeInvalidEscrow   sdk.CodeType = 210
+	CodeUnknownDenom    sdk.CodeType = 211
+	CodeInvalidPort     sdk.CodeType = 212
+	CodeUnknownPort     sdk.CodeType = 213
+	CodeInvalidPayload  sdk.CodeType = 214
+	CodeUntrustedClient sdk.CodeType = 215
 	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
 )
 
@@ -20,6 +36,34 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeInvalidProof:
+		return "invalid IBC packet proof"
+	case CodeInvalidHeader:
+		return "invalid header"
+	case CodeClientExists:
+		return "light client already exists"
+	case CodeNoClient:
+		return "no light client"
+	case CodeWrongChain:
+		return "IBC packet not destined to this chain"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodeNoPendingPacket:
+		return "no pending IBC packet"
+	case CodeNotTimedOut:
+		return "IBC packet has not timed out"
+	case CodeInvalidEscrow:
+		return "not enough escrowed coins"
+	case CodeUnknownDenom:
+		return "unknown voucher denom"
+	case CodeInvalidPort:
+		return "invalid IBC port"
+	case CodeUnknownPort:
+		return "no application bound to the IBC port"
+	case CodeInvalidPayload:
+		return "invalid IBC packet payload"
+	case CodeUntrustedClient:
+		return "untrusted light client"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +76,48 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidHeader, msg)
+}
+func ErrClientExists(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeClientExists, fmt.Sprintf("light client of chain %s already exists", chainID))
+}
+func ErrNoClient(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeNoClient, fmt.Sprintf("no light client of chain %s", chainID))
+}
+func ErrWrongChain(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeWrongChain, "")
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, msg)
+}
+func ErrNoPendingPacket(codespace sdk.CodespaceType, destChain string, sequence int64) sdk.Error {
+	return newError(codespace, CodeNoPendingPacket, fmt.Sprintf("no pending IBC packet %d to chain %s", sequence, destChain))
+}
+func ErrNotTimedOut(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNotTimedOut, "")
+}
+func ErrInvalidEscrow(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeInvalidEscrow, fmt.Sprintf("not enough coins escrowed for chain %s", chainID))
+}
+func ErrUnknownDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeUnknownDenom, fmt.Sprintf("unknown voucher denom %s", denom))
+}
+func ErrInvalidPort(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidPort, msg)
+}
+func ErrUnknownPort(codespace sdk.CodespaceType, port string) sdk.Error {
+	return newError(codespace, CodeUnknownPort, fmt.Sprintf("no application bound to IBC port %s", port))
+}
+func ErrInvalidPayload(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidPayload, msg)
+}
+func ErrUntrustedClient(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeUntrustedClient, msg)
+}
 
 // -------------------------
 // Helpers
@@ -0,0 +1,33 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the validator sets the light clients can be created from at
+// genesis
+type GenesisState struct {
+	TrustedClients []TrustedClient `json:"trusted_clients"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the trusted clients
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) {
+	for _, trusted := range data.TrustedClients {
+		ibcm.TrustClient(ctx, trusted)
+	}
+}
+
+// WriteGenesis - output the trusted clients
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	var trustedClients []TrustedClient
+	ibcm.IterateTrustedClients(ctx, func(trusted TrustedClient) (stop bool) {
+		trustedClients = append(trustedClients, trusted)
+		return false
+	})
+	return GenesisState{trustedClients}
+}
@@ -4,16 +4,23 @@ import (
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
-func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
+// NewHandler returns the handler of the IBC msgs, routing the packets to the
+// applications bound to their ports.
+func NewHandler(ibcm Mapper, router Router) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
-		case IBCTransferMsg:
-			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
 		case IBCReceiveMsg:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, router, msg)
+		case IBCAcknowledgementMsg:
+			return handleIBCAcknowledgementMsg(ctx, ibcm, router, msg)
+		case IBCTimeoutMsg:
+			return handleIBCTimeoutMsg(ctx, ibcm, router, msg)
+		case IBCCreateClientMsg:
+			return handleIBCCreateClientMsg(ctx, ibcm, msg)
+		case IBCUpdateClientMsg:
+			return handleIBCUpdateClientMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,38 +28,123 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
-func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
+// IBCReceiveMsg routes a packet to the application bound to its destination
+// port and creates an ingress IBC packet, once the packet is proven against
+// the light client of the source chain. The outcome is written as the
+// acknowledgement of the packet, timed out packets and packets to unbound
+// ports are acknowledged with an error.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, router Router, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	if packet.DestChain != ctx.ChainID() {
+		return ErrWrongChain(ibcm.codespace).Result()
+	}
+
+	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
+	if msg.Sequence != seq {
+		return ErrInvalidSequence(ibcm.codespace).Result()
+	}
+
+	err := ibcm.VerifyPacket(ctx, packet, msg.Sequence, msg.ProofHeight, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	var ack IBCAcknowledgement
+	h := router.Route(packet.DestPort)
+	switch {
+	case packet.TimedOut(ctx.BlockHeight(), ctx.BlockHeader().Time):
+		ack = IBCAcknowledgement{Error: "packet timed out"}
+	case h == nil:
+		ack = IBCAcknowledgement{Error: ErrUnknownPort(ibcm.codespace, packet.DestPort).ABCILog()}
+	default:
+		cacheCtx, write := ctx.CacheContext()
+		ack = h.OnReceive(cacheCtx, packet)
+		if ack.Success {
+			write()
+		}
+	}
+
+	ibcm.SetAcknowledgement(ctx, packet.SrcChain, seq, ack)
+	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+
+	return sdk.Result{}
+}
+
+// IBCAcknowledgementMsg resolves a pending packet once its acknowledgement is
+// proven against the light client of the destination chain, and calls back
+// the application bound to the source port with it.
+func handleIBCAcknowledgementMsg(ctx sdk.Context, ibcm Mapper, router Router, msg IBCAcknowledgementMsg) sdk.Result {
+	packet, found := ibcm.GetPendingPacket(ctx, msg.DestChain, msg.Sequence)
+	if !found {
+		return ErrNoPendingPacket(ibcm.codespace, msg.DestChain, msg.Sequence).Result()
+	}
+
+	h := router.Route(packet.SrcPort)
+	if h == nil {
+		return ErrUnknownPort(ibcm.codespace, packet.SrcPort).Result()
+	}
+
+	err := ibcm.VerifyAcknowledgement(ctx, packet, msg.Sequence, msg.Acknowledgement, msg.ProofHeight, msg.Proof)
 	if err != nil {
 		return err.Result()
 	}
 
-	err = ibcm.PostIBCPacket(ctx, packet)
+	err = h.OnAcknowledgement(ctx, packet, msg.Acknowledgement)
 	if err != nil {
 		return err.Result()
 	}
 
+	ibcm.ResolvePacket(ctx, msg.DestChain, msg.Sequence)
+
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
-	packet := msg.IBCPacket
+// IBCTimeoutMsg resolves a pending packet once it is proven that the packet
+// timed out before the destination chain processed it, and calls back the
+// application bound to the source port.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, router Router, msg IBCTimeoutMsg) sdk.Result {
+	packet, found := ibcm.GetPendingPacket(ctx, msg.DestChain, msg.Sequence)
+	if !found {
+		return ErrNoPendingPacket(ibcm.codespace, msg.DestChain, msg.Sequence).Result()
+	}
 
-	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
-	if msg.Sequence != seq {
-		return ErrInvalidSequence(ibcm.codespace).Result()
+	h := router.Route(packet.SrcPort)
+	if h == nil {
+		return ErrUnknownPort(ibcm.codespace, packet.SrcPort).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	err := ibcm.VerifyTimeout(ctx, packet, msg.Sequence, msg.IngressSequence, msg.ProofHeight, msg.Proof)
 	if err != nil {
 		return err.Result()
 	}
 
-	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+	err = h.OnTimeout(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.ResolvePacket(ctx, msg.DestChain, msg.Sequence)
+
+	return sdk.Result{}
+}
+
+// IBCCreateClientMsg creates the light client of a source chain.
+func handleIBCCreateClientMsg(ctx sdk.Context, ibcm Mapper, msg IBCCreateClientMsg) sdk.Result {
+	err := ibcm.CreateClient(ctx, msg.Header, msg.Validators)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// IBCUpdateClientMsg verifies a new header of a source chain.
+func handleIBCUpdateClientMsg(ctx sdk.Context, ibcm Mapper, msg IBCUpdateClientMsg) sdk.Result {
+	err := ibcm.UpdateClient(ctx, msg.Header, msg.Validators)
+	if err != nil {
+		return err.Result()
+	}
 
 	return sdk.Result{}
 }
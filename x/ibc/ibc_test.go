@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -47,6 +48,10 @@ func makeCodec() *wire.Codec {
 	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCCreateClientMsg{}, "test/ibc/IBCCreateClientMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateClientMsg{}, "test/ibc/IBCUpdateClientMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "test/ibc/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "test/ibc/IBCTimeoutMsg", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -69,7 +74,8 @@ func TestIBC(t *testing.T) {
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
+	srcChain := "source-chain"
+	destChain := "dest-chain"
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
 
@@ -78,14 +84,10 @@ func TestIBC(t *testing.T) {
 	require.Equal(t, mycoins, coins)
 
 	ibcm := NewMapper(cdc, key, DefaultCodespace)
-	h := NewHandler(ibcm, ck)
-	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
-	}
+	transferApp := NewTransferApp(ibcm, ck)
+	h := NewHandler(ibcm, NewRouter().AddRoute(TransferPort, transferApp))
+	transfer := NewTransferHandler(transferApp)
+	transferMsg := NewIBCTransferMsg(src, dest, mycoins, srcChain, destChain, 100, time.Time{})
 
 	store := ctx.KVStore(key)
 
@@ -94,43 +96,41 @@ func TestIBC(t *testing.T) {
 	var egl int64
 	var igs int64
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = ibcm.getEgressLength(store, destChain)
 	require.Equal(t, egl, int64(0))
 
-	msg = IBCTransferMsg{
-		IBCPacket: packet,
-	}
-	res = h(ctx, msg)
+	res = transfer(ctx, transferMsg)
 	require.True(t, res.IsOK())
 
 	coins, err = getCoins(ck, ctx, src)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = ibcm.getEgressLength(store, destChain)
 	require.Equal(t, egl, int64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	// ibc msgs are not handled by the transfer application and vice versa
+	res = transfer(ctx, IBCTimeoutMsg{})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), res.Code)
+	res = h(ctx, transferMsg)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), res.Code)
+
+	igs = ibcm.GetIngressSequence(ctx, srcChain)
 	require.Equal(t, igs, int64(0))
 
+	// packets are only received with a proof against a light client
 	msg = IBCReceiveMsg{
-		IBCPacket: packet,
+		IBCPacket: transferMsg.Packet(),
 		Relayer:   src,
 		Sequence:  0,
 	}
-	res = h(ctx, msg)
-	require.True(t, res.IsOK())
+	res = h(ctx.WithChainID(destChain), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code)
 
 	coins, err = getCoins(ck, ctx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
-
-	igs = ibcm.GetIngressSequence(ctx, chainid)
-	require.Equal(t, igs, int64(1))
-
-	res = h(ctx, msg)
-	require.False(t, res.IsOK())
+	require.Equal(t, zero, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
-	require.Equal(t, igs, int64(1))
+	igs = ibcm.GetIngressSequence(ctx, srcChain)
+	require.Equal(t, igs, int64(0))
 }
@@ -0,0 +1,267 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// The light client of a source chain tracks the validator set of the chain
+// and the headers signed by it, so that the packets the chain posted can be
+// proven against the app hash of a verified header.
+
+// TrustedClient is the hash of the validator set from which the light client
+// of a chain can be created, fixed on-chain by governance before the client
+// is created.
+type TrustedClient struct {
+	ChainID        string       `json:"chain_id"`
+	ValidatorsHash cmn.HexBytes `json:"validators_hash"`
+}
+
+// TrustClient fixes the hash of the validator set from which the light client
+// of the chain can be created, replacing any hash fixed before.
+func (ibcm Mapper) TrustClient(ctx sdk.Context, trusted TrustedClient) {
+	ctx.KVStore(ibcm.key).Set(TrustedClientKey(trusted.ChainID), trusted.ValidatorsHash)
+}
+
+// GetTrustedValidatorsHash returns the hash of the validator set from which
+// the light client of the chain can be created, nil if none was fixed.
+func (ibcm Mapper) GetTrustedValidatorsHash(ctx sdk.Context, chainID string) []byte {
+	return ctx.KVStore(ibcm.key).Get(TrustedClientKey(chainID))
+}
+
+// IterateTrustedClients calls fn on the trusted clients until it returns true
+func (ibcm Mapper) IterateTrustedClients(ctx sdk.Context, fn func(trusted TrustedClient) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(ibcm.key), TrustedClientKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		chainID := string(iter.Key()[len(TrustedClientKeyPrefix):])
+		if fn(TrustedClient{chainID, iter.Value()}) {
+			return
+		}
+	}
+}
+
+// CreateClient starts tracking the chain of the header from the given
+// validator set, which must have signed the header. The validator set must
+// match the hash fixed for the chain with TrustClient.
+func (ibcm Mapper) CreateClient(ctx sdk.Context, header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	if header.Header == nil {
+		return ErrInvalidHeader(ibcm.codespace, "missing header")
+	}
+	chainID := header.Header.ChainID
+	if ibcm.GetClientValidators(ctx, chainID) != nil {
+		return ErrClientExists(ibcm.codespace, chainID)
+	}
+
+	trustedHash := ibcm.GetTrustedValidatorsHash(ctx, chainID)
+	if trustedHash == nil {
+		return ErrUntrustedClient(ibcm.codespace, fmt.Sprintf("no trusted validator set for chain %s", chainID))
+	}
+	if validators == nil || !bytes.Equal(validators.Hash(), trustedHash) {
+		return ErrUntrustedClient(ibcm.codespace, fmt.Sprintf("validator set of chain %s is not the trusted one", chainID))
+	}
+
+	err := verifyHeader(chainID, validators, header)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	ibcm.setClientHeader(ctx, chainID, validators, *header.Header)
+	return nil
+}
+
+// UpdateClient verifies a new header of the chain, signed by the given
+// validator set, and trusts the validator set from then on. If the validator
+// set changed, more than 2/3 of the trusted validator set must have signed
+// the header as well.
+func (ibcm Mapper) UpdateClient(ctx sdk.Context, header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	if header.Header == nil {
+		return ErrInvalidHeader(ibcm.codespace, "missing header")
+	}
+	chainID := header.Header.ChainID
+	trusted := ibcm.GetClientValidators(ctx, chainID)
+	if trusted == nil {
+		return ErrNoClient(ibcm.codespace, chainID)
+	}
+
+	latest := ibcm.GetClientLatestHeight(ctx, chainID)
+	if header.Header.Height <= latest {
+		return ErrInvalidHeader(ibcm.codespace,
+			fmt.Sprintf("header height %d is not above the latest verified height %d", header.Header.Height, latest))
+	}
+
+	err := verifyHeader(chainID, validators, header)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	if !bytes.Equal(validators.Hash(), trusted.Hash()) {
+		err = trusted.VerifyCommitAny(validators, chainID, header.Commit.BlockID, header.Header.Height, header.Commit)
+		if err != nil {
+			return ErrInvalidHeader(ibcm.codespace, err.Error())
+		}
+	}
+
+	ibcm.setClientHeader(ctx, chainID, validators, *header.Header)
+	return nil
+}
+
+// verifyHeader checks the header is signed by the validator set of its hash
+func verifyHeader(chainID string, validators *tmtypes.ValidatorSet, header tmtypes.SignedHeader) error {
+	if header.Commit == nil {
+		return fmt.Errorf("missing commit")
+	}
+	if validators == nil || validators.Size() == 0 {
+		return fmt.Errorf("missing validator set")
+	}
+	if !bytes.Equal(header.Header.ValidatorsHash, validators.Hash()) {
+		return fmt.Errorf("validator set does not match the header validators hash")
+	}
+	if !bytes.Equal(header.Commit.BlockID.Hash, header.Header.Hash()) {
+		return fmt.Errorf("commit is not for the header")
+	}
+	return validators.VerifyCommit(chainID, header.Commit.BlockID, header.Header.Height, header.Commit)
+}
+
+// VerifyPacket checks the proof that the source chain of the packet posted it
+// at EgressKey(packet.DestChain, sequence).
+func (ibcm Mapper) VerifyPacket(ctx sdk.Context, packet IBCPacket, sequence int64, proofHeight int64, proof []byte) sdk.Error {
+	return ibcm.verifyProof(ctx, packet.SrcChain, proofHeight, proof,
+		EgressKey(packet.DestChain, sequence), marshalBinaryPanic(ibcm.cdc, packet))
+}
+
+// VerifyAcknowledgement checks the proof that the destination chain of the
+// packet wrote ack at AcknowledgementKey(packet.SrcChain, sequence).
+func (ibcm Mapper) VerifyAcknowledgement(ctx sdk.Context, packet IBCPacket, sequence int64, ack IBCAcknowledgement, proofHeight int64, proof []byte) sdk.Error {
+	return ibcm.verifyProof(ctx, packet.DestChain, proofHeight, proof,
+		AcknowledgementKey(packet.SrcChain, sequence), marshalBinaryPanic(ibcm.cdc, ack))
+}
+
+// VerifyTimeout checks the proof that the destination chain of the packet had
+// not processed it when the packet timed out, i.e. that its ingress sequence
+// was ingressSequence, at most sequence, at a header past the timeout. All
+// later blocks of the destination chain reject the packet.
+func (ibcm Mapper) VerifyTimeout(ctx sdk.Context, packet IBCPacket, sequence int64, ingressSequence int64, proofHeight int64, proof []byte) sdk.Error {
+	if ingressSequence > sequence {
+		return ErrInvalidSequence(ibcm.codespace)
+	}
+
+	header, found := ibcm.GetClientHeader(ctx, packet.DestChain, proofHeight)
+	if !found {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("no verified header of chain %s at height %d", packet.DestChain, proofHeight))
+	}
+	if !packet.TimedOut(header.Height, header.Time) {
+		return ErrNotTimedOut(ibcm.codespace)
+	}
+
+	key := IngressSequenceKey(packet.SrcChain)
+	err := ibcm.verifyProof(ctx, packet.DestChain, proofHeight, proof, key, marshalBinaryPanic(ibcm.cdc, ingressSequence))
+	if err != nil && ingressSequence == 0 {
+		// the destination chain never received a packet from this chain
+		err = ibcm.verifyProof(ctx, packet.DestChain, proofHeight, proof, key, nil)
+	}
+	return err
+}
+
+// verifyProof checks the proof of value at key in the ibc store of the chain.
+// The proof is the multistore proof of a query of the ibc store, checked
+// against the app hash of the verified header at proofHeight, i.e. a query at
+// proofHeight-1. A nil value is proven absent.
+func (ibcm Mapper) verifyProof(ctx sdk.Context, chainID string, proofHeight int64, proof []byte, key []byte, value []byte) sdk.Error {
+	header, found := ibcm.GetClientHeader(ctx, chainID, proofHeight)
+	if !found {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("no verified header of chain %s at height %d", chainID, proofHeight))
+	}
+
+	var multiStoreProof store.MultiStoreProof
+	err := ibcm.cdc.UnmarshalBinary(proof, &multiStoreProof)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	if multiStoreProof.StoreName != ibcm.key.Name() {
+		return ErrInvalidProof(ibcm.codespace, fmt.Sprintf("proof of store %s", multiStoreProof.StoreName))
+	}
+
+	substoreCommitHash, err := store.VerifyMultiStoreCommitInfo(multiStoreProof.StoreName,
+		multiStoreProof.StoreInfos, header.AppHash)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+
+	err = store.VerifyRangeProof(key, value, substoreCommitHash, &multiStoreProof.RangeProof)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return nil
+}
+
+// GetClientValidators returns the trusted validator set of the chain, nil if
+// the chain has no light client
+func (ibcm Mapper) GetClientValidators(ctx sdk.Context, chainID string) *tmtypes.ValidatorSet {
+	bz := ctx.KVStore(ibcm.key).Get(ClientValidatorsKey(chainID))
+	if bz == nil {
+		return nil
+	}
+	validators := new(tmtypes.ValidatorSet)
+	unmarshalBinaryPanic(ibcm.cdc, bz, validators)
+	return validators
+}
+
+// GetClientLatestHeight returns the height of the latest verified header of
+// the chain
+func (ibcm Mapper) GetClientLatestHeight(ctx sdk.Context, chainID string) int64 {
+	bz := ctx.KVStore(ibcm.key).Get(ClientLatestHeightKey(chainID))
+	if bz == nil {
+		return 0
+	}
+	var height int64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &height)
+	return height
+}
+
+// GetClientHeader returns the verified header of the chain at height
+func (ibcm Mapper) GetClientHeader(ctx sdk.Context, chainID string, height int64) (header tmtypes.Header, found bool) {
+	bz := ctx.KVStore(ibcm.key).Get(ClientHeaderKey(chainID, height))
+	if bz == nil {
+		return header, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &header)
+	return header, true
+}
+
+func (ibcm Mapper) setClientHeader(ctx sdk.Context, chainID string, validators *tmtypes.ValidatorSet, header tmtypes.Header) {
+	kvStore := ctx.KVStore(ibcm.key)
+	kvStore.Set(ClientValidatorsKey(chainID), marshalBinaryPanic(ibcm.cdc, validators))
+	kvStore.Set(ClientHeaderKey(chainID, header.Height), marshalBinaryPanic(ibcm.cdc, header))
+	kvStore.Set(ClientLatestHeightKey(chainID), marshalBinaryPanic(ibcm.cdc, header.Height))
+}
+
+// TrustedClientKeyPrefix prefixes the keys of the trusted clients
+var TrustedClientKeyPrefix = []byte("trustedclient/")
+
+// Stores the validators hash a chain's light client is created from under "trustedclient/chain_id".
+func TrustedClientKey(chainID string) []byte {
+	return append(append([]byte{}, TrustedClientKeyPrefix...), chainID...)
+}
+
+// Stores the trusted validator set of a chain under "client/chain_id/validators".
+func ClientValidatorsKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("client/%s/validators", chainID))
+}
+
+// Stores the height of the latest verified header of a chain under "client/chain_id/latest".
+func ClientLatestHeightKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("client/%s/latest", chainID))
+}
+
+// Stores the verified headers of a chain under "client/chain_id/header/height".
+func ClientHeaderKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("client/%s/header/%d", chainID, height))
+}
@@ -0,0 +1,532 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// chain keeps the validator keys of a test source chain
+type chain struct {
+	chainID string
+	privs   []ed25519.PrivKeyEd25519
+}
+
+func newChain(chainID string, numValidators int) *chain {
+	c := &chain{chainID: chainID}
+	for i := 0; i < numValidators; i++ {
+		c.addValidator()
+	}
+	return c
+}
+
+func (c *chain) addValidator() {
+	c.privs = append(c.privs, ed25519.GenPrivKey())
+}
+
+func (c *chain) validatorSet() *tmtypes.ValidatorSet {
+	validators := make([]*tmtypes.Validator, len(c.privs))
+	for i, priv := range c.privs {
+		validators[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	return tmtypes.NewValidatorSet(validators)
+}
+
+// signedHeader returns the header at height with appHash, signed by signers,
+// which default to the current validators
+func (c *chain) signedHeader(t *testing.T, height int64, appHash []byte, signers ...ed25519.PrivKeyEd25519) (tmtypes.SignedHeader, *tmtypes.ValidatorSet) {
+	if len(signers) == 0 {
+		signers = c.privs
+	}
+	validators := c.validatorSet()
+	header := &tmtypes.Header{
+		ChainID:        c.chainID,
+		Height:         height,
+		Time:           blockTime(height),
+		ValidatorsHash: validators.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, validators.Size())
+	for _, priv := range signers {
+		idx, _ := validators.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: priv.PubKey().Address(),
+			ValidatorIndex:   idx,
+			Height:           height,
+			Timestamp:        header.Time,
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		sig, err := priv.Sign(vote.SignBytes(c.chainID))
+		require.Nil(t, err)
+		vote.Signature = sig
+		precommits[idx] = vote
+	}
+
+	commit := &tmtypes.Commit{BlockID: blockID, Precommits: precommits}
+	return tmtypes.SignedHeader{Header: header, Commit: commit}, validators
+}
+
+// postPacket posts the packet on a source chain store and returns the app
+// hash of the commit along with the proof of the packet
+func postPacket(t *testing.T, key sdk.StoreKey, ibcm Mapper, packet IBCPacket) (appHash []byte, proof []byte) {
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, cms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(cms, abci.Header{ChainID: packet.SrcChain}, false, log.NewNopLogger())
+	require.Nil(t, ibcm.PostIBCPacket(ctx, packet))
+	commitID := cms.Commit()
+
+	res := cms.Query(abci.RequestQuery{
+		Path:   "/ibc/key",
+		Data:   EgressKey(packet.DestChain, 0),
+		Height: commitID.Version,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	require.NotNil(t, res.Value)
+	return commitID.Hash, res.Proof
+}
+
+func TestLightClient(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	ctx := defaultContext(key)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+
+	src := newChain("source-chain", 3)
+
+	// the client can only be created from the trusted validator set
+	header, validators := src.signedHeader(t, 1, nil)
+	err := ibcm.CreateClient(ctx, header, validators)
+	require.Equal(t, CodeUntrustedClient, err.Code())
+	other := newChain(src.chainID, 3)
+	ibcm.TrustClient(ctx, TrustedClient{src.chainID, other.validatorSet().Hash()})
+	err = ibcm.CreateClient(ctx, header, validators)
+	require.Equal(t, CodeUntrustedClient, err.Code())
+	ibcm.TrustClient(ctx, TrustedClient{src.chainID, validators.Hash()})
+
+	// the initial header must be signed by its validators
+	header, validators = src.signedHeader(t, 1, nil, src.privs[0])
+	err = ibcm.UpdateClient(ctx, header, validators)
+	require.Equal(t, CodeNoClient, err.Code())
+	err = ibcm.CreateClient(ctx, header, validators)
+	require.Equal(t, CodeInvalidHeader, err.Code())
+
+	header, validators = src.signedHeader(t, 1, nil)
+	err = ibcm.CreateClient(ctx, header, validators)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), ibcm.GetClientLatestHeight(ctx, src.chainID))
+	err = ibcm.CreateClient(ctx, header, validators)
+	require.Equal(t, CodeClientExists, err.Code())
+
+	// headers must be above the latest verified height
+	err = ibcm.UpdateClient(ctx, header, validators)
+	require.Equal(t, CodeInvalidHeader, err.Code())
+
+	header, validators = src.signedHeader(t, 2, nil)
+	err = ibcm.UpdateClient(ctx, header, validators)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), ibcm.GetClientLatestHeight(ctx, src.chainID))
+
+	// a new validator set must be signed by the trusted validators
+	header, validators = other.signedHeader(t, 3, nil)
+	err = ibcm.UpdateClient(ctx, header, validators)
+	require.Equal(t, CodeInvalidHeader, err.Code())
+
+	src.addValidator()
+	header, validators = src.signedHeader(t, 3, nil)
+	err = ibcm.UpdateClient(ctx, header, validators)
+	require.Nil(t, err)
+	require.True(t, validators.HasAddress(src.privs[3].PubKey().Address()))
+	require.Equal(t, validators.Hash(), ibcm.GetClientValidators(ctx, src.chainID).Hash())
+
+	stored, found := ibcm.GetClientHeader(ctx, src.chainID, 3)
+	require.True(t, found)
+	require.Equal(t, header.Header.Hash(), stored.Hash())
+	_, found = ibcm.GetClientHeader(ctx, src.chainID, 4)
+	require.False(t, found)
+}
+
+func TestIBCReceiveWithProof(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	ctx := defaultContext(key).WithChainID("dest-chain")
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewKeeper(am)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	h := NewHandler(ibcm, NewRouter().AddRoute(TransferPort, NewTransferApp(ibcm, ck)))
+
+	src := newChain("source-chain", 3)
+	dest := newAddress()
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	packet := NewTransferPacket(newAddress(), dest, mycoins, src.chainID, "dest-chain", 100, time.Time{})
+	appHash, proof := postPacket(t, key, ibcm, packet)
+
+	msg := IBCReceiveMsg{
+		IBCPacket:   packet,
+		Relayer:     newAddress(),
+		Sequence:    0,
+		ProofHeight: 2,
+		Proof:       proof,
+	}
+
+	// no light client of the source chain
+	res := h(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	header, validators := src.signedHeader(t, 1, nil)
+	ibcm.TrustClient(ctx, TrustedClient{src.chainID, validators.Hash()})
+	require.Nil(t, ibcm.CreateClient(ctx, header, validators))
+
+	// no verified header at the proof height
+	res = h(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	header, validators = src.signedHeader(t, 2, appHash)
+	require.Nil(t, ibcm.UpdateClient(ctx, header, validators))
+
+	// the proof does not match a different packet
+	forged := msg
+	forged.IBCPacket.Payload.Data = append([]byte{}, packet.Payload.Data...)
+	forged.IBCPacket.Payload.Data[0]++
+	res = h(ctx, forged)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	// the packet is for another chain
+	res = h(ctx.WithChainID("other-chain"), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeWrongChain), res.Code)
+
+	res = h(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err := getCoins(ck, ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("source-chain/mycoin", 10)}, coins)
+	require.Equal(t, int64(1), ibcm.GetIngressSequence(ctx, src.chainID))
+
+	// the packet is not received twice
+	res = h(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidSequence), res.Code)
+}
+
+// testChain is a source or destination chain of packets, committing its
+// store and proving its state
+type testChain struct {
+	*chain
+	cms      store.CommitMultiStore
+	ibcm     Mapper
+	ck       bank.Keeper
+	router   Router
+	handler  sdk.Handler
+	transfer sdk.Handler
+	height   int64
+}
+
+func newTestChain(t *testing.T, chainID string) *testChain {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, cms.LoadLatestVersion())
+
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	ck := bank.NewKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	transferApp := NewTransferApp(ibcm, ck)
+	router := NewRouter().AddRoute(TransferPort, transferApp)
+	return &testChain{
+		chain:    newChain(chainID, 3),
+		cms:      cms,
+		ibcm:     ibcm,
+		ck:       ck,
+		router:   router,
+		handler:  NewHandler(ibcm, router),
+		transfer: NewTransferHandler(transferApp),
+	}
+}
+
+// ctx returns the context of the block being executed
+func (c *testChain) ctx() sdk.Context {
+	header := abci.Header{ChainID: c.chainID, Height: c.height + 1, Time: blockTime(c.height + 1)}
+	return sdk.NewContext(c.cms, header, false, log.NewNopLogger())
+}
+
+// commit commits the block being executed
+func (c *testChain) commit() {
+	c.height = c.cms.Commit().Version
+}
+
+// query returns the value at key and its proof against the app hash of the
+// header of the next block
+func (c *testChain) query(t *testing.T, key []byte) (value []byte, proof []byte, proofHeight int64) {
+	res := c.cms.(store.Queryable).Query(abci.RequestQuery{
+		Path:   "/ibc/key",
+		Data:   key,
+		Height: c.height,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	return res.Value, res.Proof, c.height + 1
+}
+
+// header returns the header of the next block, committing the current state
+func (c *testChain) header(t *testing.T) (tmtypes.SignedHeader, *tmtypes.ValidatorSet) {
+	return c.signedHeader(t, c.height+1, c.cms.LastCommitID().Hash)
+}
+
+// trust creates or updates the light client of other on c
+func (c *testChain) trust(t *testing.T, other *testChain) {
+	header, validators := other.header(t)
+	if c.ibcm.GetClientValidators(c.ctx(), other.chainID) == nil {
+		c.ibcm.TrustClient(c.ctx(), TrustedClient{other.chainID, validators.Hash()})
+		require.Nil(t, c.ibcm.CreateClient(c.ctx(), header, validators))
+	} else {
+		require.Nil(t, c.ibcm.UpdateClient(c.ctx(), header, validators))
+	}
+}
+
+func blockTime(height int64) time.Time {
+	return time.Unix(1000+height, 0).UTC()
+}
+
+// sendPacket transfers coins from a new funded account of src to dest, and
+// relays the packet to dest if relay is set
+func sendPacket(t *testing.T, src, dest *testChain, timeoutHeight int64, relay bool) (sender sdk.AccAddress, seq int64) {
+	sender = newAddress()
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	_, _, err := src.ck.AddCoins(src.ctx(), sender, mycoins)
+	require.Nil(t, err)
+
+	seq = src.ibcm.getEgressLength(src.ctx().KVStore(src.ibcm.key), dest.chainID)
+	msg := NewIBCTransferMsg(sender, newAddress(), mycoins, src.chainID, dest.chainID, timeoutHeight, time.Time{})
+	res := src.transfer(src.ctx(), msg)
+	require.True(t, res.IsOK(), res.Log)
+	src.commit()
+
+	if !relay {
+		return sender, seq
+	}
+
+	relayPacket(t, src, dest, msg.Packet(), seq)
+	return sender, seq
+}
+
+// relayPacket proves the packet with seq of src on dest and receives it
+func relayPacket(t *testing.T, src, dest *testChain, packet IBCPacket, seq int64) {
+	dest.trust(t, src)
+	_, proof, proofHeight := src.query(t, EgressKey(dest.chainID, seq))
+	res := dest.handler(dest.ctx(), IBCReceiveMsg{
+		IBCPacket:   packet,
+		Relayer:     newAddress(),
+		Sequence:    seq,
+		ProofHeight: proofHeight,
+		Proof:       proof,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	dest.commit()
+}
+
+// ackMsg returns the msg relaying the acknowledgement of the packet with seq
+// from dest to src
+func ackMsg(t *testing.T, src, dest *testChain, seq int64) IBCAcknowledgementMsg {
+	src.trust(t, dest)
+	_, proof, proofHeight := dest.query(t, AcknowledgementKey(src.chainID, seq))
+	ack, found := dest.ibcm.GetAcknowledgement(dest.ctx(), src.chainID, seq)
+	require.True(t, found)
+	return IBCAcknowledgementMsg{
+		DestChain:       dest.chainID,
+		Sequence:        seq,
+		Acknowledgement: ack,
+		Relayer:         newAddress(),
+		ProofHeight:     proofHeight,
+		Proof:           proof,
+	}
+}
+
+func TestIBCAcknowledgement(t *testing.T) {
+	src := newTestChain(t, "source-chain")
+	dest := newTestChain(t, "dest-chain")
+
+	// the packet is received
+	sender, seq := sendPacket(t, src, dest, 100, true)
+	ack, _ := dest.ibcm.GetAcknowledgement(dest.ctx(), src.chainID, seq)
+	require.Equal(t, IBCAcknowledgement{Success: true}, ack)
+
+	msg := ackMsg(t, src, dest, seq)
+	forged := msg
+	forged.Acknowledgement = IBCAcknowledgement{Error: "forged"}
+	res := src.handler(src.ctx(), forged)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	res = src.handler(src.ctx(), msg)
+	require.True(t, res.IsOK(), res.Log)
+	coins, _ := getCoins(src.ck, src.ctx(), sender)
+	require.True(t, coins.IsZero())
+	_, found := src.ibcm.GetPendingPacket(src.ctx(), dest.chainID, seq)
+	require.False(t, found)
+
+	res = src.handler(src.ctx(), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoPendingPacket), res.Code)
+	src.commit()
+
+	// the packet times out on the destination chain, which acknowledges an error
+	sender, seq = sendPacket(t, src, dest, 1, true)
+	ack, _ = dest.ibcm.GetAcknowledgement(dest.ctx(), src.chainID, seq)
+	require.False(t, ack.Success)
+
+	res = src.handler(src.ctx(), ackMsg(t, src, dest, seq))
+	require.True(t, res.IsOK(), res.Log)
+	coins, _ = getCoins(src.ck, src.ctx(), sender)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}, coins)
+}
+
+func TestIBCTimeout(t *testing.T) {
+	src := newTestChain(t, "source-chain")
+	dest := newTestChain(t, "dest-chain")
+
+	// the destination chain has some state, but never received a packet
+	_, _, err := dest.ck.AddCoins(dest.ctx(), newAddress(), sdk.Coins{sdk.NewInt64Coin("mycoin", 1)})
+	require.Nil(t, err)
+	dest.commit()
+
+	sender, seq := sendPacket(t, src, dest, 4, false)
+
+	timeoutMsg := func() IBCTimeoutMsg {
+		src.trust(t, dest)
+		_, proof, proofHeight := dest.query(t, IngressSequenceKey(src.chainID))
+		return IBCTimeoutMsg{
+			DestChain:       dest.chainID,
+			Sequence:        seq,
+			IngressSequence: 0,
+			Relayer:         newAddress(),
+			ProofHeight:     proofHeight,
+			Proof:           proof,
+		}
+	}
+
+	// proof height 2 is before the timeout height
+	res := src.handler(src.ctx(), timeoutMsg())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotTimedOut), res.Code)
+
+	dest.commit()
+	dest.commit()
+	msg := timeoutMsg()
+	require.Equal(t, int64(4), msg.ProofHeight)
+
+	forged := msg
+	forged.Sequence = seq + 1
+	res = src.handler(src.ctx(), forged)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoPendingPacket), res.Code)
+
+	res = src.handler(src.ctx(), msg)
+	require.True(t, res.IsOK(), res.Log)
+	coins, _ := getCoins(src.ck, src.ctx(), sender)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}, coins)
+
+	// the packet is refunded once
+	res = src.handler(src.ctx(), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoPendingPacket), res.Code)
+}
+
+func TestIBCTimeoutAfterReceive(t *testing.T) {
+	src := newTestChain(t, "source-chain")
+	dest := newTestChain(t, "dest-chain")
+
+	// the packet is received before the timeout
+	_, seq := sendPacket(t, src, dest, 10, true)
+	for dest.height < 10 {
+		dest.commit()
+	}
+
+	src.trust(t, dest)
+	value, proof, proofHeight := dest.query(t, IngressSequenceKey(src.chainID))
+	require.NotNil(t, value)
+
+	for _, ingressSequence := range []int64{0, 1} {
+		res := src.handler(src.ctx(), IBCTimeoutMsg{
+			DestChain:       dest.chainID,
+			Sequence:        seq,
+			IngressSequence: ingressSequence,
+			Relayer:         newAddress(),
+			ProofHeight:     proofHeight,
+			Proof:           proof,
+		})
+		require.False(t, res.IsOK())
+	}
+}
+
+func TestIBCDenomTrace(t *testing.T) {
+	src := newTestChain(t, "source-chain")
+	dest := newTestChain(t, "dest-chain")
+	mycoins := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("mycoin", amount)} }
+	vouchers := func(amount int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin("source-chain/mycoin", amount)} }
+
+	// the coins are escrowed on the source chain and minted as vouchers
+	sender, _ := sendPacket(t, src, dest, 100, true)
+	packet, _ := src.ibcm.GetPendingPacket(src.ctx(), dest.chainID, 0)
+	payload, err := GetTransferPayload(packet)
+	require.Nil(t, err)
+	receiver := payload.DestAddr
+	coins, _ := getCoins(dest.ck, dest.ctx(), receiver)
+	require.Equal(t, vouchers(10), coins)
+	require.Equal(t, mycoins(10), src.ibcm.GetEscrow(src.ctx(), dest.chainID))
+
+	trace, found := dest.ibcm.GetDenomTrace(dest.ctx(), "source-chain/mycoin")
+	require.True(t, found)
+	require.Equal(t, DenomTrace{Path: []string{"source-chain"}, BaseDenom: "mycoin"}, trace)
+	_, found = dest.ibcm.GetDenomTrace(dest.ctx(), "mycoin")
+	require.False(t, found)
+
+	querier := NewQuerier(dest.ibcm)
+	bz, err := querier(dest.ctx(), []string{QueryDenomTrace}, abci.RequestQuery{
+		Data: []byte(`{"Denom":"source-chain/mycoin"}`),
+	})
+	require.Nil(t, err)
+	var queried DenomTrace
+	require.Nil(t, dest.ibcm.cdc.UnmarshalJSON(bz, &queried))
+	require.Equal(t, trace, queried)
+
+	// the vouchers sent back are burned and the coins released from the escrow
+	msg := NewIBCTransferMsg(receiver, sender, vouchers(4), dest.chainID, src.chainID, 100, time.Time{})
+	res := dest.transfer(dest.ctx(), msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, dest.ibcm.GetEscrow(dest.ctx(), src.chainID).IsZero())
+	dest.commit()
+
+	relayPacket(t, dest, src, msg.Packet(), 0)
+	coins, _ = getCoins(src.ck, src.ctx(), sender)
+	require.Equal(t, mycoins(4), coins)
+	require.Equal(t, mycoins(6), src.ibcm.GetEscrow(src.ctx(), dest.chainID))
+
+	// more coins than escrowed cannot come back
+	_, _, err = dest.ck.AddCoins(dest.ctx(), receiver, vouchers(10))
+	require.Nil(t, err)
+	msg = NewIBCTransferMsg(receiver, sender, vouchers(10), dest.chainID, src.chainID, 100, time.Time{})
+	res = dest.transfer(dest.ctx(), msg)
+	require.True(t, res.IsOK(), res.Log)
+	dest.commit()
+
+	relayPacket(t, dest, src, msg.Packet(), 1)
+	ack, _ := src.ibcm.GetAcknowledgement(src.ctx(), dest.chainID, 1)
+	require.False(t, ack.Success)
+	coins, _ = getCoins(src.ck, src.ctx(), sender)
+	require.Equal(t, mycoins(4), coins)
+	require.Equal(t, mycoins(6), src.ibcm.GetEscrow(src.ctx(), dest.chainID))
+}
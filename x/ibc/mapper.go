@@ -25,11 +25,14 @@ func NewMapper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) M
 	}
 }
 
-// XXX: This is not the public API. This will change in MVP2 and will henceforth
-// only be invoked from another module directly and not through a user
-// transaction.
-// TODO: Handle invalid IBC packets and return errors.
+// PostIBCPacket queues a packet to its destination chain. It is invoked by the
+// application bound to the source port of the packet, which is called back
+// with the acknowledgement or the timeout of the packet.
 func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if err := packet.ValidateBasic(); err != nil {
+		return err
+	}
+
 	// write everything into the state
 	store := ctx.KVStore(ibcm.key)
 	index := ibcm.getEgressLength(store, packet.DestChain)
@@ -39,6 +42,7 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	}
 
 	store.Set(EgressKey(packet.DestChain, index), bz)
+	store.Set(PendingPacketKey(packet.DestChain, index), marshalBinaryPanic(ibcm.cdc, index))
 	bz, err = ibcm.cdc.MarshalBinary(index + 1)
 	if err != nil {
 		panic(err)
@@ -48,15 +52,89 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	return nil
 }
 
-// XXX: In the future every module is able to register it's own handler for
-// handling it's own IBC packets. The "ibc" handler will only route the packets
-// to the appropriate callbacks.
-// XXX: For now this handles all interactions with the CoinKeeper.
-// XXX: This needs to do some authentication checking.
-func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+// GetPendingPacket returns the outgoing IBC packet with sequence to destChain
+// if it is neither acknowledged nor timed out yet.
+func (ibcm Mapper) GetPendingPacket(ctx sdk.Context, destChain string, sequence int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	if !store.Has(PendingPacketKey(destChain, sequence)) {
+		return packet, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, store.Get(EgressKey(destChain, sequence)), &packet)
+	return packet, true
+}
+
+// ResolvePacket removes the outgoing IBC packet with sequence to destChain
+// from the pending packets, once acknowledged or timed out.
+func (ibcm Mapper) ResolvePacket(ctx sdk.Context, destChain string, sequence int64) {
+	ctx.KVStore(ibcm.key).Delete(PendingPacketKey(destChain, sequence))
+}
+
+// GetAcknowledgement returns the acknowledgement written for the incoming IBC
+// packet with sequence from srcChain.
+func (ibcm Mapper) GetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64) (ack IBCAcknowledgement, found bool) {
+	bz := ctx.KVStore(ibcm.key).Get(AcknowledgementKey(srcChain, sequence))
+	if bz == nil {
+		return ack, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &ack)
+	return ack, true
+}
+
+// SetAcknowledgement writes the acknowledgement of the incoming IBC packet
+// with sequence from srcChain, to be proven on srcChain.
+func (ibcm Mapper) SetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64, ack IBCAcknowledgement) {
+	ctx.KVStore(ibcm.key).Set(AcknowledgementKey(srcChain, sequence), marshalBinaryPanic(ibcm.cdc, ack))
+}
+
+// GetEscrow returns the coins sent to destChain which did not come back yet.
+func (ibcm Mapper) GetEscrow(ctx sdk.Context, destChain string) (coins sdk.Coins) {
+	bz := ctx.KVStore(ibcm.key).Get(EscrowKey(destChain))
+	if bz == nil {
+		return nil
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &coins)
+	return coins
+}
+
+func (ibcm Mapper) setEscrow(ctx sdk.Context, destChain string, coins sdk.Coins) {
+	store := ctx.KVStore(ibcm.key)
+	if coins.IsZero() {
+		store.Delete(EscrowKey(destChain))
+		return
+	}
+	store.Set(EscrowKey(destChain), marshalBinaryPanic(ibcm.cdc, coins))
+}
+
+// escrow adds coins sent to destChain to its escrow.
+func (ibcm Mapper) escrow(ctx sdk.Context, destChain string, coins sdk.Coins) {
+	ibcm.setEscrow(ctx, destChain, ibcm.GetEscrow(ctx, destChain).Plus(coins))
+}
+
+// release removes coins coming back from destChain from its escrow, failing
+// if more coins come back than were sent.
+func (ibcm Mapper) release(ctx sdk.Context, destChain string, coins sdk.Coins) sdk.Error {
+	escrow := ibcm.GetEscrow(ctx, destChain).Minus(coins)
+	if !escrow.IsNotNegative() {
+		return ErrInvalidEscrow(ibcm.codespace, destChain)
+	}
+	ibcm.setEscrow(ctx, destChain, escrow)
 	return nil
 }
 
+// GetDenomTrace returns the trace of a voucher denom minted on this chain.
+func (ibcm Mapper) GetDenomTrace(ctx sdk.Context, denom string) (trace DenomTrace, found bool) {
+	bz := ctx.KVStore(ibcm.key).Get(DenomTraceKey(denom))
+	if bz == nil {
+		return trace, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &trace)
+	return trace, true
+}
+
+func (ibcm Mapper) setDenomTrace(ctx sdk.Context, trace DenomTrace) {
+	ctx.KVStore(ibcm.key).Set(DenomTraceKey(trace.Denom()), marshalBinaryPanic(ibcm.cdc, trace))
+}
+
 // --------------------------
 // Functions for accessing the underlying KVStore.
 
@@ -128,3 +206,29 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Stores the sequence of the outgoing IBC packets which are neither
+// acknowledged nor timed out under "pending/chain_id/index".
+func PendingPacketKey(destChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("pending/%s/%d", destChain, index))
+}
+
+// Prefix of the pending outgoing IBC packets to a chain.
+func PendingPacketsKey(destChain string) []byte {
+	return []byte(fmt.Sprintf("pending/%s/", destChain))
+}
+
+// Stores the acknowledgement of an incoming IBC packet under "ack/chain_id/index".
+func AcknowledgementKey(srcChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("ack/%s/%d", srcChain, index))
+}
+
+// Stores the coins escrowed for a destination chain under "escrow/chain_id".
+func EscrowKey(destChain string) []byte {
+	return []byte(fmt.Sprintf("escrow/%s", destChain))
+}
+
+// Stores the trace of a voucher denom under "denom/denom".
+func DenomTraceKey(denom string) []byte {
+	return []byte(fmt.Sprintf("denom/%s", denom))
+}
@@ -0,0 +1,37 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// ParamStoreKeyTrustedClient is the param store key through which governance
+// fixes the validator set a light client can be created from
+const ParamStoreKeyTrustedClient = "ibc/trustedclient"
+
+// RegisterParamTypes registers the trusted clients with the param store, so
+// they can be set through governance. They are stored by the mapper.
+func RegisterParamTypes(pk params.Keeper, ibcm Mapper) {
+	pk.RegisterExternalType(ParamStoreKeyTrustedClient, TrustedClient{},
+		func(ctx sdk.Context, value interface{}) error {
+			trusted := value.(TrustedClient)
+			switch {
+			case trusted.ChainID == "":
+				return errors.New("missing chain ID")
+			case trusted.ChainID == ctx.ChainID():
+				return errors.New("cannot trust a client of this chain")
+			case len(trusted.ValidatorsHash) == 0:
+				return errors.New("missing validators hash")
+			case ibcm.GetClientValidators(ctx, trusted.ChainID) != nil:
+				return fmt.Errorf("light client of chain %s already exists", trusted.ChainID)
+			}
+			return nil
+		},
+		func(ctx sdk.Context, value interface{}) {
+			ibcm.TrustClient(ctx, value.(TrustedClient))
+		},
+	)
+}
@@ -0,0 +1,49 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the ibc Querier
+const (
+	QueryDenomTrace = "denom-trace"
+)
+
+func NewQuerier(ibcm Mapper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryDenomTrace:
+			return queryDenomTrace(ctx, path[1:], req, ibcm)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/ibc/denom-trace'
+type QueryDenomTraceParams struct {
+	Denom string
+}
+
+func queryDenomTrace(ctx sdk.Context, path []string, req abci.RequestQuery, ibcm Mapper) (res []byte, err sdk.Error) {
+	var params QueryDenomTraceParams
+	err2 := ibcm.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	trace, found := ibcm.GetDenomTrace(ctx, params.Denom)
+	if !found {
+		return []byte{}, ErrUnknownDenom(ibcm.codespace, params.Denom)
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(ibcm.cdc, trace)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
@@ -0,0 +1,74 @@
+package ibc
+
+import (
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PacketHandler is the application bound to an IBC port. It processes the
+// packets sent to the port, and is called back with the outcome of the
+// packets the port sent.
+type PacketHandler interface {
+	// OnReceive processes a packet sent to the port, returning the
+	// acknowledgement written for the source chain. The state changes are
+	// discarded unless the acknowledgement is successful.
+	OnReceive(ctx sdk.Context, packet IBCPacket) IBCAcknowledgement
+
+	// OnAcknowledgement is called with the acknowledgement of a packet sent
+	// from the port, once proven.
+	OnAcknowledgement(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error
+
+	// OnTimeout is called once it is proven that a packet sent from the port
+	// timed out before the destination chain processed it.
+	OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error
+}
+
+// Router routes IBC packets to the application bound to their port.
+type Router interface {
+	AddRoute(port string, h PacketHandler) (rtr Router)
+	Route(port string) (h PacketHandler)
+}
+
+// bind a port to an application
+type route struct {
+	port string
+	h    PacketHandler
+}
+
+type router struct {
+	routes []route
+}
+
+// nolint
+// NewRouter - create new router
+func NewRouter() Router {
+	return &router{
+		routes: make([]route, 0),
+	}
+}
+
+var isAlphaNumeric = regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString
+
+// AddRoute binds the port to an application, a port is bound at most once.
+func (rtr *router) AddRoute(port string, h PacketHandler) Router {
+	if !isAlphaNumeric(port) {
+		panic("ports can only contain alphanumeric characters")
+	}
+	if rtr.Route(port) != nil {
+		panic("port " + port + " is already bound")
+	}
+	rtr.routes = append(rtr.routes, route{port, h})
+
+	return rtr
+}
+
+// Route returns the application bound to the port, nil if none.
+func (rtr *router) Route(port string) (h PacketHandler) {
+	for _, route := range rtr.routes {
+		if route.port == port {
+			return route.h
+		}
+	}
+	return nil
+}
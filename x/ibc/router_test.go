@@ -0,0 +1,85 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// oracleApp is an IBC application storing the prices it receives, failing on
+// negative ones, and recording the acknowledgements of the prices it sent
+type oracleApp struct {
+	key  sdk.StoreKey
+	acks map[string]IBCAcknowledgement
+}
+
+var _ PacketHandler = oracleApp{}
+
+func (app oracleApp) OnReceive(ctx sdk.Context, packet IBCPacket) IBCAcknowledgement {
+	ctx.KVStore(app.key).Set([]byte("price"), packet.Payload.Data)
+	if packet.Payload.Data[0] == '-' {
+		return IBCAcknowledgement{Error: "negative price"}
+	}
+	return IBCAcknowledgement{Success: true}
+}
+
+func (app oracleApp) OnAcknowledgement(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error {
+	app.acks[string(packet.Payload.Data)] = ack
+	return nil
+}
+
+func (app oracleApp) OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	return nil
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter().AddRoute(TransferPort, oracleApp{})
+	require.NotNil(t, router.Route(TransferPort))
+	require.Nil(t, router.Route("oracle"))
+
+	require.Panics(t, func() { router.AddRoute(TransferPort, oracleApp{}) })
+	require.Panics(t, func() { router.AddRoute("ora/cle", oracleApp{}) })
+}
+
+func TestIBCPacketRouting(t *testing.T) {
+	src := newTestChain(t, "source-chain")
+	dest := newTestChain(t, "dest-chain")
+
+	srcApp := oracleApp{key: src.ibcm.key, acks: make(map[string]IBCAcknowledgement)}
+	src.router.AddRoute("oracle", srcApp)
+	dest.router.AddRoute("oracle", oracleApp{key: dest.ibcm.key})
+
+	send := func(seq int64, destPort string, price string) {
+		payload := Payload{Type: "price", Data: []byte(price)}
+		packet := NewIBCPacket("oracle", destPort, src.chainID, dest.chainID, payload, 100, time.Time{})
+		require.Nil(t, src.ibcm.PostIBCPacket(src.ctx(), packet))
+		src.commit()
+		relayPacket(t, src, dest, packet, seq)
+
+		res := src.handler(src.ctx(), ackMsg(t, src, dest, seq))
+		require.True(t, res.IsOK(), res.Log)
+		src.commit()
+	}
+	price := func() string {
+		return string(dest.ctx().KVStore(dest.ibcm.key).Get([]byte("price")))
+	}
+
+	// the packet is routed to the application bound to its destination port
+	send(0, "oracle", "42")
+	require.Equal(t, "42", price())
+	require.Equal(t, IBCAcknowledgement{Success: true}, srcApp.acks["42"])
+
+	// the state changes of an unsuccessful packet are discarded
+	send(1, "oracle", "-1")
+	require.Equal(t, "42", price())
+	require.Equal(t, IBCAcknowledgement{Error: "negative price"}, srcApp.acks["-1"])
+
+	// a packet to an unbound port is acknowledged with an error
+	send(2, "gov", "43")
+	require.Equal(t, "42", price())
+	require.False(t, srcApp.acks["43"].Success)
+	require.Equal(t, int64(3), dest.ibcm.GetIngressSequence(dest.ctx(), src.chainID))
+}
@@ -0,0 +1,171 @@
+package ibc
+
+import (
+	"reflect"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	// TransferPort is the port the coin transfer application is bound to
+	TransferPort = "transfer"
+
+	// TransferPayloadType is the type of the payload of coin transfer packets
+	TransferPayloadType = "transfer"
+)
+
+// TransferPayload is the payload of the packets of the coin transfer
+// application.
+type TransferPayload struct {
+	SrcAddr  sdk.AccAddress `json:"src_addr"`
+	DestAddr sdk.AccAddress `json:"dest_addr"`
+	Coins    sdk.Coins      `json:"coins"`
+}
+
+// validate the coin transfer payload
+func (p TransferPayload) ValidateBasic() sdk.Error {
+	if len(p.SrcAddr) == 0 || len(p.DestAddr) == 0 {
+		return sdk.ErrInvalidAddress("")
+	}
+	if !p.Coins.IsValid() {
+		return sdk.ErrInvalidCoins("")
+	}
+	return nil
+}
+
+// NewTransferPacket returns the packet of the coin transfer application
+// sending coins from srcAddr on srcChain to destAddr on destChain.
+func NewTransferPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
+	srcChain string, destChain string, timeoutHeight int64, timeoutTime time.Time) IBCPacket {
+
+	payload := Payload{
+		Type: TransferPayloadType,
+		Data: marshalBinaryPanic(msgCdc, TransferPayload{
+			SrcAddr:  srcAddr,
+			DestAddr: destAddr,
+			Coins:    coins,
+		}),
+	}
+	return NewIBCPacket(TransferPort, TransferPort, srcChain, destChain, payload, timeoutHeight, timeoutTime)
+}
+
+// GetTransferPayload decodes the payload of a coin transfer packet.
+func GetTransferPayload(packet IBCPacket) (payload TransferPayload, err sdk.Error) {
+	if packet.Payload.Type != TransferPayloadType {
+		return payload, ErrInvalidPayload(DefaultCodespace, "unknown payload type "+packet.Payload.Type)
+	}
+	if err := msgCdc.UnmarshalBinary(packet.Payload.Data, &payload); err != nil {
+		return payload, ErrInvalidPayload(DefaultCodespace, err.Error())
+	}
+	return payload, payload.ValidateBasic()
+}
+
+// TransferApp is the IBC application transferring coins between chains.
+// Coins are escrowed on the chain they are sent from and minted as vouchers
+// prefixed with the source chain on the destination chain. Vouchers sent back
+// to the chain they came from are burned and the escrowed coins released.
+type TransferApp struct {
+	ibcm Mapper
+	ck   bank.Keeper
+}
+
+var _ PacketHandler = TransferApp{}
+
+func NewTransferApp(ibcm Mapper, ck bank.Keeper) TransferApp {
+	return TransferApp{
+		ibcm: ibcm,
+		ck:   ck,
+	}
+}
+
+// NewTransferHandler returns the handler of the msgs of the coin transfer
+// application.
+func NewTransferHandler(app TransferApp) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case IBCTransferMsg:
+			return handleIBCTransferMsg(ctx, app, msg)
+		default:
+			errMsg := "Unrecognized IBC transfer Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
+// Vouchers sent back to the chain they came from are burned, other coins are
+// escrowed until they come back.
+func handleIBCTransferMsg(ctx sdk.Context, app TransferApp, msg IBCTransferMsg) sdk.Result {
+	_, _, err := app.ck.SubtractCoins(ctx, msg.SrcAddr, msg.Coins)
+	if err != nil {
+		return err.Result()
+	}
+	app.ibcm.escrow(ctx, msg.DestChain, escrowedCoins(msg.Coins, msg.DestChain))
+
+	err = app.ibcm.PostIBCPacket(ctx, msg.Packet())
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// OnReceive adds the coins to the destination address. Coins coming back are
+// released from the escrow, other coins are minted as vouchers prefixed with
+// the source chain, recording their trace.
+func (app TransferApp) OnReceive(ctx sdk.Context, packet IBCPacket) IBCAcknowledgement {
+	payload, err := GetTransferPayload(packet)
+	if err != nil {
+		return IBCAcknowledgement{Error: err.ABCILog()}
+	}
+
+	released, vouchers := receivedCoins(payload.Coins, packet.SrcChain, packet.DestChain)
+	err = app.ibcm.release(ctx, packet.SrcChain, released)
+	if err != nil {
+		return IBCAcknowledgement{Error: err.ABCILog()}
+	}
+
+	_, _, err = app.ck.AddCoins(ctx, payload.DestAddr, released.Plus(vouchers))
+	if err != nil {
+		return IBCAcknowledgement{Error: err.ABCILog()}
+	}
+
+	for _, voucher := range vouchers {
+		app.ibcm.setDenomTrace(ctx, ParseDenomTrace(voucher.Denom))
+	}
+	return IBCAcknowledgement{Success: true}
+}
+
+// OnAcknowledgement refunds the sender if the destination chain could not
+// process the packet.
+func (app TransferApp) OnAcknowledgement(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error {
+	if ack.Success {
+		return nil
+	}
+	return app.refund(ctx, packet)
+}
+
+// OnTimeout refunds the sender.
+func (app TransferApp) OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	return app.refund(ctx, packet)
+}
+
+// refund returns the coins of a packet the destination chain did not process
+// to the sender, releasing the escrowed ones and minting back the burned
+// vouchers.
+func (app TransferApp) refund(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	payload, err := GetTransferPayload(packet)
+	if err != nil {
+		return err
+	}
+
+	err = app.ibcm.release(ctx, packet.DestChain, escrowedCoins(payload.Coins, packet.DestChain))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = app.ck.AddCoins(ctx, payload.SrcAddr, payload.Coins)
+	return err
+}
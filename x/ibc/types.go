@@ -2,9 +2,11 @@ package ibc
 
 import (
 	"encoding/json"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 var (
@@ -13,34 +15,59 @@ var (
 
 func init() {
 	msgCdc = wire.NewCodec()
+	wire.RegisterCrypto(msgCdc)
 }
 
 // ------------------------------
 // IBCPacket
 
+// Payload is the opaque data of an IBC packet. The application bound to the
+// destination port of the packet decodes Data according to Type.
+type Payload struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains. It is sent by the application bound to SrcPort on the source
+// chain and routed to the application bound to DestPort on the destination
+// chain. The destination chain does not process the packet from
+// TimeoutHeight or TimeoutTime on, whichever is set, and the source chain
+// application is called back once the timeout is proven.
 type IBCPacket struct {
-	SrcAddr   sdk.AccAddress `json:"src_addr"`
-	DestAddr  sdk.AccAddress `json:"dest_addr"`
-	Coins     sdk.Coins      `json:"coins"`
-	SrcChain  string         `json:"src_chain"`
-	DestChain string         `json:"dest_chain"`
+	SrcPort       string    `json:"src_port"`
+	DestPort      string    `json:"dest_port"`
+	SrcChain      string    `json:"src_chain"`
+	DestChain     string    `json:"dest_chain"`
+	Payload       Payload   `json:"payload"`
+	TimeoutHeight int64     `json:"timeout_height"`
+	TimeoutTime   time.Time `json:"timeout_time"`
 }
 
-func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+func NewIBCPacket(srcPort string, destPort string, srcChain string, destChain string,
+	payload Payload, timeoutHeight int64, timeoutTime time.Time) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		SrcPort:       srcPort,
+		DestPort:      destPort,
+		SrcChain:      srcChain,
+		DestChain:     destChain,
+		Payload:       payload,
+		TimeoutHeight: timeoutHeight,
+		TimeoutTime:   timeoutTime,
 	}
 }
 
+// TimedOut returns whether the packet has timed out on the destination chain
+// at the given block height and time
+func (p IBCPacket) TimedOut(height int64, blockTime time.Time) bool {
+	if p.TimeoutHeight != 0 && height >= p.TimeoutHeight {
+		return true
+	}
+	return !p.TimeoutTime.IsZero() && !blockTime.Before(p.TimeoutTime)
+}
+
 //nolint
 func (p IBCPacket) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(p)
@@ -55,8 +82,17 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if p.SrcChain == p.DestChain {
 		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
 	}
-	if !p.Coins.IsValid() {
-		return sdk.ErrInvalidCoins("")
+	if !isAlphaNumeric(p.SrcPort) || !isAlphaNumeric(p.DestPort) {
+		return ErrInvalidPort(DefaultCodespace, "ports can only contain alphanumeric characters")
+	}
+	if len(p.Payload.Type) == 0 {
+		return ErrInvalidPayload(DefaultCodespace, "missing payload type")
+	}
+	if p.TimeoutHeight < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "negative timeout height")
+	}
+	if p.TimeoutHeight == 0 && p.TimeoutTime.IsZero() {
+		return ErrInvalidTimeout(DefaultCodespace, "packet must have a timeout height or time")
 	}
 	return nil
 }
@@ -65,25 +101,60 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 // IBCTransferMsg
 
 // nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
-// IBCTransferMsg defines how another module can send an IBCPacket.
+// IBCTransferMsg sends coins to another chain through the coin transfer
+// application.
 type IBCTransferMsg struct {
-	IBCPacket
+	TransferPayload
+	SrcChain      string    `json:"src_chain"`
+	DestChain     string    `json:"dest_chain"`
+	TimeoutHeight int64     `json:"timeout_height"`
+	TimeoutTime   time.Time `json:"timeout_time"`
+}
+
+func NewIBCTransferMsg(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
+	srcChain string, destChain string, timeoutHeight int64, timeoutTime time.Time) IBCTransferMsg {
+
+	return IBCTransferMsg{
+		TransferPayload: TransferPayload{
+			SrcAddr:  srcAddr,
+			DestAddr: destAddr,
+			Coins:    coins,
+		},
+		SrcChain:      srcChain,
+		DestChain:     destChain,
+		TimeoutHeight: timeoutHeight,
+		TimeoutTime:   timeoutTime,
+	}
+}
+
+// Packet returns the packet of the coin transfer application sent for the msg
+func (msg IBCTransferMsg) Packet() IBCPacket {
+	return NewTransferPacket(msg.SrcAddr, msg.DestAddr, msg.Coins, msg.SrcChain, msg.DestChain,
+		msg.TimeoutHeight, msg.TimeoutTime)
 }
 
 // nolint
-func (msg IBCTransferMsg) Type() string { return "ibc" }
+func (msg IBCTransferMsg) Type() string { return "ibctransfer" }
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCTransferMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.SrcAddr} }
 
 // get the sign bytes for ibc transfer message
 func (msg IBCTransferMsg) GetSignBytes() []byte {
-	return msg.IBCPacket.GetSignBytes()
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
 }
 
 // validate ibc transfer message
 func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
-	return msg.IBCPacket.ValidateBasic()
+	err := msg.TransferPayload.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	return msg.Packet().ValidateBasic()
 }
 
 // ----------------------------------
@@ -91,16 +162,27 @@ func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. Proof is the proof of the source chain ibc store
+// that the packet was posted with Sequence, against the app hash of the
+// header at ProofHeight verified by the light client of the source chain.
 type IBCReceiveMsg struct {
 	IBCPacket
-	Relayer  sdk.AccAddress
-	Sequence int64
+	Relayer     sdk.AccAddress
+	Sequence    int64
+	ProofHeight int64
+	Proof       []byte
 }
 
 // nolint
-func (msg IBCReceiveMsg) Type() string             { return "ibc" }
-func (msg IBCReceiveMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) Type() string { return "ibc" }
+
+// validate ibc receive message
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error {
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
@@ -108,16 +190,185 @@ func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress
 // get the sign bytes for ibc receive message
 func (msg IBCReceiveMsg) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(struct {
-		IBCPacket json.RawMessage
-		Relayer   sdk.AccAddress
-		Sequence  int64
+		IBCPacket   json.RawMessage
+		Relayer     sdk.AccAddress
+		Sequence    int64
+		ProofHeight int64
+		Proof       []byte
 	}{
-		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
-		Relayer:   msg.Relayer,
-		Sequence:  msg.Sequence,
+		IBCPacket:   json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:     msg.Relayer,
+		Sequence:    msg.Sequence,
+		ProofHeight: msg.ProofHeight,
+		Proof:       msg.Proof,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return sdk.MustSortJSON(b)
 }
+
+// ----------------------------------
+// IBCAcknowledgement
+
+// IBCAcknowledgement is written by the destination chain once it processed a
+// packet. The application bound to the source port of the packet is called
+// back with it on the source chain.
+type IBCAcknowledgement struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// ----------------------------------
+// IBCAcknowledgementMsg
+
+// IBCAcknowledgementMsg defines the message that a relayer uses to post the
+// acknowledgement of the packet with Sequence back to the source chain. Proof
+// is the proof of the destination chain ibc store that it wrote the
+// acknowledgement, against the app hash of the header at ProofHeight verified
+// by the light client of the destination chain.
+type IBCAcknowledgementMsg struct {
+	DestChain       string             `json:"dest_chain"`
+	Sequence        int64              `json:"sequence"`
+	Acknowledgement IBCAcknowledgement `json:"acknowledgement"`
+	Relayer         sdk.AccAddress     `json:"relayer"`
+	ProofHeight     int64              `json:"proof_height"`
+	Proof           []byte             `json:"proof"`
+}
+
+// nolint
+func (msg IBCAcknowledgementMsg) Type() string                 { return "ibc" }
+func (msg IBCAcknowledgementMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) ValidateBasic() sdk.Error {
+	if len(msg.DestChain) == 0 || msg.Sequence < 0 {
+		return ErrInvalidSequence(DefaultCodespace)
+	}
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCTimeoutMsg
+
+// IBCTimeoutMsg defines the message that a relayer uses to prove that the
+// packet with Sequence timed out before the destination chain processed it.
+// Proof is the proof of the IngressSequence of the destination chain, which
+// must not be past Sequence, against the app hash of the header at
+// ProofHeight, which must be at or past the timeout of the packet.
+type IBCTimeoutMsg struct {
+	DestChain       string         `json:"dest_chain"`
+	Sequence        int64          `json:"sequence"`
+	IngressSequence int64          `json:"ingress_sequence"`
+	Relayer         sdk.AccAddress `json:"relayer"`
+	ProofHeight     int64          `json:"proof_height"`
+	Proof           []byte         `json:"proof"`
+}
+
+// nolint
+func (msg IBCTimeoutMsg) Type() string                 { return "ibc" }
+func (msg IBCTimeoutMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc timeout message
+func (msg IBCTimeoutMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc timeout message
+func (msg IBCTimeoutMsg) ValidateBasic() sdk.Error {
+	if len(msg.DestChain) == 0 || msg.Sequence < 0 || msg.IngressSequence < 0 {
+		return ErrInvalidSequence(DefaultCodespace)
+	}
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCCreateClientMsg
+
+// IBCCreateClientMsg creates the light client of the chain of Header, which
+// trusts Validators from then on. Validators must have signed Header and
+// match the validators hash fixed for the chain by governance.
+type IBCCreateClientMsg struct {
+	Header     tmtypes.SignedHeader  `json:"header"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+	Signer     sdk.AccAddress        `json:"signer"`
+}
+
+// nolint
+func (msg IBCCreateClientMsg) Type() string                 { return "ibc" }
+func (msg IBCCreateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for ibc create client message
+func (msg IBCCreateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc create client message
+func (msg IBCCreateClientMsg) ValidateBasic() sdk.Error {
+	return validateClientHeader(msg.Header, msg.Validators)
+}
+
+// ----------------------------------
+// IBCUpdateClientMsg
+
+// IBCUpdateClientMsg updates the light client of the chain of Header with the
+// new Header, signed by Validators.
+type IBCUpdateClientMsg struct {
+	Header     tmtypes.SignedHeader  `json:"header"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+	Signer     sdk.AccAddress        `json:"signer"`
+}
+
+// nolint
+func (msg IBCUpdateClientMsg) Type() string                 { return "ibc" }
+func (msg IBCUpdateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for ibc update client message
+func (msg IBCUpdateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc update client message
+func (msg IBCUpdateClientMsg) ValidateBasic() sdk.Error {
+	return validateClientHeader(msg.Header, msg.Validators)
+}
+
+func validateClientHeader(header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	if header.Header == nil || header.Commit == nil {
+		return ErrInvalidHeader(DefaultCodespace, "missing header or commit")
+	}
+	if len(header.Header.ChainID) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "missing chain ID")
+	}
+	if validators == nil || validators.Size() == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "missing validator set")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +19,14 @@ func TestIBCPacketValidation(t *testing.T) {
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{true, withTimeout(constructIBCPacket(true), 0, time.Unix(1000, 0))},
+		{false, withTimeout(constructIBCPacket(true), 0, time.Time{})},
+		{false, withTimeout(constructIBCPacket(true), -1, time.Unix(1000, 0))},
+		{false, withPorts(constructIBCPacket(true), "", TransferPort)},
+		{false, withPorts(constructIBCPacket(true), TransferPort, "trans/fer")},
+		{true, withPorts(constructIBCPacket(true), "oracle", "gov")},
+		{false, withPayload(constructIBCPacket(true), Payload{Data: []byte("data")})},
+		{true, withPayload(constructIBCPacket(true), Payload{Type: "price"})},
 	}
 
 	for i, tc := range cases {
@@ -30,26 +39,58 @@ func TestIBCPacketValidation(t *testing.T) {
 	}
 }
 
+func TestIBCPacketTimedOut(t *testing.T) {
+	timeoutTime := time.Unix(1000, 0)
+	cases := []struct {
+		packet    IBCPacket
+		height    int64
+		blockTime time.Time
+		timedOut  bool
+	}{
+		{withTimeout(constructIBCPacket(true), 10, time.Time{}), 9, timeoutTime, false},
+		{withTimeout(constructIBCPacket(true), 10, time.Time{}), 10, timeoutTime, true},
+		{withTimeout(constructIBCPacket(true), 0, timeoutTime), 100, timeoutTime.Add(-time.Second), false},
+		{withTimeout(constructIBCPacket(true), 0, timeoutTime), 100, timeoutTime, true},
+		{withTimeout(constructIBCPacket(true), 10, timeoutTime), 10, timeoutTime.Add(-time.Second), true},
+		{withTimeout(constructIBCPacket(true), 10, timeoutTime), 9, timeoutTime, true},
+	}
+
+	for i, tc := range cases {
+		require.Equal(t, tc.timedOut, tc.packet.TimedOut(tc.height, tc.blockTime), "%d", i)
+	}
+}
+
 // -------------------------------
 // IBCTransferMsg Tests
 
 func TestIBCTransferMsg(t *testing.T) {
-	packet := constructIBCPacket(true)
-	msg := IBCTransferMsg{packet}
+	msg := constructIBCTransferMsg(sdk.Coins{sdk.NewInt64Coin("atom", 10)}, "dest-chain")
 
-	require.Equal(t, msg.Type(), "ibc")
+	require.Equal(t, msg.Type(), "ibctransfer")
+	require.Equal(t, []sdk.AccAddress{msg.SrcAddr}, msg.GetSigners())
+
+	packet := msg.Packet()
+	require.Equal(t, TransferPort, packet.SrcPort)
+	require.Equal(t, TransferPort, packet.DestPort)
+	payload, err := GetTransferPayload(packet)
+	require.Nil(t, err)
+	require.Equal(t, msg.TransferPayload, payload)
+
+	packet.Payload.Type = "price"
+	_, err = GetTransferPayload(packet)
+	require.NotNil(t, err)
 }
 
 func TestIBCTransferMsgValidation(t *testing.T) {
-	validPacket := constructIBCPacket(true)
-	invalidPacket := constructIBCPacket(false)
+	coins := sdk.Coins{sdk.NewInt64Coin("atom", 10)}
 
 	cases := []struct {
 		valid bool
 		msg   IBCTransferMsg
 	}{
-		{true, IBCTransferMsg{validPacket}},
-		{false, IBCTransferMsg{invalidPacket}},
+		{true, constructIBCTransferMsg(coins, "dest-chain")},
+		{false, constructIBCTransferMsg(coins, "source-chain")},
+		{false, constructIBCTransferMsg(sdk.Coins{sdk.NewInt64Coin("atom", 0)}, "dest-chain")},
 	}
 
 	for i, tc := range cases {
@@ -67,7 +108,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0}
+	msg := IBCReceiveMsg{IBCPacket: packet, Relayer: sdk.AccAddress([]byte("relayer"))}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
@@ -75,13 +116,16 @@ func TestIBCReceiveMsg(t *testing.T) {
 func TestIBCReceiveMsgValidation(t *testing.T) {
 	validPacket := constructIBCPacket(true)
 	invalidPacket := constructIBCPacket(false)
+	relayer := sdk.AccAddress([]byte("relayer"))
+	proof := []byte("proof")
 
 	cases := []struct {
 		valid bool
 		msg   IBCReceiveMsg
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0}},
+		{true, IBCReceiveMsg{IBCPacket: validPacket, Relayer: relayer, Proof: proof}},
+		{false, IBCReceiveMsg{IBCPacket: invalidPacket, Relayer: relayer, Proof: proof}},
+		{false, IBCReceiveMsg{IBCPacket: validPacket, Relayer: relayer}},
 	}
 
 	for i, tc := range cases {
@@ -105,7 +149,53 @@ func constructIBCPacket(valid bool) IBCPacket {
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewTransferPacket(srcAddr, destAddr, coins, srcChain, destChain, 100, time.Time{})
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewTransferPacket(srcAddr, destAddr, coins, srcChain, srcChain, 100, time.Time{})
+}
+
+func constructIBCTransferMsg(coins sdk.Coins, destChain string) IBCTransferMsg {
+	srcAddr := sdk.AccAddress([]byte("source"))
+	destAddr := sdk.AccAddress([]byte("destination"))
+	return NewIBCTransferMsg(srcAddr, destAddr, coins, "source-chain", destChain, 100, time.Time{})
+}
+
+func withPorts(packet IBCPacket, srcPort string, destPort string) IBCPacket {
+	packet.SrcPort = srcPort
+	packet.DestPort = destPort
+	return packet
+}
+
+func withPayload(packet IBCPacket, payload Payload) IBCPacket {
+	packet.Payload = payload
+	return packet
+}
+
+func withTimeout(packet IBCPacket, timeoutHeight int64, timeoutTime time.Time) IBCPacket {
+	packet.TimeoutHeight = timeoutHeight
+	packet.TimeoutTime = timeoutTime
+	return packet
+}
+
+func TestParseDenomTrace(t *testing.T) {
+	cases := []struct {
+		denom string
+		trace DenomTrace
+	}{
+		{"steak", DenomTrace{Path: []string{}, BaseDenom: "steak"}},
+		{"gaia-7001/steak", DenomTrace{Path: []string{"gaia-7001"}, BaseDenom: "steak"}},
+		{"hub/gaia-7001/steak", DenomTrace{Path: []string{"hub", "gaia-7001"}, BaseDenom: "steak"}},
+	}
+
+	for _, tc := range cases {
+		trace := ParseDenomTrace(tc.denom)
+		require.Equal(t, tc.trace, trace)
+		require.Equal(t, tc.denom, trace.Denom())
+		require.Equal(t, len(tc.trace.Path) > 0, trace.IsVoucher())
+	}
+
+	require.Equal(t, "gaia-7001/steak", VoucherDenom("gaia-7001", "steak"))
+	require.True(t, returnsTo("gaia-7001/steak", "gaia-7001"))
+	require.False(t, returnsTo("hub/gaia-7001/steak", "gaia-7001"))
+	require.False(t, returnsTo("steak", "gaia-7001"))
 }
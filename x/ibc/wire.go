@@ -8,4 +8,8 @@ import (
 func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(IBCTransferMsg{}, "cosmos-sdk/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "cosmos-sdk/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCCreateClientMsg{}, "cosmos-sdk/IBCCreateClientMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateClientMsg{}, "cosmos-sdk/IBCUpdateClientMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "cosmos-sdk/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "cosmos-sdk/IBCTimeoutMsg", nil)
 }
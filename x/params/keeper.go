@@ -12,16 +12,62 @@ import (
 type Keeper struct {
 	cdc *wire.Codec
 	key sdk.StoreKey
+
+	// registered types of the parameters which can be set from JSON
+	types map[string]paramType
+}
+
+// ValidateFn checks the value of a param before it is set from JSON, the
+// value being of the registered type of the param. The context holds the
+// current values of the other params.
+type ValidateFn func(ctx sdk.Context, value interface{}) error
+
+// ExternalSetFn sets a param stored by the module owning it rather than by
+// the param store.
+type ExternalSetFn func(ctx sdk.Context, value interface{})
+
+type paramType struct {
+	ty       reflect.Type
+	validate ValidateFn
+	set      ExternalSetFn
 }
 
 // NewKeeper constructs a new Keeper
 func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
 	return Keeper{
-		cdc: cdc,
-		key: key,
+		cdc:   cdc,
+		key:   key,
+		types: make(map[string]paramType),
 	}
 }
 
+// RegisterType registers the type of the parameter stored under key. Only
+// parameters with a registered type can be set from their JSON encoding, and
+// only to the values accepted by validate, if not nil.
+func (k Keeper) RegisterType(key string, param interface{}, validate ValidateFn) {
+	k.registerType(key, param, validate, nil)
+}
+
+// RegisterExternalType registers the type of a parameter kept by its module
+// outside of the param store, set from its JSON encoding by set.
+func (k Keeper) RegisterExternalType(key string, param interface{}, validate ValidateFn, set ExternalSetFn) {
+	if set == nil {
+		panic(fmt.Sprintf("no setter for external param %s", key))
+	}
+	k.registerType(key, param, validate, set)
+}
+
+func (k Keeper) registerType(key string, param interface{}, validate ValidateFn, set ExternalSetFn) {
+	if _, ok := k.types[key]; ok {
+		panic(fmt.Sprintf("type of param %s already registered", key))
+	}
+	ty := reflect.TypeOf(param)
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	k.types[key] = paramType{ty, validate, set}
+}
+
 // InitKeeper constructs a new Keeper with initial parameters
 func InitKeeper(ctx sdk.Context, cdc *wire.Codec, key sdk.StoreKey, params ...interface{}) Keeper {
 	if len(params)%2 != 0 {
@@ -72,6 +118,39 @@ func (k Keeper) set(ctx sdk.Context, key string, param interface{}) error {
 	return nil
 }
 
+// decodeJSON decodes a JSON encoded parameter into its registered type and
+// validates it
+func (k Keeper) decodeJSON(ctx sdk.Context, key string, value []byte) (interface{}, paramType, error) {
+	pt, ok := k.types[key]
+	if !ok {
+		return nil, pt, fmt.Errorf("No type registered for param %s", key)
+	}
+	ptr := reflect.New(pt.ty)
+	if err := k.cdc.UnmarshalJSON(value, ptr.Interface()); err != nil {
+		return nil, pt, fmt.Errorf("Invalid value for param %s: %v", key, err)
+	}
+	param := ptr.Elem().Interface()
+	if pt.validate != nil {
+		if err := pt.validate(ctx, param); err != nil {
+			return nil, pt, fmt.Errorf("Invalid value for param %s: %v", key, err)
+		}
+	}
+	return param, pt, nil
+}
+
+// setJSON decodes a JSON encoded parameter into its registered type and sets it
+func (k Keeper) setJSON(ctx sdk.Context, key string, value []byte) error {
+	param, pt, err := k.decodeJSON(ctx, key, value)
+	if err != nil {
+		return err
+	}
+	if pt.set != nil {
+		pt.set(ctx, param)
+		return nil
+	}
+	return k.set(ctx, key, param)
+}
+
 // setRaw sets raw byte slice
 func (k Keeper) setRaw(ctx sdk.Context, key string, param []byte) {
 	store := ctx.KVStore(k.key)
@@ -103,6 +182,13 @@ func (k Getter) GetRaw(ctx sdk.Context, key string) []byte {
 	return k.k.getRaw(ctx, key)
 }
 
+// ValidateJSON checks that a JSON encoded parameter matches the registered
+// type of the param and is a valid value given the current params
+func (k Getter) ValidateJSON(ctx sdk.Context, key string, value []byte) error {
+	_, _, err := k.k.decodeJSON(ctx, key, value)
+	return err
+}
+
 // GetString is helper function for string params
 func (k Getter) GetString(ctx sdk.Context, key string) (res string, err error) {
 	store := ctx.KVStore(k.k.key)
@@ -322,6 +408,11 @@ func (k Setter) Set(ctx sdk.Context, key string, param interface{}) error {
 	return k.k.set(ctx, key, param)
 }
 
+// SetJSON exposes setJSON
+func (k Setter) SetJSON(ctx sdk.Context, key string, value []byte) error {
+	return k.k.setJSON(ctx, key, value)
+}
+
 // SetRaw exposes setRaw
 func (k Setter) SetRaw(ctx sdk.Context, key string, param []byte) {
 	k.k.setRaw(ctx, key, param)
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -278,3 +279,54 @@ func TestGetter(t *testing.T) {
 	assert.Equal(t, def10, res)
 
 }
+
+func TestSetJSON(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	keeper := NewKeeper(wire.NewCodec(), key)
+	setter := keeper.Setter()
+
+	keeper.RegisterType("int64", int64(0), func(_ sdk.Context, value interface{}) error {
+		if value.(int64) <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	})
+	keeper.RegisterType("dec", sdk.Dec{}, nil)
+	assert.Panics(t, func() { keeper.RegisterType("int64", int64(0), nil) })
+
+	// values must match the registered type and pass its validation
+	assert.Nil(t, setter.ValidateJSON(ctx, "int64", []byte(`"10"`)))
+	assert.NotNil(t, setter.ValidateJSON(ctx, "int64", []byte(`"ten"`)))
+	assert.NotNil(t, setter.ValidateJSON(ctx, "int64", []byte(`"0"`)))
+	assert.NotNil(t, setter.ValidateJSON(ctx, "unregistered", []byte(`"10"`)))
+
+	assert.Nil(t, setter.SetJSON(ctx, "int64", []byte(`"10"`)))
+	assert.Equal(t, int64(10), setter.GetInt64WithDefault(ctx, "int64", 0))
+
+	assert.Nil(t, setter.SetJSON(ctx, "dec", []byte(`"5000000000"`)))
+	assert.True(t, sdk.NewDecWithPrec(5, 1).Equal(setter.GetDecWithDefault(ctx, "dec", sdk.ZeroDec())))
+
+	// invalid values leave the stored param untouched
+	assert.NotNil(t, setter.SetJSON(ctx, "int64", []byte(`true`)))
+	assert.NotNil(t, setter.SetJSON(ctx, "int64", []byte(`"-1"`)))
+	assert.NotNil(t, setter.SetJSON(ctx, "unregistered", []byte(`"10"`)))
+	assert.Equal(t, int64(10), setter.GetInt64WithDefault(ctx, "int64", 0))
+}
+
+func TestSetJSONExternal(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	keeper := NewKeeper(wire.NewCodec(), key)
+	setter := keeper.Setter()
+
+	var external int64
+	keeper.RegisterExternalType("external", int64(0), nil, func(_ sdk.Context, value interface{}) {
+		external = value.(int64)
+	})
+
+	// external params are set by their setter, not in the param store
+	assert.Nil(t, setter.SetJSON(ctx, "external", []byte(`"10"`)))
+	assert.Equal(t, int64(10), external)
+	assert.Nil(t, setter.GetRaw(ctx, "external"))
+}
@@ -44,3 +44,13 @@ func (v ValidatorHooks) OnValidatorBonded(ctx sdk.Context, address sdk.ConsAddre
 func (v ValidatorHooks) OnValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddress) {
 	v.k.onValidatorBeginUnbonding(ctx, address)
 }
+
+// nolint - unused hooks
+func (v ValidatorHooks) OnValidatorCreated(_ sdk.Context, _ sdk.ValAddress) {}
+func (v ValidatorHooks) OnValidatorRemoved(_ sdk.Context, _ sdk.ValAddress) {}
+func (v ValidatorHooks) BeforeDelegationCreated(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {
+}
+func (v ValidatorHooks) BeforeDelegationSharesModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {
+}
+func (v ValidatorHooks) AfterDelegationModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {
+}
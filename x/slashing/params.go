@@ -1,9 +1,11 @@
 package slashing
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // nolint
@@ -17,6 +19,40 @@ const (
 	SlashFractionDowntimeKey    = "slashing/SlashFractionDowntime"
 )
 
+// RegisterParamTypes registers the types of the slashing params with the
+// param store, so they can be changed through governance
+func RegisterParamTypes(k params.Keeper) {
+	k.RegisterType(MaxEvidenceAgeKey, int64(0), validatePositive)
+	k.RegisterType(SignedBlocksWindowKey, int64(0), validatePositive)
+	k.RegisterType(MinSignedPerWindowKey, sdk.Dec{}, validateFraction)
+	k.RegisterType(DoubleSignUnbondDurationKey, int64(0), validateNonNegative)
+	k.RegisterType(DowntimeUnbondDurationKey, int64(0), validateNonNegative)
+	k.RegisterType(SlashFractionDoubleSignKey, sdk.Dec{}, validateFraction)
+	k.RegisterType(SlashFractionDowntimeKey, sdk.Dec{}, validateFraction)
+}
+
+func validatePositive(_ sdk.Context, value interface{}) error {
+	if value.(int64) <= 0 {
+		return fmt.Errorf("must be positive, got %d", value)
+	}
+	return nil
+}
+
+func validateNonNegative(_ sdk.Context, value interface{}) error {
+	if value.(int64) < 0 {
+		return fmt.Errorf("must not be negative, got %d", value)
+	}
+	return nil
+}
+
+func validateFraction(_ sdk.Context, value interface{}) error {
+	dec := value.(sdk.Dec)
+	if dec.IsNil() || dec.LT(sdk.ZeroDec()) || dec.GT(sdk.OneDec()) {
+		return fmt.Errorf("must be between 0 and 1, got %v", dec)
+	}
+	return nil
+}
+
 // MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
 // MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
 func (k Keeper) MaxEvidenceAge(ctx sdk.Context) time.Duration {
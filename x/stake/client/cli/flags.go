@@ -21,6 +21,10 @@ const (
 	FlagIdentity = "identity"
 	FlagWebsite  = "website"
 	FlagDetails  = "details"
+
+	FlagCommissionRate          = types.FlagCommissionRate
+	FlagCommissionMaxRate       = types.FlagCommissionMaxRate
+	FlagCommissionMaxChangeRate = types.FlagCommissionMaxChangeRate
 )
 
 // common flagsets to add to various functions
@@ -30,6 +34,8 @@ var (
 	fsShares            = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDescriptionCreate = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDescriptionEdit   = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommissionCreate  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommissionUpdate  = flag.NewFlagSet("", flag.ContinueOnError)
 	fsValidator         = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDelegator         = flag.NewFlagSet("", flag.ContinueOnError)
 	fsRedelegation      = flag.NewFlagSet("", flag.ContinueOnError)
@@ -48,6 +54,10 @@ func init() {
 	fsDescriptionEdit.String(FlagIdentity, types.DoNotModifyDesc, "optional identity signature (ex. UPort or Keybase)")
 	fsDescriptionEdit.String(FlagWebsite, types.DoNotModifyDesc, "optional website")
 	fsDescriptionEdit.String(FlagDetails, types.DoNotModifyDesc, "optional details")
+	fsCommissionCreate.String(FlagCommissionRate, "", "initial commission rate percentage as a decimal")
+	fsCommissionCreate.String(FlagCommissionMaxRate, "", "maximum commission rate percentage as a decimal")
+	fsCommissionCreate.String(FlagCommissionMaxChangeRate, "", "maximum commission change rate percentage (per day) as a decimal")
+	fsCommissionUpdate.String(FlagCommissionRate, "", "new commission rate percentage as a decimal, optional")
 	fsValidator.String(FlagAddressValidator, "", "hex address of the validator")
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
 	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
@@ -66,6 +66,15 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				Details:  viper.GetString(FlagDetails),
 			}
 
+			commission, err := stake.BuildCommissionMsg(
+				viper.GetString(FlagCommissionRate),
+				viper.GetString(FlagCommissionMaxRate),
+				viper.GetString(FlagCommissionMaxChangeRate),
+			)
+			if err != nil {
+				return err
+			}
+
 			var msg sdk.Msg
 			if viper.GetString(FlagAddressDelegator) != "" {
 				delAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressDelegator))
@@ -73,9 +82,9 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 					return err
 				}
 
-				msg = stake.NewMsgCreateValidatorOnBehalfOf(delAddr, sdk.ValAddress(valAddr), pk, amount, description)
+				msg = stake.NewMsgCreateValidatorOnBehalfOf(delAddr, sdk.ValAddress(valAddr), pk, amount, description, commission)
 			} else {
-				msg = stake.NewMsgCreateValidator(sdk.ValAddress(valAddr), pk, amount, description)
+				msg = stake.NewMsgCreateValidator(sdk.ValAddress(valAddr), pk, amount, description, commission)
 			}
 			if cliCtx.GenerateOnly {
 				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
@@ -88,6 +97,7 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsPk)
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescriptionCreate)
+	cmd.Flags().AddFlagSet(fsCommissionCreate)
 	cmd.Flags().AddFlagSet(fsDelegator)
 
 	return cmd
@@ -117,7 +127,16 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 				Details:  viper.GetString(FlagDetails),
 			}
 
-			msg := stake.NewMsgEditValidator(sdk.ValAddress(valAddr), description)
+			var newRate *sdk.Dec
+			if rateStr := viper.GetString(FlagCommissionRate); rateStr != "" {
+				rate, err := sdk.NewDecFromStr(rateStr)
+				if err != nil {
+					return fmt.Errorf("invalid new commission rate: %v", err)
+				}
+				newRate = &rate
+			}
+
+			msg := stake.NewMsgEditValidator(sdk.ValAddress(valAddr), description, newRate)
 
 			if cliCtx.GenerateOnly {
 				return utils.PrintUnsignedStdTx(txCtx, cliCtx, []sdk.Msg{msg})
@@ -128,6 +147,7 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().AddFlagSet(fsDescriptionEdit)
+	cmd.Flags().AddFlagSet(fsCommissionUpdate)
 
 	return cmd
 }
@@ -25,6 +25,14 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec,
 		"/stake/delegators/{delegatorAddr}/delegations",
 		delegationsRequestHandlerFn(cdc, kb, cliCtx),
 	).Methods("POST")
+	r.HandleFunc(
+		"/stake/validators",
+		createValidatorRequestHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/stake/validators/{validatorAddr}",
+		editValidatorRequestHandlerFn(cdc, kb, cliCtx),
+	).Methods("PUT")
 }
 
 type msgDelegationsInput struct {
@@ -69,6 +77,33 @@ type EditDelegationsBody struct {
 	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"`
 }
 
+type baseBody struct {
+	LocalAccountName string `json:"name"`
+	Password         string `json:"password"`
+	ChainID          string `json:"chain_id"`
+	AccountNumber    int64  `json:"account_number"`
+	Sequence         int64  `json:"sequence"`
+	Gas              int64  `json:"gas"`
+	GasAdjustment    string `json:"gas_adjustment"`
+}
+
+// the request body to create a validator operated by the local account
+type CreateValidatorBody struct {
+	BaseBody    baseBody            `json:"base_req"`
+	Description stake.Description   `json:"description"`
+	Commission  stake.CommissionMsg `json:"commission"`
+	PubKey      string              `json:"pub_key"` // bech32 consensus public key
+	Delegation  sdk.Coin            `json:"delegation"`
+}
+
+// the request body to edit a validator, the description fields set to
+// "[do-not-modify]" are unchanged
+type EditValidatorBody struct {
+	BaseBody       baseBody          `json:"base_req"`
+	Description    stake.Description `json:"description"`
+	CommissionRate *sdk.Dec          `json:"commission_rate"` // the new commission rate, unchanged if nil
+}
+
 // nolint: gocyclo
 // TODO: Split this up into several smaller functions, and remove the above nolint
 // TODO: use sdk.ValAddress instead of sdk.AccAddress for validators in messages
@@ -334,3 +369,134 @@ func delegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx contex
 		w.Write(output)
 	}
 }
+
+func createValidatorRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m CreateValidatorBody
+		if !readBody(w, r, cdc, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.BaseBody.LocalAccountName)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		pk, err := sdk.GetConsPubKeyBech32(m.PubKey)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Couldn't decode pub key. Error: %s", err.Error()))
+			return
+		}
+
+		valAddr := sdk.ValAddress(info.GetPubKey().Address())
+		msg := stake.NewMsgCreateValidator(valAddr, pk, m.Delegation, m.Description, m.Commission)
+		if err := msg.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		signAndBroadcast(w, r, cdc, cliCtx, m.BaseBody, msg)
+	}
+}
+
+func editValidatorRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m EditValidatorBody
+		if !readBody(w, r, cdc, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.BaseBody.LocalAccountName)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		valAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error()))
+			return
+		}
+		if !bytes.Equal(info.GetPubKey().Address(), valAddr) {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, "Must use own validator address")
+			return
+		}
+
+		msg := stake.NewMsgEditValidator(valAddr, m.Description, m.CommissionRate)
+		if err := msg.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		signAndBroadcast(w, r, cdc, cliCtx, m.BaseBody, msg)
+	}
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, cdc *wire.Codec, m interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	err = cdc.UnmarshalJSON(body, m)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func signAndBroadcast(w http.ResponseWriter, r *http.Request, cdc *wire.Codec, cliCtx context.CLIContext, m baseBody, msg sdk.Msg) {
+	txCtx := authcliCtx.TxContext{
+		Codec:         cdc,
+		ChainID:       m.ChainID,
+		AccountNumber: m.AccountNumber,
+		Sequence:      m.Sequence,
+		Gas:           m.Gas,
+	}
+
+	adjustment, ok := utils.ParseFloat64OrReturnBadRequest(w, m.GasAdjustment, client.DefaultGasAdjustment)
+	if !ok {
+		return
+	}
+	cliCtx = cliCtx.WithGasAdjustment(adjustment)
+
+	if utils.HasDryRunArg(r) || m.Gas == 0 {
+		newCtx, err := utils.EnrichCtxWithGas(txCtx, cliCtx, m.LocalAccountName, []sdk.Msg{msg})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if utils.HasDryRunArg(r) {
+			utils.WriteSimulationResponse(w, newCtx.Gas)
+			return
+		}
+		txCtx = newCtx
+	}
+
+	if utils.HasGenerateOnlyArg(r) {
+		utils.WriteGenerateStdTxResponse(w, txCtx, []sdk.Msg{msg})
+		return
+	}
+
+	txBytes, err := txCtx.BuildAndSign(m.LocalAccountName, m.Password, []sdk.Msg{msg})
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	res, err := cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	output, err := wire.MarshalJSONIndent(cdc, res)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Write(output)
+}
@@ -43,9 +43,15 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 	blockTime := ctx.BlockHeader().Time
 	if blockTime.Sub(pool.InflationLastTime) >= time.Hour {
 		params := k.GetParams(ctx)
+		looseTokens := pool.LooseTokens
 		pool.InflationLastTime = blockTime
 		pool = pool.ProcessProvisions(params)
+
+		// provisions are distributed together with the collected fees
+		var provisions sdk.Int
+		pool, provisions = pool.CollectProvisions(looseTokens)
 		k.SetPool(ctx, pool)
+		k.CollectProvisions(ctx, sdk.Coins{sdk.NewCoin(params.BondDenom, provisions)})
 	}
 
 	// reset the intra-transaction counter
@@ -78,12 +84,17 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	}
 
 	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, msg.Description)
+	validator, err := validator.SetInitialCommission(msg.Commission, ctx.BlockHeader().Time)
+	if err != nil {
+		return err.Result()
+	}
 	k.SetValidator(ctx, validator)
 	k.SetValidatorByPubKeyIndex(ctx, validator)
+	k.OnValidatorCreated(ctx, validator.Operator)
 
 	// move coins from the msg.Address account to a (self-delegation) delegator account
 	// the validator account and global shares are updated within here
-	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
+	_, err = k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
 	if err != nil {
 		return err.Result()
 	}
@@ -108,20 +119,31 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	}
 
 	// replace all editable fields (clients should autofill existing values)
-	description, err := validator.Description.UpdateDescription(msg.Description)
-	if err != nil {
-		return err.Result()
+	if msg.Description != (Description{}) {
+		description, err := validator.Description.UpdateDescription(msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		validator.Description = description
+	}
+
+	// the commission rate can only be changed within the validator's bounds
+	if msg.CommissionRate != nil {
+		var err sdk.Error
+		validator, err = validator.UpdateCommission(*msg.CommissionRate, ctx.BlockHeader().Time)
+		if err != nil {
+			return err.Result()
+		}
 	}
-	validator.Description = description
 
 	// We don't need to run through all the power update logic within k.UpdateValidator
-	// We just need to override the entry in state, since only the description has changed.
+	// We just need to override the entry in state, since only the description and commission have changed.
 	k.SetValidator(ctx, validator)
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionEditValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -16,8 +17,10 @@ import (
 
 //______________________________________________________________________
 
+var commissionMsg = NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+
 func newTestMsgCreateValidator(address sdk.ValAddress, pubKey crypto.PubKey, amt int64) MsgCreateValidator {
-	return types.NewMsgCreateValidator(address, pubKey, sdk.Coin{"steak", sdk.NewInt(amt)}, Description{})
+	return types.NewMsgCreateValidator(address, pubKey, sdk.Coin{"steak", sdk.NewInt(amt)}, Description{}, commissionMsg)
 }
 
 func newTestMsgDelegate(delAddr sdk.AccAddress, valAddr sdk.ValAddress, amt int64) MsgDelegate {
@@ -31,6 +34,7 @@ func newTestMsgDelegate(delAddr sdk.AccAddress, valAddr sdk.ValAddress, amt int6
 func newTestMsgCreateValidatorOnBehalfOf(delAddr sdk.AccAddress, valAddr sdk.ValAddress, valPubKey crypto.PubKey, amt int64) MsgCreateValidator {
 	return MsgCreateValidator{
 		Description:   Description{},
+		Commission:    commissionMsg,
 		DelegatorAddr: delAddr,
 		ValidatorAddr: valAddr,
 		PubKey:        valPubKey,
@@ -193,6 +197,46 @@ func TestDuplicatesMsgCreateValidatorOnBehalfOf(t *testing.T) {
 	require.False(t, got.IsOK(), "%v", got)
 }
 
+func TestEditValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := sdk.ValAddress(keep.Addrs[0])
+
+	// create a validator with a 10% commission, capped at 20% and 5% per day
+	commission := NewCommissionMsg(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(5, 2))
+	msgCreateValidator := types.NewMsgCreateValidator(validatorAddr, keep.PKs[0], sdk.NewInt64Coin("steak", 10), Description{}, commission)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+
+	editCommission := func(rate sdk.Dec) sdk.Result {
+		return handleMsgEditValidator(ctx, types.NewMsgEditValidator(validatorAddr, Description{}, &rate), keeper)
+	}
+
+	// the commission cannot be changed within a day of the creation
+	got = editCommission(sdk.NewDecWithPrec(12, 2))
+	require.False(t, got.IsOK(), "expected edit-validator to fail within the change period")
+
+	// the commission cannot be changed by more than the max change rate
+	ctx = ctx.WithBlockHeader(abci.Header{Time: ctx.BlockHeader().Time.Add(types.CommissionChangePeriod)})
+	got = editCommission(sdk.NewDecWithPrec(16, 2))
+	require.False(t, got.IsOK(), "expected edit-validator to fail above the max change rate")
+
+	// a valid change is applied
+	got = editCommission(sdk.NewDecWithPrec(14, 2))
+	require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(sdk.DecEq(t, sdk.NewDecWithPrec(14, 2), validator.Commission))
+	require.Equal(t, ctx.BlockHeader().Time, validator.CommissionLastChange)
+
+	// the commission can never exceed the max rate
+	ctx = ctx.WithBlockHeader(abci.Header{Time: ctx.BlockHeader().Time.Add(types.CommissionChangePeriod)})
+	got = editCommission(sdk.NewDecWithPrec(19, 2))
+	require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: ctx.BlockHeader().Time.Add(types.CommissionChangePeriod)})
+	got = editCommission(sdk.NewDecWithPrec(21, 2))
+	require.False(t, got.IsOK(), "expected edit-validator to fail above the max rate")
+}
+
 func TestLegacyValidatorDelegations(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, int64(1000))
 	setInstantUnbondPeriod(keeper, ctx)
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// StoreDecoders returns the decoders of the values of the stake store, the
+// indexes excepted.
+func StoreDecoders() []sdk.KeyPrefixDecoder {
+	return []sdk.KeyPrefixDecoder{
+		{
+			Prefix: ParamKey,
+			Decode: func(cdc *wire.Codec, _, value []byte) (interface{}, error) {
+				return types.UnmarshalParams(cdc, value)
+			},
+		},
+		{
+			Prefix: PoolKey,
+			Decode: func(cdc *wire.Codec, _, value []byte) (interface{}, error) {
+				return types.UnmarshalPool(cdc, value)
+			},
+		},
+		{
+			Prefix: ValidatorsKey,
+			Decode: func(cdc *wire.Codec, key, value []byte) (interface{}, error) {
+				return types.UnmarshalValidator(cdc, key[1:], value)
+			},
+		},
+		{
+			Prefix: DelegationKey,
+			Decode: func(cdc *wire.Codec, key, value []byte) (interface{}, error) {
+				return types.UnmarshalDelegation(cdc, key, value)
+			},
+		},
+		{
+			Prefix: UnbondingDelegationKey,
+			Decode: func(cdc *wire.Codec, key, value []byte) (interface{}, error) {
+				return types.UnmarshalUBD(cdc, key, value)
+			},
+		},
+		{
+			Prefix: RedelegationKey,
+			Decode: func(cdc *wire.Codec, key, value []byte) (interface{}, error) {
+				return types.UnmarshalRED(cdc, key, value)
+			},
+		},
+	}
+}
@@ -234,12 +234,19 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 
 	if subtractAccount {
 		// Account new shares, save
-		_, _, err = k.coinKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
+		_, err = k.coinKeeper.DelegateCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
 		if err != nil {
 			return
 		}
 	}
 
+	// call the appropriate hook before the shares change
+	if found {
+		k.BeforeDelegationSharesModified(ctx, delAddr, validator.Operator)
+	} else {
+		k.BeforeDelegationCreated(ctx, delAddr, validator.Operator)
+	}
+
 	pool := k.GetPool(ctx)
 	validator, pool, newShares = validator.AddTokensFromDel(pool, bondAmt.Amount)
 	delegation.Shares = delegation.Shares.Add(newShares)
@@ -251,6 +258,8 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	k.SetDelegation(ctx, delegation)
 	k.UpdateValidator(ctx, validator)
 
+	k.AfterDelegationModified(ctx, delAddr, validator.Operator)
+
 	return
 }
 
@@ -278,6 +287,9 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 		return
 	}
 
+	// call the hook before the shares change
+	k.BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+
 	// subtract shares from delegator
 	delegation.Shares = delegation.Shares.Sub(shares)
 
@@ -305,6 +317,9 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 
 	// update then remove validator if necessary
 	validator = k.UpdateValidator(ctx, validator)
+	if !delegation.Shares.IsZero() {
+		k.AfterDelegationModified(ctx, delAddr, valAddr)
+	}
 	if validator.DelegatorShares.IsZero() {
 		k.RemoveValidator(ctx, validator.Operator)
 	}
@@ -362,7 +377,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 
 	// no need to create the ubd object just complete now
 	if completeNow {
-		_, _, err := k.coinKeeper.AddCoins(ctx, delAddr, sdk.Coins{balance})
+		_, err := k.coinKeeper.UndelegateCoins(ctx, delAddr, sdk.Coins{balance})
 		if err != nil {
 			return err
 		}
@@ -395,7 +410,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAd
 		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
 	}
 
-	_, _, err := k.coinKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
+	_, err := k.coinKeeper.UndelegateCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Expose the hooks if present
+func (k Keeper) OnValidatorCreated(ctx sdk.Context, address sdk.ValAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.OnValidatorCreated(ctx, address)
+	}
+}
+
+// nolint
+func (k Keeper) OnValidatorRemoved(ctx sdk.Context, address sdk.ValAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.OnValidatorRemoved(ctx, address)
+	}
+}
+
+// nolint
+func (k Keeper) OnValidatorBonded(ctx sdk.Context, address sdk.ConsAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.OnValidatorBonded(ctx, address)
+	}
+}
+
+// nolint
+func (k Keeper) OnValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.OnValidatorBeginUnbonding(ctx, address)
+	}
+}
+
+// nolint
+func (k Keeper) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.BeforeDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+
+// nolint
+func (k Keeper) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+
+// nolint
+func (k Keeper) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.validatorHooks != nil {
+		k.validatorHooks.AfterDelegationModified(ctx, delAddr, valAddr)
+	}
+}
+
+//_________________________________________________________________________
+
+// combine multiple validator hooks, all hook functions are run in array sequence
+type MultiValidatorHooks []sdk.ValidatorHooks
+
+// Assert implementation
+var _ sdk.ValidatorHooks = MultiValidatorHooks{}
+
+// create a set of validator hooks which are all called in the order provided
+func NewMultiValidatorHooks(hooks ...sdk.ValidatorHooks) MultiValidatorHooks {
+	return hooks
+}
+
+// nolint
+func (h MultiValidatorHooks) OnValidatorCreated(ctx sdk.Context, address sdk.ValAddress) {
+	for i := range h {
+		h[i].OnValidatorCreated(ctx, address)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) OnValidatorRemoved(ctx sdk.Context, address sdk.ValAddress) {
+	for i := range h {
+		h[i].OnValidatorRemoved(ctx, address)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) OnValidatorBonded(ctx sdk.Context, address sdk.ConsAddress) {
+	for i := range h {
+		h[i].OnValidatorBonded(ctx, address)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) OnValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddress) {
+	for i := range h {
+		h[i].OnValidatorBeginUnbonding(ctx, address)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+
+// nolint
+func (h MultiValidatorHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterDelegationModified(ctx, delAddr, valAddr)
+	}
+}
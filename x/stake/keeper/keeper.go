@@ -8,12 +8,19 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
+// expected fee collection keeper, receives the inflation provisions so they
+// can be distributed together with the collected fees
+type FeeCollectionKeeper interface {
+	AddCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins
+}
+
 // keeper of the stake store
 type Keeper struct {
-	storeKey       sdk.StoreKey
-	cdc            *wire.Codec
-	coinKeeper     bank.Keeper
-	validatorHooks sdk.ValidatorHooks
+	storeKey            sdk.StoreKey
+	cdc                 *wire.Codec
+	coinKeeper          bank.Keeper
+	validatorHooks      sdk.ValidatorHooks
+	feeCollectionKeeper FeeCollectionKeeper
 
 	// codespace
 	codespace sdk.CodespaceType
@@ -39,6 +46,24 @@ func (k Keeper) WithValidatorHooks(v sdk.ValidatorHooks) Keeper {
 	return k
 }
 
+// Set the fee collection keeper receiving the inflation provisions
+func (k Keeper) WithFeeCollectionKeeper(fck FeeCollectionKeeper) Keeper {
+	if k.feeCollectionKeeper != nil {
+		panic("cannot set fee collection keeper twice")
+	}
+	k.feeCollectionKeeper = fck
+	return k
+}
+
+// Add the inflation provisions to the collected fees, if a fee collection
+// keeper is set
+func (k Keeper) CollectProvisions(ctx sdk.Context, provisions sdk.Coins) {
+	if k.feeCollectionKeeper == nil || provisions.IsZero() {
+		return
+	}
+	k.feeCollectionKeeper.AddCollectedFees(ctx, provisions)
+}
+
 //_________________________________________________________________________
 
 // return the codespace
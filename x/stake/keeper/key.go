@@ -30,6 +30,7 @@ var (
 	RedelegationKey                  = []byte{0x0D} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by source validator owner
 	RedelegationByValDstIndexKey     = []byte{0x0F} // prefix for each key for an redelegation, by destination validator owner
+	ValidatorsByConsAddrIndexKey     = []byte{0x10} // prefix for each key to a validator index, by consensus address
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -46,6 +47,12 @@ func GetValidatorByPubKeyIndexKey(pubkey crypto.PubKey) []byte {
 	return append(ValidatorsByPubKeyIndexKey, pubkey.Bytes()...)
 }
 
+// gets the key for the validator with consensus address
+// VALUE: validator owner address ([]byte)
+func GetValidatorByConsAddrIndexKey(addr sdk.ConsAddress) []byte {
+	return append(ValidatorsByConsAddrIndexKey, addr.Bytes()...)
+}
+
 // gets the key for the current validator group
 // VALUE: none (key rearrangement with GetValKeyFromValBondedIndexKey)
 func GetValidatorsBondedIndexKey(operatorAddr sdk.ValAddress) []byte {
@@ -68,6 +68,15 @@ func (k Keeper) ValidatorByPubKey(ctx sdk.Context, pubkey crypto.PubKey) sdk.Val
 	return val
 }
 
+// get the sdk.validator for a particular consensus address
+func (k Keeper) ValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) sdk.Validator {
+	val, found := k.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		return nil
+	}
+	return val
+}
+
 // total power from the bond
 func (k Keeper) TotalPower(ctx sdk.Context) sdk.Dec {
 	pool := k.GetPool(ctx)
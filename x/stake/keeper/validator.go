@@ -68,6 +68,16 @@ func (k Keeper) GetValidatorByPubKey(ctx sdk.Context, pubkey crypto.PubKey) (val
 	return k.GetValidator(ctx, addr)
 }
 
+// get a single validator by consensus address
+func (k Keeper) GetValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) (validator types.Validator, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	addr := store.Get(GetValidatorByConsAddrIndexKey(consAddr))
+	if addr == nil {
+		return validator, false
+	}
+	return k.GetValidator(ctx, addr)
+}
+
 // set the main record holding validator details
 func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
@@ -75,10 +85,11 @@ func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store.Set(GetValidatorKey(validator.Operator), bz)
 }
 
-// validator index
+// validator index, by pubkey and by consensus address
 func (k Keeper) SetValidatorByPubKeyIndex(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(GetValidatorByPubKeyIndexKey(validator.PubKey), validator.Operator)
+	store.Set(GetValidatorByConsAddrIndexKey(validator.ConsAddress()), validator.Operator)
 }
 
 // validator index
@@ -641,9 +652,7 @@ func (k Keeper) beginUnbondingValidator(ctx sdk.Context, validator types.Validat
 	store.Delete(GetValidatorsBondedIndexKey(validator.Operator))
 
 	// call the unbond hook if present
-	if k.validatorHooks != nil {
-		k.validatorHooks.OnValidatorBeginUnbonding(ctx, validator.ConsAddress())
-	}
+	k.OnValidatorBeginUnbonding(ctx, validator.ConsAddress())
 
 	// return updated validator
 	return validator
@@ -673,9 +682,7 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 	store.Set(GetTendermintUpdatesKey(validator.Operator), bzABCI)
 
 	// call the bond hook if present
-	if k.validatorHooks != nil {
-		k.validatorHooks.OnValidatorBonded(ctx, validator.ConsAddress())
-	}
+	k.OnValidatorBonded(ctx, validator.ConsAddress())
 
 	// return updated validator
 	return validator
@@ -690,11 +697,15 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 		return
 	}
 
+	// call the removal hook before the record is gone
+	k.OnValidatorRemoved(ctx, address)
+
 	// delete the old validator record
 	store := ctx.KVStore(k.storeKey)
 	pool := k.GetPool(ctx)
 	store.Delete(GetValidatorKey(address))
 	store.Delete(GetValidatorByPubKeyIndexKey(validator.PubKey))
+	store.Delete(GetValidatorByConsAddrIndexKey(validator.ConsAddress()))
 	store.Delete(GetValidatorsByPowerIndexKey(validator, pool))
 
 	// delete from the current and power weighted validator groups if the validator
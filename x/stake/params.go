@@ -0,0 +1,56 @@
+package stake
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// ParamStoreKeyParams is the param store key through which the staking params
+// can be changed
+const ParamStoreKeyParams = "stake/params"
+
+// RegisterParamTypes registers the staking params with the param store, so
+// they can be changed through governance. They remain stored by the keeper.
+func RegisterParamTypes(pk params.Keeper, k Keeper) {
+	pk.RegisterExternalType(ParamStoreKeyParams, types.Params{},
+		func(ctx sdk.Context, value interface{}) error {
+			return validateParamsChange(k.GetParams(ctx), value.(types.Params))
+		},
+		func(ctx sdk.Context, value interface{}) {
+			k.SetParams(ctx, value.(types.Params))
+		},
+	)
+}
+
+func validateParamsChange(current, p types.Params) error {
+	for _, f := range []struct {
+		name  string
+		value sdk.Dec
+	}{
+		{"inflation rate change", p.InflationRateChange},
+		{"maximum inflation", p.InflationMax},
+		{"minimum inflation", p.InflationMin},
+		{"bonded goal", p.GoalBonded},
+	} {
+		if f.value.IsNil() || f.value.LT(sdk.ZeroDec()) || f.value.GT(sdk.OneDec()) {
+			return fmt.Errorf("%s must be between 0 and 1, got %v", f.name, f.value)
+		}
+	}
+	switch {
+	case p.InflationMin.GT(p.InflationMax):
+		return fmt.Errorf("minimum inflation %v above maximum inflation %v", p.InflationMin, p.InflationMax)
+	case p.GoalBonded.IsZero():
+		return fmt.Errorf("bonded goal must be positive")
+	case p.UnbondingTime < 0:
+		return fmt.Errorf("unbonding time must not be negative, got %v", p.UnbondingTime)
+	case p.MaxValidators == 0:
+		return fmt.Errorf("maximum number of validators must be positive")
+	case p.BondDenom != current.BondDenom:
+		// the bonded tokens are accounted in the current denomination
+		return fmt.Errorf("bond denomination can't be changed from %s", current.BondDenom)
+	}
+	return nil
+}
@@ -34,8 +34,16 @@ func SimulateMsgCreateValidator(m auth.AccountMapper, k stake.Keeper) simulation
 		if amount.Equal(sdk.ZeroInt()) {
 			return "no-operation", nil, nil
 		}
+		maxRate := r.Int63n(101)
+		maxChangeRate := r.Int63n(maxRate + 1)
+		commission := stake.NewCommissionMsg(
+			sdk.NewDecWithPrec(r.Int63n(maxRate+1), 2),
+			sdk.NewDecWithPrec(maxRate, 2),
+			sdk.NewDecWithPrec(maxChangeRate, 2),
+		)
 		msg := stake.MsgCreateValidator{
 			Description:   description,
+			Commission:    commission,
 			ValidatorAddr: address,
 			DelegatorAddr: sdk.AccAddress(address),
 			PubKey:        pubkey,
@@ -70,9 +78,11 @@ func SimulateMsgEditValidator(k stake.Keeper) simulation.Operation {
 		key := simulation.RandomKey(r, keys)
 		pubkey := key.PubKey()
 		address := sdk.ValAddress(pubkey.Address())
+		newRate := sdk.NewDecWithPrec(r.Int63n(101), 2)
 		msg := stake.MsgEditValidator{
-			Description:   description,
-			ValidatorAddr: address,
+			Description:    description,
+			ValidatorAddr:  address,
+			CommissionRate: &newRate,
 		}
 		if msg.ValidateBasic() != nil {
 			tb.Fatalf("expected msg to pass ValidateBasic: %s, log %s", msg.GetSignBytes(), log)
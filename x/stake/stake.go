@@ -9,6 +9,8 @@ import (
 
 type (
 	Keeper                = keeper.Keeper
+	FeeCollectionKeeper   = keeper.FeeCollectionKeeper
+	MultiValidatorHooks   = keeper.MultiValidatorHooks
 	Validator             = types.Validator
 	BechValidator         = types.BechValidator
 	Description           = types.Description
@@ -25,10 +27,13 @@ type (
 	MsgBeginRedelegate    = types.MsgBeginRedelegate
 	MsgCompleteRedelegate = types.MsgCompleteRedelegate
 	GenesisState          = types.GenesisState
+	CommissionMsg         = types.CommissionMsg
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper              = keeper.NewKeeper
+	NewMultiValidatorHooks = keeper.NewMultiValidatorHooks
+	StoreDecoders          = keeper.StoreDecoders
 
 	GetValidatorKey              = keeper.GetValidatorKey
 	GetValidatorByPubKeyIndexKey = keeper.GetValidatorByPubKeyIndexKey
@@ -60,6 +65,9 @@ var (
 	GetREDsToValDstIndexKey      = keeper.GetREDsToValDstIndexKey
 	GetREDsByDelToValDstIndexKey = keeper.GetREDsByDelToValDstIndexKey
 
+	GetValidatorByConsAddrIndexKey = keeper.GetValidatorByConsAddrIndexKey
+	ValidatorsByConsAddrIndexKey   = keeper.ValidatorsByConsAddrIndexKey
+
 	DefaultParams       = types.DefaultParams
 	InitialPool         = types.InitialPool
 	NewValidator        = types.NewValidator
@@ -76,6 +84,14 @@ var (
 	NewMsgCompleteUnbonding         = types.NewMsgCompleteUnbonding
 	NewMsgBeginRedelegate           = types.NewMsgBeginRedelegate
 	NewMsgCompleteRedelegate        = types.NewMsgCompleteRedelegate
+	NewCommissionMsg                = types.NewCommissionMsg
+	BuildCommissionMsg              = types.BuildCommissionMsg
+)
+
+const (
+	FlagCommissionRate          = types.FlagCommissionRate
+	FlagCommissionMaxRate       = types.FlagCommissionMaxRate
+	FlagCommissionMaxChangeRate = types.FlagCommissionMaxChangeRate
 )
 
 const (
@@ -100,6 +116,11 @@ var (
 	ErrCommissionNegative    = types.ErrCommissionNegative
 	ErrCommissionHuge        = types.ErrCommissionHuge
 
+	ErrCommissionGTMaxRate           = types.ErrCommissionGTMaxRate
+	ErrCommissionChangeRateGTMaxRate = types.ErrCommissionChangeRateGTMaxRate
+	ErrCommissionUpdateTime          = types.ErrCommissionUpdateTime
+	ErrCommissionGTMaxChangeRate     = types.ErrCommissionGTMaxChangeRate
+
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
 	ErrBadDelegationAmount       = types.ErrBadDelegationAmount
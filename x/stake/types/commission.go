@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// minimum time between two commission rate changes of a validator
+const CommissionChangePeriod = 24 * time.Hour
+
+// names of the flags setting the commission of a new validator, in the CLI
+// and in the genesis transactions
+// nolint
+const (
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxRate       = "commission-max-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+)
+
+// CommissionMsg defines the commission parameters a validator is created with
+type CommissionMsg struct {
+	Rate          sdk.Dec `json:"rate"`            // the commission rate charged to delegators
+	MaxRate       sdk.Dec `json:"max_rate"`        // maximum commission rate which the validator can ever charge
+	MaxChangeRate sdk.Dec `json:"max_change_rate"` // maximum daily change of the validator commission rate
+}
+
+// NewCommissionMsg returns an initialized validator commission message
+func NewCommissionMsg(rate, maxRate, maxChangeRate sdk.Dec) CommissionMsg {
+	return CommissionMsg{
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// Validate performs basic sanity validation checks of the initial commission
+// parameters of a validator
+func (c CommissionMsg) Validate() sdk.Error {
+	switch {
+	case c.Rate.IsNil() || c.MaxRate.IsNil() || c.MaxChangeRate.IsNil():
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "commission rates must be provided")
+	case c.Rate.LT(sdk.ZeroDec()), c.MaxRate.LT(sdk.ZeroDec()), c.MaxChangeRate.LT(sdk.ZeroDec()):
+		return ErrCommissionNegative(DefaultCodespace)
+	case c.MaxRate.GT(sdk.OneDec()):
+		return ErrCommissionHuge(DefaultCodespace)
+	case c.Rate.GT(c.MaxRate):
+		return ErrCommissionGTMaxRate(DefaultCodespace)
+	case c.MaxChangeRate.GT(c.MaxRate):
+		return ErrCommissionChangeRateGTMaxRate(DefaultCodespace)
+	}
+	return nil
+}
+
+func (c CommissionMsg) String() string {
+	return fmt.Sprintf("rate: %s, max rate: %s, max change rate: %s", c.Rate, c.MaxRate, c.MaxChangeRate)
+}
+
+// BuildCommissionMsg builds the commission message of a new validator from
+// the flag values
+func BuildCommissionMsg(rateStr, maxRateStr, maxChangeRateStr string) (commission CommissionMsg, err error) {
+	if rateStr == "" || maxRateStr == "" || maxChangeRateStr == "" {
+		return commission, fmt.Errorf("must specify all validator commission parameters using --%s, --%s and --%s",
+			FlagCommissionRate, FlagCommissionMaxRate, FlagCommissionMaxChangeRate)
+	}
+
+	rate, sdkErr := sdk.NewDecFromStr(rateStr)
+	if sdkErr != nil {
+		return commission, fmt.Errorf("invalid commission rate: %v", sdkErr)
+	}
+	maxRate, sdkErr := sdk.NewDecFromStr(maxRateStr)
+	if sdkErr != nil {
+		return commission, fmt.Errorf("invalid commission max rate: %v", sdkErr)
+	}
+	maxChangeRate, sdkErr := sdk.NewDecFromStr(maxChangeRateStr)
+	if sdkErr != nil {
+		return commission, fmt.Errorf("invalid commission max change rate: %v", sdkErr)
+	}
+
+	return NewCommissionMsg(rate, maxRate, maxChangeRate), nil
+}
+
+//______________________________________________________________________
+
+// SetInitialCommission sets the commission parameters of a newly created
+// validator, the commission rate can first be changed a day after creation
+func (v Validator) SetInitialCommission(commission CommissionMsg, blockTime time.Time) (Validator, sdk.Error) {
+	if err := commission.Validate(); err != nil {
+		return v, err
+	}
+	v.Commission = commission.Rate
+	v.CommissionMax = commission.MaxRate
+	v.CommissionChangeRate = commission.MaxChangeRate
+	v.CommissionLastChange = blockTime
+	return v, nil
+}
+
+// UpdateCommission changes the commission rate of a validator. The rate can
+// only be changed once per CommissionChangePeriod, by no more than the
+// validator's change rate and never above its maximum rate.
+func (v Validator) UpdateCommission(newRate sdk.Dec, blockTime time.Time) (Validator, sdk.Error) {
+	switch {
+	case blockTime.Sub(v.CommissionLastChange) < CommissionChangePeriod:
+		return v, ErrCommissionUpdateTime(DefaultCodespace)
+	case newRate.LT(sdk.ZeroDec()):
+		return v, ErrCommissionNegative(DefaultCodespace)
+	case newRate.GT(v.CommissionMax):
+		return v, ErrCommissionGTMaxRate(DefaultCodespace)
+	case newRate.Sub(v.Commission).Abs().GT(v.CommissionChangeRate):
+		return v, ErrCommissionGTMaxChangeRate(DefaultCodespace)
+	}
+	v.Commission = newRate
+	v.CommissionLastChange = blockTime
+	return v, nil
+}
@@ -82,6 +82,32 @@ func TestProcessProvisions(t *testing.T) {
 	checkFinalPoolValues(t, pool, sdk.NewDec(initialTotalTokens), cumulativeExpProvs)
 }
 
+// Test that the provisions are collected as whole tokens, their fractions
+// being carried into the next collections
+func TestCollectProvisions(t *testing.T) {
+	pool := InitialPool()
+	params := DefaultParams()
+	pool.LooseTokens = sdk.NewDec(550000000)
+	initialLooseTokens := pool.LooseTokens
+
+	collected := sdk.ZeroInt()
+	for hr := 0; hr < 100; hr++ {
+		looseTokens := pool.LooseTokens
+		pool = pool.ProcessProvisions(params)
+
+		var provisions sdk.Int
+		pool, provisions = pool.CollectProvisions(looseTokens)
+		collected = collected.Add(provisions)
+		require.True(t, pool.ProvisionsRemainder.GTE(sdk.ZeroDec()))
+		require.True(t, pool.ProvisionsRemainder.LT(sdk.OneDec()))
+	}
+
+	// nothing is lost to the truncation of the hourly provisions
+	totalProvisions := pool.LooseTokens.Sub(initialLooseTokens)
+	require.True(sdk.DecEq(t, totalProvisions, sdk.NewDecFromInt(collected).Add(pool.ProvisionsRemainder)))
+	require.True(t, collected.Equal(totalProvisions.TruncateInt()))
+}
+
 //_________________________________________________________________________________________
 ////////////////////////////////HELPER FUNCTIONS BELOW/////////////////////////////////////
 
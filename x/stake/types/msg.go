@@ -20,6 +20,7 @@ var _, _ sdk.Msg = &MsgBeginRedelegate{}, &MsgCompleteRedelegate{}
 // MsgCreateValidator - struct for unbonding transactions
 type MsgCreateValidator struct {
 	Description
+	Commission    CommissionMsg  `json:"commission"`
 	DelegatorAddr sdk.AccAddress `json:"delegator_address"`
 	ValidatorAddr sdk.ValAddress `json:"validator_address"`
 	PubKey        crypto.PubKey  `json:"pubkey"`
@@ -28,18 +29,19 @@ type MsgCreateValidator struct {
 
 // Default way to create validator. Delegator address and validator address are the same
 func NewMsgCreateValidator(valAddr sdk.ValAddress, pubkey crypto.PubKey,
-	selfDelegation sdk.Coin, description Description) MsgCreateValidator {
+	selfDelegation sdk.Coin, description Description, commission CommissionMsg) MsgCreateValidator {
 
 	return NewMsgCreateValidatorOnBehalfOf(
-		sdk.AccAddress(valAddr), valAddr, pubkey, selfDelegation, description,
+		sdk.AccAddress(valAddr), valAddr, pubkey, selfDelegation, description, commission,
 	)
 }
 
 // Creates validator msg by delegator address on behalf of validator address
 func NewMsgCreateValidatorOnBehalfOf(delAddr sdk.AccAddress, valAddr sdk.ValAddress,
-	pubkey crypto.PubKey, delegation sdk.Coin, description Description) MsgCreateValidator {
+	pubkey crypto.PubKey, delegation sdk.Coin, description Description, commission CommissionMsg) MsgCreateValidator {
 	return MsgCreateValidator{
 		Description:   description,
+		Commission:    commission,
 		DelegatorAddr: delAddr,
 		ValidatorAddr: valAddr,
 		PubKey:        pubkey,
@@ -67,12 +69,14 @@ func (msg MsgCreateValidator) GetSigners() []sdk.AccAddress {
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
+		Commission    CommissionMsg  `json:"commission"`
 		DelegatorAddr sdk.AccAddress `json:"delegator_address"`
 		ValidatorAddr sdk.ValAddress `json:"validator_address"`
 		PubKey        string         `json:"pubkey"`
 		Delegation    sdk.Coin       `json:"delegation"`
 	}{
 		Description:   msg.Description,
+		Commission:    msg.Commission,
 		ValidatorAddr: msg.ValidatorAddr,
 		PubKey:        sdk.MustBech32ifyConsPub(msg.PubKey),
 		Delegation:    msg.Delegation,
@@ -98,6 +102,9 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if msg.Description == empty {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
+	if err := msg.Commission.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -107,12 +114,16 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 type MsgEditValidator struct {
 	Description
 	ValidatorAddr sdk.ValAddress `json:"address"`
+
+	// the new commission rate of the validator, nil if it is not changed
+	CommissionRate *sdk.Dec `json:"commission_rate"`
 }
 
-func NewMsgEditValidator(valAddr sdk.ValAddress, description Description) MsgEditValidator {
+func NewMsgEditValidator(valAddr sdk.ValAddress, description Description, newRate *sdk.Dec) MsgEditValidator {
 	return MsgEditValidator{
-		Description:   description,
-		ValidatorAddr: valAddr,
+		Description:    description,
+		ValidatorAddr:  valAddr,
+		CommissionRate: newRate,
 	}
 }
 
@@ -126,10 +137,12 @@ func (msg MsgEditValidator) GetSigners() []sdk.AccAddress {
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr sdk.ValAddress `json:"address"`
+		ValidatorAddr  sdk.ValAddress `json:"address"`
+		CommissionRate *sdk.Dec       `json:"commission_rate"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: msg.ValidatorAddr,
+		Description:    msg.Description,
+		ValidatorAddr:  msg.ValidatorAddr,
+		CommissionRate: msg.CommissionRate,
 	})
 	if err != nil {
 		panic(err)
@@ -143,9 +156,17 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 	empty := Description{}
-	if msg.Description == empty {
+	if msg.Description == empty && msg.CommissionRate == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+	if msg.CommissionRate != nil {
+		if msg.CommissionRate.LT(sdk.ZeroDec()) {
+			return ErrCommissionNegative(DefaultCodespace)
+		}
+		if msg.CommissionRate.GT(sdk.OneDec()) {
+			return ErrCommissionHuge(DefaultCodespace)
+		}
+	}
 	return nil
 }
 
@@ -13,6 +13,8 @@ var (
 	coinPos  = sdk.NewInt64Coin("steak", 1000)
 	coinZero = sdk.NewInt64Coin("steak", 0)
 	coinNeg  = sdk.NewInt64Coin("steak", -10000)
+
+	commissionMsg = NewCommissionMsg(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2))
 )
 
 // test ValidateBasic for MsgCreateValidator
@@ -36,7 +38,7 @@ func TestMsgCreateValidator(t *testing.T) {
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description)
+		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description, commissionMsg)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -60,7 +62,7 @@ func TestMsgEditValidator(t *testing.T) {
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgEditValidator(tc.validatorAddr, description)
+		msg := NewMsgEditValidator(tc.validatorAddr, description, nil)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -69,6 +71,46 @@ func TestMsgEditValidator(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for the commission parameters of MsgCreateValidator and MsgEditValidator
+func TestMsgCommission(t *testing.T) {
+	tests := []struct {
+		name                         string
+		rate, maxRate, maxChangeRate sdk.Dec
+		expectPass                   bool
+	}{
+		{"basic good", sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2), true},
+		{"zero commission", sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), true},
+		{"missing commission", sdk.Dec{}, sdk.Dec{}, sdk.Dec{}, false},
+		{"negative rate", sdk.NewDecWithPrec(-1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2), false},
+		{"max rate above 100%", sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(11, 1), sdk.NewDecWithPrec(1, 2), false},
+		{"rate above max rate", sdk.NewDecWithPrec(3, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2), false},
+		{"change rate above max rate", sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(3, 1), false},
+	}
+
+	for _, tc := range tests {
+		commission := NewCommissionMsg(tc.rate, tc.maxRate, tc.maxChangeRate)
+		msg := NewMsgCreateValidator(addr1, pk1, coinPos, NewDescription("a", "b", "c", "d"), commission)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+
+	// editing only the commission rate is valid
+	rate := sdk.NewDecWithPrec(1, 1)
+	msg := NewMsgEditValidator(addr1, Description{}, &rate)
+	require.Nil(t, msg.ValidateBasic())
+
+	rate = sdk.NewDecWithPrec(-1, 1)
+	msg = NewMsgEditValidator(addr1, Description{}, &rate)
+	require.NotNil(t, msg.ValidateBasic())
+
+	rate = sdk.NewDecWithPrec(11, 1)
+	msg = NewMsgEditValidator(addr1, Description{}, &rate)
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic and GetSigners for MsgCreateValidatorOnBehalfOf
 func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 	tests := []struct {
@@ -92,7 +134,7 @@ func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidatorOnBehalfOf(tc.delegatorAddr, tc.validatorAddr, tc.validatorPubKey, tc.bond, description)
+		msg := NewMsgCreateValidatorOnBehalfOf(tc.delegatorAddr, tc.validatorAddr, tc.validatorPubKey, tc.bond, description, commissionMsg)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -100,11 +142,11 @@ func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 		}
 	}
 
-	msg := NewMsgCreateValidator(addr1, pk1, coinPos, Description{})
+	msg := NewMsgCreateValidator(addr1, pk1, coinPos, Description{}, commissionMsg)
 	addrs := msg.GetSigners()
 	require.Equal(t, []sdk.AccAddress{sdk.AccAddress(addr1)}, addrs, "Signers on default msg is wrong")
 
-	msg = NewMsgCreateValidatorOnBehalfOf(sdk.AccAddress(addr2), addr1, pk1, coinPos, Description{})
+	msg = NewMsgCreateValidatorOnBehalfOf(sdk.AccAddress(addr2), addr1, pk1, coinPos, Description{}, commissionMsg)
 	addrs = msg.GetSigners()
 	require.Equal(t, []sdk.AccAddress{sdk.AccAddress(addr2), sdk.AccAddress(addr1)}, addrs, "Signers for onbehalfof msg is wrong")
 }
@@ -19,7 +19,8 @@ type Pool struct {
 	DateLastCommissionReset int64 `json:"date_last_commission_reset"` // unix timestamp for last commission accounting reset (daily)
 
 	// Fee Related
-	PrevBondedShares sdk.Dec `json:"prev_bonded_shares"` // last recorded bonded shares - for fee calculations
+	PrevBondedShares    sdk.Dec `json:"prev_bonded_shares"`   // last recorded bonded shares - for fee calculations
+	ProvisionsRemainder sdk.Dec `json:"provisions_remainder"` // fraction of a token of the provisions not yet collected
 }
 
 // nolint
@@ -38,6 +39,7 @@ func InitialPool() Pool {
 		Inflation:               sdk.NewDecWithPrec(7, 2),
 		DateLastCommissionReset: 0,
 		PrevBondedShares:        sdk.ZeroDec(),
+		ProvisionsRemainder:     sdk.ZeroDec(),
 	}
 }
 
@@ -97,6 +99,19 @@ func (p Pool) ProcessProvisions(params Params) Pool {
 	return p
 }
 
+// collect the whole tokens of the provisions added to the loose tokens since
+// they amounted to looseTokens, the fraction of a token left over being carried
+// into the next collection
+func (p Pool) CollectProvisions(looseTokens sdk.Dec) (Pool, sdk.Int) {
+	provisions := p.LooseTokens.Sub(looseTokens)
+	if !p.ProvisionsRemainder.IsNil() {
+		provisions = provisions.Add(p.ProvisionsRemainder)
+	}
+	collected := provisions.TruncateInt()
+	p.ProvisionsRemainder = provisions.Sub(sdk.NewDecFromInt(collected))
+	return p, collected
+}
+
 // get the next inflation rate for the hour
 func (p Pool) NextInflation(params Params) (inflation sdk.Dec) {
 
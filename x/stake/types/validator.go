@@ -36,68 +36,68 @@ type Validator struct {
 	UnbondingHeight  int64     `json:"unbonding_height"` // if unbonding, height at which this validator has begun unbonding
 	UnbondingMinTime time.Time `json:"unbonding_time"`   // if unbonding, min time for the validator to complete unbonding
 
-	Commission            sdk.Dec `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Dec `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Dec `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Dec `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission           sdk.Dec   `json:"commission"`             // the commission rate of fees charged to any delegators
+	CommissionMax        sdk.Dec   `json:"commission_max"`         // maximum commission rate which this validator can ever charge
+	CommissionChangeRate sdk.Dec   `json:"commission_change_rate"` // maximum daily change of the validator commission rate
+	CommissionLastChange time.Time `json:"commission_last_change"` // time of the last commission rate change
 }
 
 // NewValidator - initialize a new validator
 func NewValidator(operator sdk.ValAddress, pubKey crypto.PubKey, description Description) Validator {
 	return Validator{
-		Operator:              operator,
-		PubKey:                pubKey,
-		Jailed:                false,
-		Status:                sdk.Unbonded,
-		Tokens:                sdk.ZeroDec(),
-		DelegatorShares:       sdk.ZeroDec(),
-		Description:           description,
-		BondHeight:            int64(0),
-		BondIntraTxCounter:    int16(0),
-		UnbondingHeight:       int64(0),
-		UnbondingMinTime:      time.Unix(0, 0),
-		Commission:            sdk.ZeroDec(),
-		CommissionMax:         sdk.ZeroDec(),
-		CommissionChangeRate:  sdk.ZeroDec(),
-		CommissionChangeToday: sdk.ZeroDec(),
+		Operator:             operator,
+		PubKey:               pubKey,
+		Jailed:               false,
+		Status:               sdk.Unbonded,
+		Tokens:               sdk.ZeroDec(),
+		DelegatorShares:      sdk.ZeroDec(),
+		Description:          description,
+		BondHeight:           int64(0),
+		BondIntraTxCounter:   int16(0),
+		UnbondingHeight:      int64(0),
+		UnbondingMinTime:     time.Unix(0, 0),
+		Commission:           sdk.ZeroDec(),
+		CommissionMax:        sdk.ZeroDec(),
+		CommissionChangeRate: sdk.ZeroDec(),
+		CommissionLastChange: time.Unix(0, 0),
 	}
 }
 
 // what's kept in the store value
 type validatorValue struct {
-	PubKey                crypto.PubKey
-	Jailed                bool
-	Status                sdk.BondStatus
-	Tokens                sdk.Dec
-	DelegatorShares       sdk.Dec
-	Description           Description
-	BondHeight            int64
-	BondIntraTxCounter    int16
-	UnbondingHeight       int64
-	UnbondingMinTime      time.Time
-	Commission            sdk.Dec
-	CommissionMax         sdk.Dec
-	CommissionChangeRate  sdk.Dec
-	CommissionChangeToday sdk.Dec
+	PubKey               crypto.PubKey
+	Jailed               bool
+	Status               sdk.BondStatus
+	Tokens               sdk.Dec
+	DelegatorShares      sdk.Dec
+	Description          Description
+	BondHeight           int64
+	BondIntraTxCounter   int16
+	UnbondingHeight      int64
+	UnbondingMinTime     time.Time
+	Commission           sdk.Dec
+	CommissionMax        sdk.Dec
+	CommissionChangeRate sdk.Dec
+	CommissionLastChange time.Time
 }
 
 // return the redelegation without fields contained within the key for the store
 func MustMarshalValidator(cdc *wire.Codec, validator Validator) []byte {
 	val := validatorValue{
-		PubKey:                validator.PubKey,
-		Jailed:                validator.Jailed,
-		Status:                validator.Status,
-		Tokens:                validator.Tokens,
-		DelegatorShares:       validator.DelegatorShares,
-		Description:           validator.Description,
-		BondHeight:            validator.BondHeight,
-		BondIntraTxCounter:    validator.BondIntraTxCounter,
-		UnbondingHeight:       validator.UnbondingHeight,
-		UnbondingMinTime:      validator.UnbondingMinTime,
-		Commission:            validator.Commission,
-		CommissionMax:         validator.CommissionMax,
-		CommissionChangeRate:  validator.CommissionChangeRate,
-		CommissionChangeToday: validator.CommissionChangeToday,
+		PubKey:               validator.PubKey,
+		Jailed:               validator.Jailed,
+		Status:               validator.Status,
+		Tokens:               validator.Tokens,
+		DelegatorShares:      validator.DelegatorShares,
+		Description:          validator.Description,
+		BondHeight:           validator.BondHeight,
+		BondIntraTxCounter:   validator.BondIntraTxCounter,
+		UnbondingHeight:      validator.UnbondingHeight,
+		UnbondingMinTime:     validator.UnbondingMinTime,
+		Commission:           validator.Commission,
+		CommissionMax:        validator.CommissionMax,
+		CommissionChangeRate: validator.CommissionChangeRate,
+		CommissionLastChange: validator.CommissionLastChange,
 	}
 	return cdc.MustMarshalBinary(val)
 }
@@ -124,21 +124,21 @@ func UnmarshalValidator(cdc *wire.Codec, operatorAddr, value []byte) (validator
 	}
 
 	return Validator{
-		Operator:              operatorAddr,
-		PubKey:                storeValue.PubKey,
-		Jailed:                storeValue.Jailed,
-		Tokens:                storeValue.Tokens,
-		Status:                storeValue.Status,
-		DelegatorShares:       storeValue.DelegatorShares,
-		Description:           storeValue.Description,
-		BondHeight:            storeValue.BondHeight,
-		BondIntraTxCounter:    storeValue.BondIntraTxCounter,
-		UnbondingHeight:       storeValue.UnbondingHeight,
-		UnbondingMinTime:      storeValue.UnbondingMinTime,
-		Commission:            storeValue.Commission,
-		CommissionMax:         storeValue.CommissionMax,
-		CommissionChangeRate:  storeValue.CommissionChangeRate,
-		CommissionChangeToday: storeValue.CommissionChangeToday,
+		Operator:             operatorAddr,
+		PubKey:               storeValue.PubKey,
+		Jailed:               storeValue.Jailed,
+		Tokens:               storeValue.Tokens,
+		Status:               storeValue.Status,
+		DelegatorShares:      storeValue.DelegatorShares,
+		Description:          storeValue.Description,
+		BondHeight:           storeValue.BondHeight,
+		BondIntraTxCounter:   storeValue.BondIntraTxCounter,
+		UnbondingHeight:      storeValue.UnbondingHeight,
+		UnbondingMinTime:     storeValue.UnbondingMinTime,
+		Commission:           storeValue.Commission,
+		CommissionMax:        storeValue.CommissionMax,
+		CommissionChangeRate: storeValue.CommissionChangeRate,
+		CommissionLastChange: storeValue.CommissionLastChange,
 	}, nil
 }
 
@@ -165,7 +165,7 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Commission: %s\n", v.Commission.String())
 	resp += fmt.Sprintf("Max Commission Rate: %s\n", v.CommissionMax.String())
 	resp += fmt.Sprintf("Commission Change Rate: %s\n", v.CommissionChangeRate.String())
-	resp += fmt.Sprintf("Commission Change Today: %s\n", v.CommissionChangeToday.String())
+	resp += fmt.Sprintf("Commission Last Change: %v\n", v.CommissionLastChange)
 
 	return resp, nil
 }
@@ -189,10 +189,10 @@ type BechValidator struct {
 	UnbondingHeight  int64     `json:"unbonding_height"` // if unbonding, height at which this validator has begun unbonding
 	UnbondingMinTime time.Time `json:"unbonding_time"`   // if unbonding, min time for the validator to complete unbonding
 
-	Commission            sdk.Dec `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Dec `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Dec `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Dec `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission           sdk.Dec   `json:"commission"`             // the commission rate of fees charged to any delegators
+	CommissionMax        sdk.Dec   `json:"commission_max"`         // maximum commission rate which this validator can ever charge
+	CommissionChangeRate sdk.Dec   `json:"commission_change_rate"` // maximum daily change of the validator commission rate
+	CommissionLastChange time.Time `json:"commission_last_change"` // time of the last commission rate change
 }
 
 // get the bech validator from the the regular validator
@@ -217,10 +217,10 @@ func (v Validator) Bech32Validator() (BechValidator, error) {
 		UnbondingHeight:    v.UnbondingHeight,
 		UnbondingMinTime:   v.UnbondingMinTime,
 
-		Commission:            v.Commission,
-		CommissionMax:         v.CommissionMax,
-		CommissionChangeRate:  v.CommissionChangeRate,
-		CommissionChangeToday: v.CommissionChangeToday,
+		Commission:           v.Commission,
+		CommissionMax:        v.CommissionMax,
+		CommissionChangeRate: v.CommissionChangeRate,
+		CommissionLastChange: v.CommissionLastChange,
 	}, nil
 }
 
@@ -238,7 +238,7 @@ func (v Validator) Equal(c2 Validator) bool {
 		v.Commission.Equal(c2.Commission) &&
 		v.CommissionMax.Equal(c2.CommissionMax) &&
 		v.CommissionChangeRate.Equal(c2.CommissionChangeRate) &&
-		v.CommissionChangeToday.Equal(c2.CommissionChangeToday)
+		v.CommissionLastChange.Equal(c2.CommissionLastChange)
 }
 
 // return the TM validator address
@@ -452,3 +452,4 @@ func (v Validator) GetPower() sdk.Dec           { return v.BondedTokens() }
 func (v Validator) GetTokens() sdk.Dec          { return v.Tokens }
 func (v Validator) GetDelegatorShares() sdk.Dec { return v.DelegatorShares }
 func (v Validator) GetBondHeight() int64        { return v.BondHeight }
+func (v Validator) GetCommission() sdk.Dec      { return v.Commission }
@@ -0,0 +1,27 @@
+//nolint
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default upgrade codespace
+	DefaultCodespace sdk.CodespaceType = 7
+
+	CodeInvalidPlan CodeType = 101
+	CodeUpgradeDone CodeType = 102
+)
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, msg)
+}
+
+func ErrUpgradeDone(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeUpgradeDone, fmt.Sprintf("upgrade %s has already been performed", name))
+}
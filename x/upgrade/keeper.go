@@ -0,0 +1,110 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the upgrade store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// upgrade handlers known by this binary
+	handlers map[string]Handler
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an upgrade keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		handlers:  make(map[string]Handler),
+		codespace: codespace,
+	}
+}
+
+// return the codespace
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+// SetUpgradeHandler registers the handler performing the upgrade with the
+// given name. A binary must register the handler of a scheduled upgrade to
+// process blocks past the upgrade height.
+func (k Keeper) SetUpgradeHandler(name string, handler Handler) {
+	if _, ok := k.handlers[name]; ok {
+		panic(fmt.Sprintf("upgrade handler %s already registered", name))
+	}
+	k.handlers[name] = handler
+}
+
+// HasUpgradeHandler returns whether this binary can perform the named upgrade
+func (k Keeper) HasUpgradeHandler(name string) bool {
+	_, ok := k.handlers[name]
+	return ok
+}
+
+//______________________________________________________________________
+
+// ScheduleUpgrade schedules an upgrade, replacing any upgrade scheduled before
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return ErrInvalidPlan(k.codespace, "upgrade cannot be scheduled in the past")
+	}
+	if _, done := k.GetDoneHeight(ctx, plan.Name); done {
+		return ErrUpgradeDone(k.codespace, plan.Name)
+	}
+	k.setUpgradePlan(ctx, plan)
+	return nil
+}
+
+// get the scheduled upgrade plan
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(PlanKey)
+	if b == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(b, &plan)
+	return plan, true
+}
+
+// set the scheduled upgrade plan
+func (k Keeper) setUpgradePlan(ctx sdk.Context, plan Plan) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(plan)
+	store.Set(PlanKey, b)
+}
+
+// ClearUpgradePlan removes the scheduled upgrade plan, if any
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// get the height at which the named upgrade was performed
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) (height int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDoneUpgradeKey(name))
+	if b == nil {
+		return 0, false
+	}
+	k.cdc.MustUnmarshalBinary(b, &height)
+	return height, true
+}
+
+// set the height at which the named upgrade was performed
+func (k Keeper) setDoneHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(height)
+	store.Set(GetDoneUpgradeKey(name), b)
+}
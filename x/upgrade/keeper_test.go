@@ -0,0 +1,81 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyUpgrade, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	return ctx, NewKeeper(wire.NewCodec(), keyUpgrade, DefaultCodespace)
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	ctx = ctx.WithBlockHeight(10)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("", 20)))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 10)))
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 20)))
+
+	plan, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, NewPlan("test", 20), plan)
+
+	// a later plan replaces the scheduled one
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test2", 30)))
+	plan, _ = keeper.GetUpgradePlan(ctx)
+	require.Equal(t, NewPlan("test2", 30), plan)
+}
+
+func TestUpgrader(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	upgrader := NewUpgrader(keeper)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 10)))
+
+	// blocks before the upgrade height are processed
+	ctx = ctx.WithBlockHeight(9)
+	require.Nil(t, upgrader(ctx))
+
+	// the chain halts at the upgrade height without the upgrade handler
+	ctx = ctx.WithBlockHeight(10)
+	require.NotNil(t, upgrader(ctx))
+
+	// the handler is run once at the upgrade height
+	calls := 0
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) { calls++ })
+	require.Nil(t, upgrader(ctx))
+	require.Equal(t, 1, calls)
+
+	ctx = ctx.WithBlockHeight(11)
+	require.Nil(t, upgrader(ctx))
+	require.Equal(t, 1, calls)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	height, found := keeper.GetDoneHeight(ctx, "test")
+	require.True(t, found)
+	require.Equal(t, int64(10), height)
+
+	// a performed upgrade cannot be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 20)))
+}
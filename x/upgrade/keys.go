@@ -0,0 +1,12 @@
+package upgrade
+
+// key prefix bytes
+var (
+	PlanKey        = []byte{0x00} // key for the scheduled upgrade plan
+	DoneUpgradeKey = []byte{0x01} // prefix for the heights of performed upgrades
+)
+
+// key for the height an upgrade was performed at
+func GetDoneUpgradeKey(name string) []byte {
+	return append(DoneUpgradeKey, []byte(name)...)
+}
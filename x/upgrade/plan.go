@@ -0,0 +1,41 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan names a software upgrade and the block height at which the current
+// binary halts unless it knows how to perform the upgrade
+type Plan struct {
+	Name   string `json:"name"`   // name of the upgrade, identifying its upgrade handler
+	Height int64  `json:"height"` // height at which the upgrade is performed
+}
+
+// NewPlan returns a new upgrade plan
+func NewPlan(name string, height int64) Plan {
+	return Plan{
+		Name:   name,
+		Height: height,
+	}
+}
+
+// ValidateBasic performs stateless validation of the plan
+func (p Plan) ValidateBasic() sdk.Error {
+	if len(p.Name) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "upgrade name cannot be empty")
+	}
+	if p.Height <= 0 {
+		return ErrInvalidPlan(DefaultCodespace, "upgrade height must be positive")
+	}
+	return nil
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("Upgrade %s at height %d", p.Name, p.Height)
+}
+
+// Handler migrates the store state of the chain for an upgrade. It is run
+// once, at the beginning of the upgrade height.
+type Handler func(ctx sdk.Context, plan Plan)
@@ -0,0 +1,31 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewUpgrader returns the upgrader performing the scheduled upgrade once its
+// height is reached, to be set on the BaseApp. If the binary has no handler
+// for the upgrade it returns an error, on which the BaseApp halts the chain
+// until the node is restarted with a binary which knows the upgrade.
+func NewUpgrader(k Keeper) sdk.Upgrader {
+	return func(ctx sdk.Context) error {
+		plan, found := k.GetUpgradePlan(ctx)
+		if !found || ctx.BlockHeight() < plan.Height {
+			return nil
+		}
+
+		handler, ok := k.handlers[plan.Name]
+		if !ok {
+			return fmt.Errorf("UPGRADE \"%s\" NEEDED at height %d: this binary does not contain the upgrade handler", plan.Name, plan.Height)
+		}
+
+		ctx.Logger().With("module", "x/upgrade").Info(fmt.Sprintf("performing upgrade %s at height %d", plan.Name, ctx.BlockHeight()))
+		handler(ctx, plan)
+		k.ClearUpgradePlan(ctx)
+		k.setDoneHeight(ctx, plan.Name, ctx.BlockHeight())
+		return nil
+	}
+}